@@ -64,18 +64,21 @@ const (
 	EvidenceType_UNKNOWN             EvidenceType = 0
 	EvidenceType_DUPLICATE_VOTE      EvidenceType = 1
 	EvidenceType_LIGHT_CLIENT_ATTACK EvidenceType = 2
+	EvidenceType_AMNESIA             EvidenceType = 3
 )
 
 var EvidenceType_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "DUPLICATE_VOTE",
 	2: "LIGHT_CLIENT_ATTACK",
+	3: "AMNESIA",
 }
 
 var EvidenceType_value = map[string]int32{
 	"UNKNOWN":             0,
 	"DUPLICATE_VOTE":      1,
 	"LIGHT_CLIENT_ATTACK": 2,
+	"AMNESIA":             3,
 }
 
 func (x EvidenceType) String() string {
@@ -984,8 +987,9 @@ var xxx_messageInfo_RequestListSnapshots proto.InternalMessageInfo
 
 // offers a snapshot to the application
 type RequestOfferSnapshot struct {
-	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
-	AppHash  []byte    `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	Snapshot      *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	AppHash       []byte    `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	AppliedChunks []uint32  `protobuf:"varint,3,rep,packed,name=applied_chunks,json=appliedChunks,proto3" json:"applied_chunks,omitempty"`
 }
 
 func (m *RequestOfferSnapshot) Reset()         { *m = RequestOfferSnapshot{} }
@@ -1035,6 +1039,13 @@ func (m *RequestOfferSnapshot) GetAppHash() []byte {
 	return nil
 }
 
+func (m *RequestOfferSnapshot) GetAppliedChunks() []uint32 {
+	if m != nil {
+		return m.AppliedChunks
+	}
+	return nil
+}
+
 // loads a snapshot chunk
 type RequestLoadSnapshotChunk struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -2184,7 +2195,8 @@ func (m *ResponseListSnapshots) GetSnapshots() []*Snapshot {
 }
 
 type ResponseOfferSnapshot struct {
-	Result ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseOfferSnapshot_Result" json:"result,omitempty"`
+	Result            ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseOfferSnapshot_Result" json:"result,omitempty"`
+	SkipAppliedChunks bool                         `protobuf:"varint,2,opt,name=skip_applied_chunks,json=skipAppliedChunks,proto3" json:"skip_applied_chunks,omitempty"`
 }
 
 func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
@@ -2227,6 +2239,13 @@ func (m *ResponseOfferSnapshot) GetResult() ResponseOfferSnapshot_Result {
 	return ResponseOfferSnapshot_UNKNOWN
 }
 
+func (m *ResponseOfferSnapshot) GetSkipAppliedChunks() bool {
+	if m != nil {
+		return m.SkipAppliedChunks
+	}
+	return false
+}
+
 type ResponseLoadSnapshotChunk struct {
 	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 }
@@ -3065,176 +3084,178 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2691 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0xe2, 0xbd, 0x0d, 0xe2, 0xc1, 0x11, 0x2d, 0xc3, 0xb0, 0x4c, 0x4a, 0xab, 0x92, 0x63,
-	0xc9, 0x36, 0x19, 0x53, 0x25, 0x45, 0x2a, 0x3b, 0xb1, 0x09, 0x08, 0x32, 0x68, 0x32, 0x24, 0xb3,
-	0x84, 0xe4, 0xbc, 0xac, 0xf5, 0x02, 0x3b, 0x04, 0xd6, 0x02, 0x76, 0xd7, 0xd8, 0x01, 0x45, 0xfa,
-	0x98, 0xc7, 0x45, 0xb9, 0xe8, 0x98, 0x8b, 0xab, 0xf2, 0x0f, 0x72, 0xcd, 0x29, 0x97, 0x5c, 0x5c,
-	0x95, 0x4a, 0x95, 0x8f, 0x39, 0x39, 0x29, 0xe9, 0x96, 0x3f, 0x90, 0x53, 0x2a, 0xa9, 0x79, 0xec,
-	0x0b, 0xc0, 0x12, 0x60, 0x9c, 0x5b, 0x6e, 0x3b, 0xbd, 0xdd, 0x8d, 0x99, 0xde, 0xe9, 0xaf, 0xbf,
-	0xe9, 0x01, 0xbc, 0x4a, 0xb0, 0x65, 0xe0, 0xd1, 0xd0, 0xb4, 0xc8, 0x86, 0xde, 0xe9, 0x9a, 0x1b,
-	0xe4, 0xd4, 0xc1, 0xee, 0xba, 0x33, 0xb2, 0x89, 0x8d, 0xca, 0xc1, 0xcb, 0x75, 0xfa, 0xb2, 0xf6,
-	0x5a, 0x48, 0xbb, 0x3b, 0x3a, 0x75, 0x88, 0xbd, 0xe1, 0x8c, 0x6c, 0xfb, 0x88, 0xeb, 0xd7, 0x2e,
-	0x85, 0x5e, 0x33, 0x3f, 0x61, 0x6f, 0x91, 0xb7, 0xc2, 0xf8, 0x31, 0x3e, 0xf5, 0xde, 0xbe, 0x36,
-	0x65, 0xeb, 0xe8, 0x23, 0x7d, 0xe8, 0xbd, 0x5e, 0xeb, 0xd9, 0x76, 0x6f, 0x80, 0x37, 0xd8, 0xa8,
-	0x33, 0x3e, 0xda, 0x20, 0xe6, 0x10, 0xbb, 0x44, 0x1f, 0x3a, 0x42, 0x61, 0xa5, 0x67, 0xf7, 0x6c,
-	0xf6, 0xb8, 0x41, 0x9f, 0xb8, 0x54, 0xf9, 0x4b, 0x0e, 0x72, 0x2a, 0xfe, 0x7c, 0x8c, 0x5d, 0x82,
-	0x36, 0x21, 0x8d, 0xbb, 0x7d, 0xbb, 0x2a, 0x5d, 0x96, 0xde, 0x28, 0x6c, 0x5e, 0x5a, 0x9f, 0x58,
-	0xdc, 0xba, 0xd0, 0x6b, 0x76, 0xfb, 0x76, 0x2b, 0xa1, 0x32, 0x5d, 0x74, 0x0b, 0x32, 0x47, 0x83,
-	0xb1, 0xdb, 0xaf, 0x26, 0x99, 0xd1, 0x6b, 0x71, 0x46, 0xf7, 0xa9, 0x52, 0x2b, 0xa1, 0x72, 0x6d,
-	0xfa, 0x53, 0xa6, 0x75, 0x64, 0x57, 0x53, 0x67, 0xff, 0xd4, 0xb6, 0x75, 0xc4, 0x7e, 0x8a, 0xea,
-	0xa2, 0x3a, 0x80, 0x69, 0x99, 0x44, 0xeb, 0xf6, 0x75, 0xd3, 0xaa, 0xa6, 0x99, 0xe5, 0x95, 0x78,
-	0x4b, 0x93, 0x34, 0xa8, 0x62, 0x2b, 0xa1, 0xca, 0xa6, 0x37, 0xa0, 0xd3, 0xfd, 0x7c, 0x8c, 0x47,
-	0xa7, 0xd5, 0xcc, 0xd9, 0xd3, 0xfd, 0x11, 0x55, 0xa2, 0xd3, 0x65, 0xda, 0xa8, 0x09, 0x85, 0x0e,
-	0xee, 0x99, 0x96, 0xd6, 0x19, 0xd8, 0xdd, 0xc7, 0xd5, 0x2c, 0x33, 0x56, 0xe2, 0x8c, 0xeb, 0x54,
-	0xb5, 0x4e, 0x35, 0x5b, 0x09, 0x15, 0x3a, 0xfe, 0x08, 0xbd, 0x07, 0xf9, 0x6e, 0x1f, 0x77, 0x1f,
-	0x6b, 0xe4, 0xa4, 0x9a, 0x63, 0x3e, 0xd6, 0xe2, 0x7c, 0x34, 0xa8, 0x5e, 0xfb, 0xa4, 0x95, 0x50,
-	0x73, 0x5d, 0xfe, 0x48, 0xd7, 0x6f, 0xe0, 0x81, 0x79, 0x8c, 0x47, 0xd4, 0x3e, 0x7f, 0xf6, 0xfa,
-	0xef, 0x71, 0x4d, 0xe6, 0x41, 0x36, 0xbc, 0x01, 0x7a, 0x1f, 0x64, 0x6c, 0x19, 0x62, 0x19, 0x32,
-	0x73, 0x71, 0x39, 0xf6, 0x3b, 0x5b, 0x86, 0xb7, 0x88, 0x3c, 0x16, 0xcf, 0xe8, 0x0e, 0x64, 0xbb,
-	0xf6, 0x70, 0x68, 0x92, 0x2a, 0x30, 0xeb, 0xd5, 0xd8, 0x05, 0x30, 0xad, 0x56, 0x42, 0x15, 0xfa,
-	0x68, 0x0f, 0x4a, 0x03, 0xd3, 0x25, 0x9a, 0x6b, 0xe9, 0x8e, 0xdb, 0xb7, 0x89, 0x5b, 0x2d, 0x30,
-	0x0f, 0xd7, 0xe2, 0x3c, 0xec, 0x9a, 0x2e, 0x39, 0xf4, 0x94, 0x5b, 0x09, 0xb5, 0x38, 0x08, 0x0b,
-	0xa8, 0x3f, 0xfb, 0xe8, 0x08, 0x8f, 0x7c, 0x87, 0xd5, 0xa5, 0xb3, 0xfd, 0xed, 0x53, 0x6d, 0xcf,
-	0x9e, 0xfa, 0xb3, 0xc3, 0x02, 0xf4, 0x33, 0xb8, 0x30, 0xb0, 0x75, 0xc3, 0x77, 0xa7, 0x75, 0xfb,
-	0x63, 0xeb, 0x71, 0xb5, 0xc8, 0x9c, 0x5e, 0x8f, 0x9d, 0xa4, 0xad, 0x1b, 0x9e, 0x8b, 0x06, 0x35,
-	0x68, 0x25, 0xd4, 0xe5, 0xc1, 0xa4, 0x10, 0x3d, 0x82, 0x15, 0xdd, 0x71, 0x06, 0xa7, 0x93, 0xde,
-	0x4b, 0xcc, 0xfb, 0x8d, 0x38, 0xef, 0x5b, 0xd4, 0x66, 0xd2, 0x3d, 0xd2, 0xa7, 0xa4, 0xf5, 0x1c,
-	0x64, 0x8e, 0xf5, 0xc1, 0x18, 0x2b, 0xdf, 0x81, 0x42, 0x28, 0x4d, 0x51, 0x15, 0x72, 0x43, 0xec,
-	0xba, 0x7a, 0x0f, 0xb3, 0xac, 0x96, 0x55, 0x6f, 0xa8, 0x94, 0x60, 0x29, 0x9c, 0x9a, 0xca, 0x33,
-	0xc9, 0xb7, 0xa4, 0x59, 0x47, 0x2d, 0x8f, 0xf1, 0xc8, 0x35, 0x6d, 0xcb, 0xb3, 0x14, 0x43, 0x74,
-	0x15, 0x8a, 0x6c, 0xff, 0x68, 0xde, 0x7b, 0x9a, 0xfa, 0x69, 0x75, 0x89, 0x09, 0x1f, 0x0a, 0xa5,
-	0x35, 0x28, 0x38, 0x9b, 0x8e, 0xaf, 0x92, 0x62, 0x2a, 0xe0, 0x6c, 0x3a, 0x9e, 0xc2, 0x15, 0x58,
-	0xa2, 0x2b, 0xf5, 0x35, 0xd2, 0xec, 0x47, 0x0a, 0x54, 0x26, 0x54, 0x94, 0x3f, 0x27, 0xa1, 0x32,
-	0x99, 0xce, 0xe8, 0x0e, 0xa4, 0x29, 0xb2, 0x09, 0x90, 0xaa, 0xad, 0x73, 0xd8, 0x5b, 0xf7, 0x60,
-	0x6f, 0xbd, 0xed, 0xc1, 0x5e, 0x3d, 0xff, 0xd5, 0x37, 0x6b, 0x89, 0x67, 0x7f, 0x5b, 0x93, 0x54,
-	0x66, 0x81, 0x5e, 0xa1, 0xd9, 0xa7, 0x9b, 0x96, 0x66, 0x1a, 0x6c, 0xca, 0x32, 0x4d, 0x2d, 0xdd,
-	0xb4, 0xb6, 0x0d, 0xb4, 0x03, 0x95, 0xae, 0x6d, 0xb9, 0xd8, 0x72, 0xc7, 0xae, 0xc6, 0x61, 0x55,
-	0x40, 0xd3, 0x74, 0x76, 0x34, 0x3c, 0xc5, 0x03, 0xa6, 0xa7, 0x96, 0xbb, 0x51, 0x01, 0xba, 0x0f,
-	0x70, 0xac, 0x0f, 0x4c, 0x43, 0x27, 0xf6, 0xc8, 0xad, 0xa6, 0x2f, 0xa7, 0x66, 0xba, 0x79, 0xe8,
-	0xa9, 0x3c, 0x70, 0x0c, 0x9d, 0xe0, 0x7a, 0x9a, 0xce, 0x56, 0x0d, 0x59, 0xa2, 0xd7, 0xa1, 0xac,
-	0x3b, 0x8e, 0xe6, 0x12, 0x9d, 0x60, 0xad, 0x73, 0x4a, 0xb0, 0xcb, 0x50, 0x6b, 0x49, 0x2d, 0xea,
-	0x8e, 0x73, 0x48, 0xa5, 0x75, 0x2a, 0x44, 0xd7, 0xa0, 0x44, 0x01, 0xce, 0xd4, 0x07, 0x5a, 0x1f,
-	0x9b, 0xbd, 0x3e, 0x61, 0xf8, 0x94, 0x52, 0x8b, 0x42, 0xda, 0x62, 0x42, 0xc5, 0xf0, 0x3f, 0x38,
-	0x03, 0x37, 0x84, 0x20, 0x6d, 0xe8, 0x44, 0x67, 0x81, 0x5c, 0x52, 0xd9, 0x33, 0x95, 0x39, 0x3a,
-	0xe9, 0x8b, 0xf0, 0xb0, 0x67, 0x74, 0x11, 0xb2, 0xc2, 0x6d, 0x8a, 0xb9, 0x15, 0x23, 0xb4, 0x02,
-	0x19, 0x67, 0x64, 0x1f, 0x63, 0xf6, 0xe5, 0xf2, 0x2a, 0x1f, 0x28, 0xbf, 0x4a, 0xc2, 0xf2, 0x14,
-	0x0c, 0x52, 0xbf, 0x7d, 0xdd, 0xed, 0x7b, 0xbf, 0x45, 0x9f, 0xd1, 0x6d, 0xea, 0x57, 0x37, 0xf0,
-	0x48, 0x94, 0x8e, 0x6a, 0x38, 0x44, 0xbc, 0x2c, 0xb6, 0xd8, 0x7b, 0x11, 0x1a, 0xa1, 0x8d, 0xf6,
-	0xa1, 0x32, 0xd0, 0x5d, 0xa2, 0x71, 0x58, 0xd1, 0x42, 0x65, 0x64, 0x1a, 0x4c, 0x77, 0x75, 0x0f,
-	0x88, 0xe8, 0x9e, 0x16, 0x8e, 0x4a, 0x83, 0x88, 0x14, 0xa9, 0xb0, 0xd2, 0x39, 0xfd, 0x42, 0xb7,
-	0x88, 0x69, 0x61, 0x6d, 0xea, 0xcb, 0xbd, 0x32, 0xe5, 0xb4, 0x79, 0x6c, 0x1a, 0xd8, 0xea, 0x7a,
-	0x9f, 0xec, 0x82, 0x6f, 0xec, 0x7f, 0x52, 0x57, 0x51, 0xa1, 0x14, 0x05, 0x72, 0x54, 0x82, 0x24,
-	0x39, 0x11, 0x01, 0x48, 0x92, 0x13, 0xf4, 0x5d, 0x48, 0xd3, 0x45, 0xb2, 0xc5, 0x97, 0x66, 0x54,
-	0x40, 0x61, 0xd7, 0x3e, 0x75, 0xb0, 0xca, 0x34, 0x15, 0xc5, 0xcf, 0x06, 0x1f, 0xdc, 0x27, 0xbd,
-	0x2a, 0xd7, 0xa1, 0x3c, 0x81, 0xde, 0xa1, 0xef, 0x27, 0x85, 0xbf, 0x9f, 0x52, 0x86, 0x62, 0x04,
-	0xaa, 0x95, 0x8b, 0xb0, 0x32, 0x0b, 0x79, 0x95, 0xbe, 0x2f, 0x8f, 0x20, 0x28, 0xba, 0x05, 0x79,
-	0x1f, 0x7a, 0x79, 0x36, 0x4e, 0xc7, 0xca, 0x53, 0x56, 0x7d, 0x55, 0x9a, 0x86, 0x74, 0x5b, 0xb3,
-	0xfd, 0x90, 0x64, 0x13, 0xcf, 0xe9, 0x8e, 0xd3, 0xd2, 0xdd, 0xbe, 0xf2, 0x29, 0x54, 0xe3, 0x60,
-	0x75, 0x62, 0x19, 0x69, 0x7f, 0x1b, 0x5e, 0x84, 0xec, 0x91, 0x3d, 0x1a, 0xea, 0x84, 0x39, 0x2b,
-	0xaa, 0x62, 0x44, 0xb7, 0x27, 0x87, 0xd8, 0x14, 0x13, 0xf3, 0x81, 0xa2, 0xc1, 0x2b, 0xb1, 0xd0,
-	0x4a, 0x4d, 0x4c, 0xcb, 0xc0, 0x3c, 0x9e, 0x45, 0x95, 0x0f, 0x02, 0x47, 0x7c, 0xb2, 0x7c, 0x40,
-	0x7f, 0xd6, 0x65, 0x6b, 0x65, 0xfe, 0x65, 0x55, 0x8c, 0x94, 0xdf, 0xe5, 0x21, 0xaf, 0x62, 0xd7,
-	0xa1, 0x98, 0x80, 0xea, 0x20, 0xe3, 0x93, 0x2e, 0x76, 0x88, 0x87, 0xa2, 0xb3, 0x49, 0x03, 0xd7,
-	0x6e, 0x7a, 0x9a, 0xb4, 0x62, 0xfb, 0x66, 0xe8, 0xa6, 0x20, 0x65, 0xf1, 0xfc, 0x4a, 0x98, 0x87,
-	0x59, 0xd9, 0x6d, 0x8f, 0x95, 0xa5, 0x62, 0x8b, 0x34, 0xb7, 0x9a, 0xa0, 0x65, 0x37, 0x05, 0x2d,
-	0x4b, 0xcf, 0xf9, 0xb1, 0x08, 0x2f, 0x6b, 0x44, 0x78, 0x59, 0x66, 0xce, 0x32, 0x63, 0x88, 0xd9,
-	0x6d, 0x8f, 0x98, 0x65, 0xe7, 0xcc, 0x78, 0x82, 0x99, 0xdd, 0x8f, 0x32, 0x33, 0xce, 0xaa, 0xae,
-	0xc6, 0x5a, 0xc7, 0x52, 0xb3, 0xef, 0x87, 0xa8, 0x59, 0x3e, 0x96, 0x17, 0x71, 0x27, 0x33, 0xb8,
-	0x59, 0x23, 0xc2, 0xcd, 0xe4, 0x39, 0x31, 0x88, 0x21, 0x67, 0x1f, 0x84, 0xc9, 0x19, 0xc4, 0xf2,
-	0x3b, 0xf1, 0xbd, 0x67, 0xb1, 0xb3, 0xbb, 0x3e, 0x3b, 0x2b, 0xc4, 0xd2, 0x4b, 0xb1, 0x86, 0x49,
-	0x7a, 0xb6, 0x3f, 0x45, 0xcf, 0x38, 0x9d, 0x7a, 0x3d, 0xd6, 0xc5, 0x1c, 0x7e, 0xb6, 0x3f, 0xc5,
-	0xcf, 0x8a, 0x73, 0x1c, 0xce, 0x21, 0x68, 0x3f, 0x9f, 0x4d, 0xd0, 0xe2, 0x29, 0x94, 0x98, 0xe6,
-	0x62, 0x0c, 0x4d, 0x8b, 0x61, 0x68, 0x65, 0xe6, 0xfe, 0xcd, 0x58, 0xf7, 0xe7, 0xa7, 0x68, 0xd7,
-	0x69, 0x85, 0x9c, 0xc8, 0x79, 0x8a, 0x32, 0x78, 0x34, 0xb2, 0x47, 0x82, 0x6c, 0xf1, 0x81, 0xf2,
-	0x06, 0xad, 0xd9, 0x41, 0x7e, 0x9f, 0x41, 0xe7, 0x18, 0x9a, 0x87, 0x72, 0x5a, 0xf9, 0x83, 0x14,
-	0xd8, 0xb2, 0x32, 0x17, 0xae, 0xf7, 0xb2, 0xa8, 0xf7, 0x21, 0x92, 0x97, 0x8c, 0x92, 0xbc, 0x35,
-	0x28, 0x50, 0x94, 0x9e, 0xe0, 0x6f, 0xba, 0xe3, 0xf3, 0xb7, 0x1b, 0xb0, 0xcc, 0xca, 0x30, 0xa7,
-	0x82, 0x02, 0x9a, 0xd3, 0xac, 0xc2, 0x94, 0xe9, 0x0b, 0xbe, 0x39, 0x39, 0x46, 0xbf, 0x0d, 0x17,
-	0x42, 0xba, 0x3e, 0xfa, 0x73, 0x36, 0x53, 0xf1, 0xb5, 0xb7, 0x44, 0x19, 0xf8, 0x93, 0x14, 0x44,
-	0x28, 0x20, 0x7e, 0xb3, 0x38, 0x9a, 0xf4, 0xbf, 0xe1, 0x68, 0xc9, 0xff, 0x9a, 0xa3, 0x85, 0x8b,
-	0x59, 0x2a, 0x5a, 0xcc, 0xfe, 0x29, 0x05, 0x9f, 0xc4, 0x67, 0x5c, 0x5d, 0xdb, 0xc0, 0xa2, 0xbc,
-	0xb0, 0x67, 0x54, 0x81, 0xd4, 0xc0, 0xee, 0x89, 0x22, 0x42, 0x1f, 0xa9, 0x96, 0x8f, 0xc1, 0xb2,
-	0x80, 0x58, 0xbf, 0x32, 0x65, 0x58, 0x80, 0x45, 0x65, 0xaa, 0x40, 0xea, 0x31, 0xe6, 0x88, 0xb9,
-	0xa4, 0xd2, 0x47, 0xaa, 0xc7, 0xf6, 0x18, 0xc3, 0xc1, 0x25, 0x95, 0x0f, 0xd0, 0x1d, 0x90, 0x59,
-	0x13, 0x42, 0xb3, 0x1d, 0x57, 0x80, 0xdb, 0xab, 0xe1, 0xb5, 0xf2, 0x5e, 0xc3, 0xfa, 0x01, 0xd5,
-	0xd9, 0x77, 0x5c, 0x35, 0xef, 0x88, 0xa7, 0x50, 0xd1, 0x95, 0x23, 0xdc, 0xef, 0x12, 0xc8, 0x74,
-	0xf6, 0xae, 0xa3, 0x77, 0x31, 0x43, 0x2a, 0x59, 0x0d, 0x04, 0xca, 0x23, 0x40, 0xd3, 0x78, 0x8b,
-	0x5a, 0x90, 0xc5, 0xc7, 0xd8, 0x22, 0xf4, 0xab, 0xd1, 0x70, 0x5f, 0x9c, 0x41, 0xac, 0xb0, 0x45,
-	0xea, 0x55, 0x1a, 0xe4, 0x7f, 0x7c, 0xb3, 0x56, 0xe1, 0xda, 0x6f, 0xd9, 0x43, 0x93, 0xe0, 0xa1,
-	0x43, 0x4e, 0x55, 0x61, 0xaf, 0xfc, 0x32, 0x49, 0x59, 0x4e, 0x04, 0x8b, 0x67, 0xc6, 0xd6, 0xdb,
-	0xf1, 0xc9, 0x10, 0xc3, 0x5d, 0x2c, 0xde, 0xab, 0x00, 0x3d, 0xdd, 0xd5, 0x9e, 0xe8, 0x16, 0xc1,
-	0x86, 0x08, 0x7a, 0x48, 0x82, 0x6a, 0x90, 0xa7, 0xa3, 0xb1, 0x8b, 0x0d, 0x41, 0xb6, 0xfd, 0x71,
-	0x68, 0x9d, 0xb9, 0x6f, 0xb7, 0xce, 0x68, 0x94, 0xf3, 0x93, 0x51, 0xfe, 0x75, 0x32, 0xc8, 0x92,
-	0x80, 0x10, 0xfe, 0xff, 0xc5, 0xe1, 0x37, 0xec, 0x94, 0x18, 0x2d, 0x8a, 0xe8, 0x10, 0x96, 0xfd,
-	0x2c, 0xd5, 0xc6, 0x2c, 0x7b, 0xbd, 0x7d, 0xb7, 0x68, 0x9a, 0x57, 0x8e, 0xa3, 0x62, 0x17, 0xfd,
-	0x18, 0x5e, 0x9e, 0x40, 0x20, 0xdf, 0x75, 0x72, 0x41, 0x20, 0x7a, 0x29, 0x0a, 0x44, 0x9e, 0xe7,
-	0x20, 0x56, 0xa9, 0x6f, 0x99, 0x1b, 0xdb, 0xf4, 0xe0, 0x11, 0x2e, 0xf1, 0x33, 0xbf, 0xfe, 0x55,
-	0x28, 0x8e, 0x30, 0xa1, 0x67, 0xe1, 0xc8, 0xd1, 0x6e, 0x89, 0x0b, 0xc5, 0x81, 0xf1, 0x00, 0x5e,
-	0x9a, 0x59, 0xea, 0xd1, 0xf7, 0x40, 0x0e, 0x58, 0x82, 0x14, 0x73, 0x4a, 0xf2, 0x99, 0x7f, 0xa0,
-	0xab, 0xfc, 0x51, 0x0a, 0x5c, 0x46, 0xcf, 0x12, 0x4d, 0xc8, 0x8e, 0xb0, 0x3b, 0x1e, 0x70, 0x76,
-	0x5f, 0xda, 0x7c, 0x7b, 0x31, 0x92, 0x40, 0xa5, 0xe3, 0x01, 0x51, 0x85, 0xb1, 0xf2, 0x08, 0xb2,
-	0x5c, 0x82, 0x0a, 0x90, 0x7b, 0xb0, 0xb7, 0xb3, 0xb7, 0xff, 0xf1, 0x5e, 0x25, 0x81, 0x00, 0xb2,
-	0x5b, 0x8d, 0x46, 0xf3, 0xa0, 0x5d, 0x91, 0x90, 0x0c, 0x99, 0xad, 0xfa, 0xbe, 0xda, 0xae, 0x24,
-	0xa9, 0x58, 0x6d, 0x7e, 0xd4, 0x6c, 0xb4, 0x2b, 0x29, 0xb4, 0x0c, 0x45, 0xfe, 0xac, 0xdd, 0xdf,
-	0x57, 0x7f, 0xb8, 0xd5, 0xae, 0xa4, 0x43, 0xa2, 0xc3, 0xe6, 0xde, 0xbd, 0xa6, 0x5a, 0xc9, 0x28,
-	0xef, 0xd0, 0xe3, 0x43, 0x0c, 0xad, 0x08, 0x0e, 0x0a, 0x52, 0xe8, 0xa0, 0xa0, 0xfc, 0x36, 0x09,
-	0xb5, 0x78, 0xae, 0x80, 0x3e, 0x9a, 0x58, 0xf8, 0xe6, 0x39, 0x88, 0xc6, 0xc4, 0xea, 0xd1, 0x35,
-	0x28, 0x8d, 0xf0, 0x11, 0x26, 0xdd, 0x3e, 0xe7, 0x2e, 0xbc, 0xb0, 0x15, 0xd5, 0xa2, 0x90, 0x32,
-	0x23, 0x97, 0xab, 0x7d, 0x86, 0xbb, 0x44, 0xe3, 0x67, 0x16, 0xbe, 0xe9, 0x64, 0xaa, 0x46, 0xa5,
-	0x87, 0x5c, 0xa8, 0x7c, 0x7a, 0xae, 0x58, 0xca, 0x90, 0x51, 0x9b, 0x6d, 0xf5, 0x27, 0x95, 0x14,
-	0x42, 0x50, 0x62, 0x8f, 0xda, 0xe1, 0xde, 0xd6, 0xc1, 0x61, 0x6b, 0x9f, 0xc6, 0xf2, 0x02, 0x94,
-	0xbd, 0x58, 0x7a, 0xc2, 0x8c, 0xf2, 0x6f, 0x09, 0xca, 0x13, 0x09, 0x82, 0x36, 0x21, 0xc3, 0xf9,
-	0x6f, 0x5c, 0x13, 0x9a, 0xe5, 0xb7, 0xc8, 0x26, 0xae, 0x8a, 0xde, 0x83, 0x3c, 0x16, 0x67, 0xf2,
-	0x59, 0x89, 0xc8, 0x7b, 0x09, 0xde, 0xa9, 0x5d, 0x98, 0xfa, 0x16, 0xe8, 0x7d, 0x90, 0xfd, 0x4c,
-	0x17, 0xe7, 0xa5, 0x2b, 0xd3, 0xe6, 0x3e, 0x46, 0x08, 0xfb, 0xc0, 0x06, 0xdd, 0x0d, 0x48, 0x54,
-	0x7a, 0x9a, 0x75, 0x0b, 0x73, 0xae, 0x20, 0x8c, 0x3d, 0x7d, 0xa5, 0x01, 0x85, 0xd0, 0x7a, 0xd0,
-	0xab, 0x20, 0x0f, 0xf5, 0x13, 0xd1, 0xeb, 0xe1, 0xa7, 0xf5, 0xfc, 0x50, 0x3f, 0xe1, 0x6d, 0x9e,
-	0x97, 0x21, 0x47, 0x5f, 0xf6, 0x74, 0x8e, 0x36, 0x29, 0x35, 0x3b, 0xd4, 0x4f, 0x3e, 0xd4, 0x5d,
-	0xe5, 0x13, 0x28, 0x45, 0xfb, 0x1c, 0x74, 0x27, 0x8e, 0xec, 0xb1, 0x65, 0x30, 0x1f, 0x19, 0x95,
-	0x0f, 0xd0, 0x2d, 0xc8, 0x1c, 0xdb, 0x1c, 0xac, 0x66, 0xa7, 0xec, 0x43, 0x9b, 0xe0, 0x50, 0x9f,
-	0x84, 0x6b, 0x2b, 0x5f, 0x40, 0x86, 0x81, 0x0f, 0x05, 0x12, 0xd6, 0xb1, 0x10, 0x04, 0x92, 0x3e,
-	0xa3, 0x4f, 0x00, 0x74, 0x42, 0x46, 0x66, 0x67, 0x1c, 0x38, 0x5e, 0x9b, 0x0d, 0x5e, 0x5b, 0x9e,
-	0x5e, 0xfd, 0x92, 0x40, 0xb1, 0x95, 0xc0, 0x34, 0x84, 0x64, 0x21, 0x87, 0xca, 0x1e, 0x94, 0xa2,
-	0xb6, 0x1e, 0xe7, 0x91, 0x66, 0x70, 0x9e, 0x64, 0x98, 0xf3, 0xf8, 0x8c, 0x29, 0xc5, 0xbb, 0x53,
-	0x6c, 0xa0, 0x3c, 0x95, 0x20, 0xdf, 0x3e, 0x11, 0xdb, 0x3a, 0xa6, 0x31, 0x12, 0x98, 0x26, 0xc3,
-	0x6d, 0x00, 0xde, 0x69, 0x49, 0xf9, 0xfd, 0x9b, 0x0f, 0xfc, 0xc4, 0x4d, 0x2f, 0x7a, 0xda, 0xf3,
-	0x1a, 0x59, 0x02, 0xac, 0xde, 0x05, 0xd9, 0xdf, 0x55, 0x94, 0x89, 0xeb, 0x86, 0x31, 0xc2, 0xae,
-	0x2b, 0xd6, 0xe6, 0x0d, 0x59, 0x9f, 0xcd, 0x7e, 0x22, 0x1a, 0x0d, 0x29, 0x95, 0x0f, 0x14, 0x03,
-	0xca, 0x13, 0x65, 0x0b, 0xbd, 0x0b, 0x39, 0x67, 0xdc, 0xd1, 0xbc, 0xf0, 0x4c, 0x24, 0x8f, 0x47,
-	0xf2, 0xc6, 0x9d, 0x81, 0xd9, 0xdd, 0xc1, 0xa7, 0xde, 0x64, 0x9c, 0x71, 0x67, 0x87, 0x47, 0x91,
-	0xff, 0x4a, 0x32, 0xfc, 0x2b, 0xc7, 0x90, 0xf7, 0x36, 0x05, 0xfa, 0x41, 0x38, 0x4f, 0xbc, 0xee,
-	0x6b, 0x6c, 0x29, 0x15, 0xee, 0x43, 0x69, 0x72, 0x03, 0x96, 0x5d, 0xb3, 0x67, 0x61, 0x43, 0x0b,
-	0xce, 0x02, 0xec, 0xd7, 0xf2, 0x6a, 0x99, 0xbf, 0xd8, 0xf5, 0x0e, 0x02, 0xca, 0xbf, 0x24, 0xc8,
-	0x7b, 0x09, 0x8b, 0xde, 0x09, 0xed, 0xbb, 0xd2, 0x8c, 0xa6, 0x84, 0xa7, 0x18, 0xb4, 0xca, 0xa2,
-	0x73, 0x4d, 0x9e, 0x7f, 0xae, 0x71, 0x3d, 0x4f, 0xaf, 0xf9, 0x9c, 0x3e, 0x77, 0xf3, 0xf9, 0x2d,
-	0x40, 0xc4, 0x26, 0xfa, 0x40, 0x3b, 0xb6, 0x89, 0x69, 0xf5, 0x34, 0x1e, 0x6c, 0xce, 0xa8, 0x2a,
-	0xec, 0xcd, 0x43, 0xf6, 0xe2, 0x80, 0xc5, 0xfd, 0x17, 0x12, 0xe4, 0xfd, 0xda, 0x78, 0xde, 0xce,
-	0xd7, 0x45, 0xc8, 0x0a, 0xf8, 0xe7, 0xad, 0x2f, 0x31, 0xf2, 0x9b, 0xb0, 0xe9, 0x50, 0x13, 0xb6,
-	0x06, 0xf9, 0x21, 0x26, 0x3a, 0x23, 0x08, 0xfc, 0x38, 0xe6, 0x8f, 0x6f, 0xdc, 0x85, 0x42, 0xa8,
-	0x09, 0x49, 0x33, 0x6f, 0xaf, 0xf9, 0x71, 0x25, 0x51, 0xcb, 0x3d, 0xfd, 0xf2, 0x72, 0x6a, 0x0f,
-	0x3f, 0xa1, 0x7b, 0x56, 0x6d, 0x36, 0x5a, 0xcd, 0xc6, 0x4e, 0x45, 0xaa, 0x15, 0x9e, 0x7e, 0x79,
-	0x39, 0xa7, 0x62, 0xd6, 0x10, 0xb9, 0xd1, 0x82, 0xa5, 0xf0, 0x57, 0x89, 0x56, 0x10, 0x04, 0xa5,
-	0x7b, 0x0f, 0x0e, 0x76, 0xb7, 0x1b, 0x5b, 0xed, 0xa6, 0xf6, 0x70, 0xbf, 0xdd, 0xac, 0x48, 0xe8,
-	0x65, 0xb8, 0xb0, 0xbb, 0xfd, 0x61, 0xab, 0xad, 0x35, 0x76, 0xb7, 0x9b, 0x7b, 0x6d, 0x6d, 0xab,
-	0xdd, 0xde, 0x6a, 0xec, 0x54, 0x92, 0x9b, 0xbf, 0x97, 0xa1, 0xbc, 0x55, 0x6f, 0x6c, 0xd3, 0xea,
-	0x67, 0x76, 0x75, 0x76, 0x56, 0x6e, 0x40, 0x9a, 0x9d, 0x86, 0xcf, 0xbc, 0xa1, 0xac, 0x9d, 0xdd,
-	0x2a, 0x43, 0xf7, 0x21, 0xc3, 0x0e, 0xca, 0xe8, 0xec, 0x2b, 0xcb, 0xda, 0x9c, 0xde, 0x19, 0x9d,
-	0x0c, 0x4b, 0x8f, 0x33, 0xef, 0x30, 0x6b, 0x67, 0xb7, 0xd2, 0x90, 0x0a, 0x72, 0x40, 0xe1, 0xe7,
-	0xdf, 0xe9, 0xd5, 0x16, 0x00, 0x1b, 0xb4, 0x0b, 0x39, 0xef, 0x70, 0x34, 0xef, 0x96, 0xb1, 0x36,
-	0xb7, 0xd7, 0x45, 0xc3, 0xc5, 0x0f, 0xb1, 0x67, 0x5f, 0x99, 0xd6, 0xe6, 0x34, 0xee, 0xd0, 0x36,
-	0x64, 0x05, 0x2f, 0x9d, 0x73, 0x73, 0x58, 0x9b, 0xd7, 0xbb, 0xa2, 0x41, 0x0b, 0xba, 0x03, 0xf3,
-	0x2f, 0x82, 0x6b, 0x0b, 0xf4, 0x24, 0xd1, 0x03, 0x80, 0xd0, 0x91, 0x75, 0x81, 0x1b, 0xde, 0xda,
-	0x22, 0xbd, 0x46, 0xb4, 0x0f, 0x79, 0xff, 0x68, 0x32, 0xf7, 0xbe, 0xb5, 0x36, 0xbf, 0xe9, 0x87,
-	0x1e, 0x41, 0x31, 0xca, 0xc9, 0x17, 0xbb, 0x45, 0xad, 0x2d, 0xd8, 0xcd, 0xa3, 0xfe, 0xa3, 0x04,
-	0x7d, 0xb1, 0x5b, 0xd5, 0xda, 0x82, 0xcd, 0x3d, 0xf4, 0x19, 0x2c, 0x4f, 0x13, 0xe8, 0xc5, 0x2f,
-	0x59, 0x6b, 0xe7, 0x68, 0xf7, 0xa1, 0x21, 0xa0, 0x19, 0xc4, 0xfb, 0x1c, 0x77, 0xae, 0xb5, 0xf3,
-	0x74, 0xff, 0xea, 0xcd, 0xaf, 0x9e, 0xaf, 0x4a, 0x5f, 0x3f, 0x5f, 0x95, 0xfe, 0xfe, 0x7c, 0x55,
-	0x7a, 0xf6, 0x62, 0x35, 0xf1, 0xf5, 0x8b, 0xd5, 0xc4, 0x5f, 0x5f, 0xac, 0x26, 0x7e, 0xfa, 0x66,
-	0xcf, 0x24, 0xfd, 0x71, 0x67, 0xbd, 0x6b, 0x0f, 0x37, 0xc2, 0x7f, 0xe6, 0x98, 0xf5, 0x07, 0x93,
-	0x4e, 0x96, 0x15, 0x95, 0x9b, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x1b, 0xc1, 0x1e, 0x80,
-	0x22, 0x00, 0x00,
+	// 2732 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0xdb, 0xc6,
+	0x15, 0x27, 0xf8, 0xcd, 0xc7, 0x4f, 0xad, 0x64, 0x9b, 0xa6, 0x6d, 0xc9, 0x81, 0xc7, 0xa9, 0xed,
+	0x24, 0x52, 0x23, 0x4f, 0x5c, 0x7b, 0xd2, 0x8f, 0x90, 0x34, 0x1d, 0x2a, 0x52, 0x24, 0x15, 0xa2,
+	0x9d, 0x7e, 0xc5, 0x08, 0x48, 0xac, 0x48, 0x44, 0x24, 0x80, 0x10, 0xa0, 0x2c, 0xe5, 0xd8, 0x8f,
+	0x8b, 0x7b, 0xa8, 0x8f, 0xbd, 0x64, 0xa6, 0xff, 0x41, 0xaf, 0xbd, 0xf7, 0x92, 0x99, 0x4e, 0x67,
+	0x72, 0xec, 0x29, 0xed, 0xd8, 0xb7, 0x1e, 0x7a, 0xed, 0xa9, 0xd3, 0xce, 0x7e, 0x81, 0x00, 0x49,
+	0x88, 0x54, 0xd3, 0x5b, 0x6f, 0xd8, 0x87, 0xf7, 0x1e, 0x77, 0x1f, 0xf6, 0xfd, 0xde, 0x6f, 0xdf,
+	0x12, 0xae, 0xb8, 0xd8, 0xd4, 0xf1, 0x70, 0x60, 0x98, 0xee, 0x86, 0xd6, 0xee, 0x18, 0x1b, 0xee,
+	0xa9, 0x8d, 0x9d, 0x75, 0x7b, 0x68, 0xb9, 0x16, 0x2a, 0x8e, 0x5f, 0xae, 0x93, 0x97, 0x95, 0x6b,
+	0x3e, 0xed, 0xce, 0xf0, 0xd4, 0x76, 0xad, 0x0d, 0x7b, 0x68, 0x59, 0x87, 0x4c, 0xbf, 0x72, 0xd5,
+	0xf7, 0x9a, 0xfa, 0xf1, 0x7b, 0xab, 0x5c, 0x9d, 0x36, 0x3e, 0xc2, 0xa7, 0xe2, 0xed, 0xb5, 0x29,
+	0x5b, 0x5b, 0x1b, 0x6a, 0x03, 0xf1, 0x7a, 0xad, 0x6b, 0x59, 0xdd, 0x3e, 0xde, 0xa0, 0xa3, 0xf6,
+	0xe8, 0x70, 0xc3, 0x35, 0x06, 0xd8, 0x71, 0xb5, 0x81, 0xcd, 0x15, 0x56, 0xba, 0x56, 0xd7, 0xa2,
+	0x8f, 0x1b, 0xe4, 0x89, 0x49, 0xe5, 0x3f, 0xa7, 0x20, 0xa5, 0xe0, 0xcf, 0x46, 0xd8, 0x71, 0xd1,
+	0x26, 0xc4, 0x71, 0xa7, 0x67, 0x95, 0xa5, 0xeb, 0xd2, 0xad, 0xec, 0xe6, 0xd5, 0xf5, 0x89, 0xc5,
+	0xad, 0x73, 0xbd, 0x46, 0xa7, 0x67, 0x35, 0x23, 0x0a, 0xd5, 0x45, 0xef, 0x40, 0xe2, 0xb0, 0x3f,
+	0x72, 0x7a, 0xe5, 0x28, 0x35, 0xba, 0x16, 0x66, 0xf4, 0x88, 0x28, 0x35, 0x23, 0x0a, 0xd3, 0x26,
+	0x3f, 0x65, 0x98, 0x87, 0x56, 0x39, 0x76, 0xf6, 0x4f, 0x6d, 0x99, 0x87, 0xf4, 0xa7, 0x88, 0x2e,
+	0xaa, 0x01, 0x18, 0xa6, 0xe1, 0xaa, 0x9d, 0x9e, 0x66, 0x98, 0xe5, 0x38, 0xb5, 0x7c, 0x2d, 0xdc,
+	0xd2, 0x70, 0xeb, 0x44, 0xb1, 0x19, 0x51, 0x32, 0x86, 0x18, 0x90, 0xe9, 0x7e, 0x36, 0xc2, 0xc3,
+	0xd3, 0x72, 0xe2, 0xec, 0xe9, 0xfe, 0x90, 0x28, 0x91, 0xe9, 0x52, 0x6d, 0xd4, 0x80, 0x6c, 0x1b,
+	0x77, 0x0d, 0x53, 0x6d, 0xf7, 0xad, 0xce, 0x51, 0x39, 0x49, 0x8d, 0xe5, 0x30, 0xe3, 0x1a, 0x51,
+	0xad, 0x11, 0xcd, 0x66, 0x44, 0x81, 0xb6, 0x37, 0x42, 0xdf, 0x85, 0x74, 0xa7, 0x87, 0x3b, 0x47,
+	0xaa, 0x7b, 0x52, 0x4e, 0x51, 0x1f, 0x6b, 0x61, 0x3e, 0xea, 0x44, 0xaf, 0x75, 0xd2, 0x8c, 0x28,
+	0xa9, 0x0e, 0x7b, 0x24, 0xeb, 0xd7, 0x71, 0xdf, 0x38, 0xc6, 0x43, 0x62, 0x9f, 0x3e, 0x7b, 0xfd,
+	0x0f, 0x99, 0x26, 0xf5, 0x90, 0xd1, 0xc5, 0x00, 0xfd, 0x00, 0x32, 0xd8, 0xd4, 0xf9, 0x32, 0x32,
+	0xd4, 0xc5, 0xf5, 0xd0, 0xef, 0x6c, 0xea, 0x62, 0x11, 0x69, 0xcc, 0x9f, 0xd1, 0x7d, 0x48, 0x76,
+	0xac, 0xc1, 0xc0, 0x70, 0xcb, 0x40, 0xad, 0x57, 0x43, 0x17, 0x40, 0xb5, 0x9a, 0x11, 0x85, 0xeb,
+	0xa3, 0x5d, 0x28, 0xf4, 0x0d, 0xc7, 0x55, 0x1d, 0x53, 0xb3, 0x9d, 0x9e, 0xe5, 0x3a, 0xe5, 0x2c,
+	0xf5, 0x70, 0x33, 0xcc, 0xc3, 0x8e, 0xe1, 0xb8, 0x07, 0x42, 0xb9, 0x19, 0x51, 0xf2, 0x7d, 0xbf,
+	0x80, 0xf8, 0xb3, 0x0e, 0x0f, 0xf1, 0xd0, 0x73, 0x58, 0xce, 0x9d, 0xed, 0x6f, 0x8f, 0x68, 0x0b,
+	0x7b, 0xe2, 0xcf, 0xf2, 0x0b, 0xd0, 0x4f, 0x61, 0xb9, 0x6f, 0x69, 0xba, 0xe7, 0x4e, 0xed, 0xf4,
+	0x46, 0xe6, 0x51, 0x39, 0x4f, 0x9d, 0xde, 0x0e, 0x9d, 0xa4, 0xa5, 0xe9, 0xc2, 0x45, 0x9d, 0x18,
+	0x34, 0x23, 0xca, 0x52, 0x7f, 0x52, 0x88, 0x9e, 0xc2, 0x8a, 0x66, 0xdb, 0xfd, 0xd3, 0x49, 0xef,
+	0x05, 0xea, 0xfd, 0x4e, 0x98, 0xf7, 0x2a, 0xb1, 0x99, 0x74, 0x8f, 0xb4, 0x29, 0x69, 0x2d, 0x05,
+	0x89, 0x63, 0xad, 0x3f, 0xc2, 0xf2, 0xb7, 0x20, 0xeb, 0x4b, 0x53, 0x54, 0x86, 0xd4, 0x00, 0x3b,
+	0x8e, 0xd6, 0xc5, 0x34, 0xab, 0x33, 0x8a, 0x18, 0xca, 0x05, 0xc8, 0xf9, 0x53, 0x53, 0x7e, 0x21,
+	0x41, 0xd6, 0x97, 0x75, 0xc4, 0xf2, 0x18, 0x0f, 0x1d, 0xc3, 0x32, 0x85, 0x25, 0x1f, 0xa2, 0x1b,
+	0x90, 0xa7, 0xfb, 0x47, 0x15, 0xef, 0x49, 0xea, 0xc7, 0x95, 0x1c, 0x15, 0x3e, 0xe1, 0x4a, 0x6b,
+	0x90, 0xb5, 0x37, 0x6d, 0x4f, 0x25, 0x46, 0x55, 0xc0, 0xde, 0xb4, 0x85, 0xc2, 0x6b, 0x90, 0x23,
+	0x2b, 0xf5, 0x34, 0xe2, 0xf4, 0x47, 0xb2, 0x44, 0xc6, 0x55, 0xe4, 0x3f, 0x45, 0xa1, 0x34, 0x99,
+	0xce, 0xe8, 0x3e, 0xc4, 0x09, 0xb2, 0x71, 0x90, 0xaa, 0xac, 0x33, 0xd8, 0x5b, 0x17, 0xb0, 0xb7,
+	0xde, 0x12, 0xb0, 0x57, 0x4b, 0x7f, 0xf9, 0xf5, 0x5a, 0xe4, 0xc5, 0x5f, 0xd7, 0x24, 0x85, 0x5a,
+	0xa0, 0xcb, 0x24, 0xfb, 0x34, 0xc3, 0x54, 0x0d, 0x9d, 0x4e, 0x39, 0x43, 0x52, 0x4b, 0x33, 0xcc,
+	0x2d, 0x1d, 0x6d, 0x43, 0xa9, 0x63, 0x99, 0x0e, 0x36, 0x9d, 0x91, 0xa3, 0x32, 0x58, 0x2d, 0xc7,
+	0x42, 0xb2, 0xa3, 0x2e, 0x14, 0xf7, 0xa9, 0x9e, 0x52, 0xec, 0x04, 0x05, 0xe8, 0x11, 0xc0, 0xb1,
+	0xd6, 0x37, 0x74, 0xcd, 0xb5, 0x86, 0x4e, 0x39, 0x7e, 0x3d, 0x36, 0xd3, 0xcd, 0x13, 0xa1, 0xf2,
+	0xd8, 0xd6, 0x35, 0x17, 0xd7, 0xe2, 0x64, 0xb6, 0x8a, 0xcf, 0x12, 0xbd, 0x0e, 0x45, 0xcd, 0xb6,
+	0x55, 0xc7, 0xd5, 0x5c, 0xac, 0xb6, 0x4f, 0x5d, 0xec, 0x50, 0xd4, 0xca, 0x29, 0x79, 0xcd, 0xb6,
+	0x0f, 0x88, 0xb4, 0x46, 0x84, 0xe8, 0x26, 0x14, 0x08, 0xc0, 0x19, 0x5a, 0x5f, 0xed, 0x61, 0xa3,
+	0xdb, 0x73, 0x29, 0x3e, 0xc5, 0x94, 0x3c, 0x97, 0x36, 0xa9, 0x50, 0xd6, 0x21, 0xe7, 0x07, 0x37,
+	0x84, 0x20, 0xae, 0x6b, 0xae, 0x46, 0x03, 0x99, 0x53, 0xe8, 0x33, 0x91, 0xd9, 0x9a, 0xdb, 0xe3,
+	0xe1, 0xa1, 0xcf, 0xe8, 0x22, 0x24, 0xb9, 0xdb, 0x18, 0x75, 0xcb, 0x47, 0x68, 0x05, 0x12, 0xf6,
+	0xd0, 0x3a, 0xc6, 0xf4, 0xcb, 0xa5, 0x15, 0x36, 0x90, 0x7f, 0x19, 0x85, 0xa5, 0x29, 0x18, 0x24,
+	0x7e, 0x7b, 0x9a, 0xd3, 0x13, 0xbf, 0x45, 0x9e, 0xd1, 0x3d, 0xe2, 0x57, 0xd3, 0xf1, 0x90, 0x97,
+	0x8e, 0xb2, 0x3f, 0x44, 0xac, 0x2c, 0x36, 0xe9, 0x7b, 0x1e, 0x1a, 0xae, 0x8d, 0xf6, 0xa0, 0xd4,
+	0xd7, 0x1c, 0x57, 0x65, 0xb0, 0xa2, 0xfa, 0xca, 0xc8, 0x34, 0x98, 0xee, 0x68, 0x02, 0x88, 0xc8,
+	0x9e, 0xe6, 0x8e, 0x0a, 0xfd, 0x80, 0x14, 0x29, 0xb0, 0xd2, 0x3e, 0xfd, 0x5c, 0x33, 0x5d, 0xc3,
+	0xc4, 0xea, 0xd4, 0x97, 0xbb, 0x3c, 0xe5, 0xb4, 0x71, 0x6c, 0xe8, 0xd8, 0xec, 0x88, 0x4f, 0xb6,
+	0xec, 0x19, 0x7b, 0x9f, 0xd4, 0x91, 0x15, 0x28, 0x04, 0x81, 0x1c, 0x15, 0x20, 0xea, 0x9e, 0xf0,
+	0x00, 0x44, 0xdd, 0x13, 0xf4, 0x6d, 0x88, 0x93, 0x45, 0xd2, 0xc5, 0x17, 0x66, 0x54, 0x40, 0x6e,
+	0xd7, 0x3a, 0xb5, 0xb1, 0x42, 0x35, 0x65, 0x19, 0x4a, 0x93, 0xe0, 0x3e, 0xe9, 0x55, 0xbe, 0x0d,
+	0xc5, 0x09, 0xf4, 0xf6, 0x7d, 0x3f, 0xc9, 0xff, 0xfd, 0xe4, 0x22, 0xe4, 0x03, 0x50, 0x2d, 0x5f,
+	0x84, 0x95, 0x59, 0xc8, 0x2b, 0xff, 0x46, 0x82, 0x95, 0x59, 0x10, 0x8a, 0xde, 0x81, 0xb4, 0x87,
+	0xbd, 0x2c, 0x1d, 0xa7, 0x83, 0x25, 0x94, 0x15, 0x4f, 0x95, 0xe4, 0x21, 0xd9, 0xd7, 0x74, 0x43,
+	0x44, 0xe9, 0xcc, 0x53, 0x9a, 0x6d, 0x37, 0xc9, 0x9e, 0xb8, 0x09, 0x05, 0x02, 0x6e, 0x06, 0xd6,
+	0x19, 0x3e, 0x92, 0x2c, 0x8c, 0xdd, 0xca, 0x2b, 0x79, 0x2e, 0xa5, 0x60, 0xe7, 0xc8, 0x9f, 0x40,
+	0x39, 0x0c, 0x7e, 0x27, 0x96, 0x1b, 0xf7, 0xb6, 0xeb, 0x45, 0x48, 0x1e, 0x5a, 0xc3, 0x81, 0xe6,
+	0xd2, 0xdf, 0xcc, 0x2b, 0x7c, 0x44, 0xb6, 0x31, 0x83, 0xe2, 0x18, 0x15, 0xb3, 0x81, 0xac, 0xc2,
+	0xe5, 0x50, 0x08, 0x26, 0x26, 0x86, 0xa9, 0x63, 0x16, 0xf7, 0xbc, 0xc2, 0x06, 0x63, 0x47, 0x6c,
+	0x4d, 0x6c, 0x40, 0x7e, 0xd6, 0xa1, 0x21, 0xa1, 0xfe, 0x33, 0x0a, 0x1f, 0xc9, 0xbf, 0x4b, 0x43,
+	0x5a, 0xc1, 0x8e, 0x4d, 0xb0, 0x03, 0xd5, 0x20, 0x83, 0x4f, 0x3a, 0xd8, 0x76, 0x05, 0xda, 0xce,
+	0x26, 0x17, 0x4c, 0xbb, 0x21, 0x34, 0x49, 0x65, 0xf7, 0xcc, 0xd0, 0x5d, 0x4e, 0xde, 0xc2, 0x79,
+	0x18, 0x37, 0xf7, 0xb3, 0xb7, 0x7b, 0x82, 0xbd, 0xc5, 0x42, 0x8b, 0x39, 0xb3, 0x9a, 0xa0, 0x6f,
+	0x77, 0x39, 0x7d, 0x8b, 0xcf, 0xf9, 0xb1, 0x00, 0x7f, 0xab, 0x07, 0xf8, 0x5b, 0x62, 0xce, 0x32,
+	0x43, 0x08, 0xdc, 0x3d, 0x41, 0xe0, 0x92, 0x73, 0x66, 0x3c, 0xc1, 0xe0, 0x1e, 0x05, 0x19, 0x1c,
+	0x63, 0x5f, 0x37, 0x42, 0xad, 0x43, 0x29, 0xdc, 0xf7, 0x7c, 0x14, 0x2e, 0x1d, 0xca, 0x9f, 0x98,
+	0x93, 0x19, 0x1c, 0xae, 0x1e, 0xe0, 0x70, 0x99, 0x39, 0x31, 0x08, 0x21, 0x71, 0xef, 0xf9, 0x49,
+	0x1c, 0x84, 0xf2, 0x40, 0xfe, 0xbd, 0x67, 0xb1, 0xb8, 0x07, 0x1e, 0x8b, 0xcb, 0x86, 0xd2, 0x50,
+	0xbe, 0x86, 0x49, 0x1a, 0xb7, 0x37, 0x45, 0xe3, 0x18, 0xed, 0x7a, 0x3d, 0xd4, 0xc5, 0x1c, 0x1e,
+	0xb7, 0x37, 0xc5, 0xe3, 0xf2, 0x73, 0x1c, 0xce, 0x21, 0x72, 0x3f, 0x9b, 0x4d, 0xe4, 0xc2, 0xa9,
+	0x16, 0x9f, 0xe6, 0x62, 0x4c, 0x4e, 0x0d, 0x61, 0x72, 0x45, 0xea, 0xfe, 0x8d, 0x50, 0xf7, 0xe7,
+	0xa7, 0x72, 0xb7, 0x61, 0x49, 0x18, 0x7b, 0x39, 0x4f, 0x50, 0x06, 0x0f, 0x87, 0xd6, 0x90, 0x93,
+	0x32, 0x36, 0x90, 0x6f, 0x41, 0xce, 0x53, 0x3d, 0x9b, 0xf6, 0x51, 0xd4, 0xf7, 0xe5, 0xb4, 0xfc,
+	0x07, 0x09, 0x72, 0xfe, 0x74, 0x0d, 0xf0, 0x82, 0x0c, 0xe7, 0x05, 0x3e, 0x32, 0x18, 0x0d, 0x92,
+	0xc1, 0x35, 0xc8, 0x12, 0x30, 0x9f, 0xe0, 0x79, 0x9a, 0xed, 0xf1, 0xbc, 0x3b, 0xb0, 0x44, 0xcb,
+	0x35, 0xa3, 0x8c, 0x1c, 0x9a, 0xe3, 0xb4, 0x12, 0x15, 0xc9, 0x0b, 0xb6, 0x39, 0xa9, 0x18, 0xbd,
+	0x05, 0xcb, 0x3e, 0x5d, 0xaf, 0x48, 0x30, 0xd6, 0x53, 0xf2, 0xb4, 0xab, 0xac, 0x5a, 0xc8, 0x7f,
+	0x94, 0x60, 0x69, 0x0a, 0x2e, 0x66, 0x72, 0x39, 0xe9, 0x7f, 0xc3, 0xe5, 0xa2, 0xff, 0x35, 0x97,
+	0xf3, 0xd7, 0xbc, 0x58, 0xa0, 0xe6, 0xc9, 0xff, 0x94, 0x20, 0x1f, 0x00, 0x2d, 0xf2, 0x05, 0x3a,
+	0x96, 0x8e, 0x79, 0x79, 0xa1, 0xcf, 0xa8, 0x04, 0xb1, 0xbe, 0xd5, 0xe5, 0x45, 0x84, 0x3c, 0x12,
+	0x2d, 0x0f, 0x83, 0x33, 0x1c, 0x62, 0xbd, 0xca, 0x94, 0xa0, 0x01, 0x66, 0x03, 0x62, 0x7b, 0x84,
+	0x19, 0x62, 0xe6, 0x14, 0xf2, 0x88, 0x56, 0xf8, 0x1e, 0xa3, 0x38, 0x98, 0x53, 0xd8, 0x00, 0xdd,
+	0x87, 0x0c, 0x6d, 0x56, 0xa8, 0x96, 0xed, 0x70, 0x70, 0xbb, 0xe2, 0x5f, 0x2b, 0xeb, 0x49, 0xac,
+	0xef, 0x13, 0x9d, 0x3d, 0xdb, 0x51, 0xd2, 0x36, 0x7f, 0xf2, 0x15, 0xdd, 0x4c, 0x80, 0x23, 0x5e,
+	0x85, 0x0c, 0x99, 0xbd, 0x63, 0x6b, 0x1d, 0x4c, 0x91, 0x2a, 0xa3, 0x8c, 0x05, 0xf2, 0x53, 0x40,
+	0xd3, 0x78, 0x8b, 0x9a, 0x90, 0xc4, 0xc7, 0xd8, 0x74, 0xc9, 0x57, 0x23, 0xe1, 0xbe, 0x38, 0x83,
+	0x80, 0x61, 0xd3, 0xad, 0x95, 0x49, 0x90, 0xff, 0xfe, 0xf5, 0x5a, 0x89, 0x69, 0xbf, 0x69, 0x0d,
+	0x0c, 0x17, 0x0f, 0x6c, 0xf7, 0x54, 0xe1, 0xf6, 0xf2, 0x2f, 0xa2, 0x50, 0x14, 0x3f, 0x20, 0x68,
+	0xd8, 0xac, 0xd8, 0x8a, 0x1d, 0x1f, 0xf5, 0x31, 0xe1, 0xc5, 0xe2, 0xbd, 0x0a, 0xd0, 0xd5, 0x1c,
+	0xf5, 0x99, 0x66, 0xba, 0x58, 0xe7, 0x41, 0xf7, 0x49, 0x50, 0x05, 0xd2, 0x64, 0x34, 0x72, 0xb0,
+	0xce, 0x49, 0xb9, 0x37, 0xf6, 0xad, 0x33, 0xf5, 0xcd, 0xd6, 0x19, 0x8c, 0x72, 0x7a, 0x32, 0xca,
+	0xbf, 0x8a, 0xc2, 0xd2, 0x54, 0x41, 0xf9, 0x3f, 0x8c, 0xc3, 0xaf, 0xe9, 0x69, 0x32, 0x58, 0x14,
+	0xd1, 0x01, 0x2c, 0x79, 0x59, 0xaa, 0x8e, 0x68, 0xf6, 0x8a, 0x7d, 0xb7, 0x68, 0x9a, 0x97, 0x8e,
+	0x83, 0x62, 0x07, 0xfd, 0x08, 0x2e, 0x4d, 0x20, 0x90, 0xe7, 0x3a, 0xba, 0x20, 0x10, 0x5d, 0x08,
+	0x02, 0x91, 0xf0, 0x3c, 0x8e, 0x55, 0xec, 0x1b, 0xe6, 0xc6, 0x16, 0x14, 0x44, 0x30, 0x58, 0x89,
+	0x9f, 0xf9, 0xf5, 0x6f, 0x40, 0x7e, 0x88, 0x5d, 0x72, 0x66, 0x0e, 0x1c, 0x01, 0x73, 0x4c, 0xc8,
+	0x0f, 0x96, 0xfb, 0x70, 0x61, 0x66, 0xa9, 0x47, 0xdf, 0x81, 0xcc, 0x98, 0x25, 0x48, 0x21, 0xa7,
+	0x29, 0xa1, 0xae, 0x8c, 0x75, 0xe5, 0x7f, 0x48, 0x70, 0x61, 0x66, 0xb1, 0x47, 0x0d, 0x48, 0x0e,
+	0xb1, 0x33, 0xea, 0x33, 0x76, 0x5f, 0xd8, 0x7c, 0x6b, 0x31, 0x92, 0x40, 0xa4, 0xa3, 0xbe, 0xab,
+	0x70, 0x63, 0xb4, 0x0e, 0xcb, 0xce, 0x91, 0x61, 0xab, 0x13, 0x87, 0x8d, 0x28, 0x3d, 0xc9, 0x2e,
+	0x91, 0x57, 0xd5, 0xc0, 0x81, 0xe3, 0x29, 0x24, 0x99, 0x07, 0x94, 0x85, 0xd4, 0xe3, 0xdd, 0xed,
+	0xdd, 0xbd, 0x8f, 0x76, 0x4b, 0x11, 0x04, 0x90, 0xac, 0xd6, 0xeb, 0x8d, 0xfd, 0x56, 0x49, 0x42,
+	0x19, 0x48, 0x54, 0x6b, 0x7b, 0x4a, 0xab, 0x14, 0x25, 0x62, 0xa5, 0xf1, 0x41, 0xa3, 0xde, 0x2a,
+	0xc5, 0xd0, 0x12, 0xe4, 0xd9, 0xb3, 0xfa, 0x68, 0x4f, 0xf9, 0xb0, 0xda, 0x2a, 0xc5, 0x7d, 0xa2,
+	0x83, 0xc6, 0xee, 0xc3, 0x86, 0x52, 0x4a, 0xc8, 0x6f, 0xc3, 0x65, 0x31, 0xef, 0xe9, 0x13, 0x8d,
+	0x77, 0xb0, 0x90, 0x7c, 0x07, 0x0b, 0xf9, 0xb7, 0x51, 0xa8, 0x84, 0x73, 0x0b, 0xf4, 0xc1, 0x44,
+	0xa0, 0x36, 0xcf, 0x41, 0x4c, 0x26, 0xa3, 0x75, 0x13, 0x0a, 0x43, 0x7c, 0x88, 0xdd, 0x4e, 0x6f,
+	0x1c, 0x28, 0x7a, 0x2a, 0xe3, 0x52, 0x16, 0x24, 0xa6, 0xf6, 0x29, 0xee, 0xb8, 0x2a, 0x3b, 0xe3,
+	0xb0, 0x4d, 0x9a, 0x51, 0xf2, 0x4c, 0x7a, 0xc0, 0x84, 0xf2, 0x27, 0xe7, 0x8a, 0x65, 0x06, 0x12,
+	0x4a, 0xa3, 0xa5, 0xfc, 0xb8, 0x14, 0x43, 0x08, 0x0a, 0xf4, 0x51, 0x3d, 0xd8, 0xad, 0xee, 0x1f,
+	0x34, 0xf7, 0x48, 0x2c, 0x97, 0xa1, 0x28, 0x62, 0x29, 0x84, 0x09, 0xf9, 0xdf, 0x12, 0x14, 0x27,
+	0x12, 0x0a, 0x6d, 0x42, 0x82, 0xf1, 0xe5, 0xb0, 0xe6, 0x36, 0xc5, 0x03, 0x9e, 0x7d, 0x89, 0xb6,
+	0x68, 0xd7, 0x62, 0x7e, 0xd6, 0x9f, 0x95, 0xb8, 0xac, 0x47, 0x21, 0xba, 0x01, 0xdc, 0xd4, 0xb3,
+	0x20, 0xad, 0x56, 0x0f, 0x19, 0xca, 0xb1, 0x69, 0x96, 0xce, 0xcc, 0x3d, 0x4c, 0xe1, 0xf6, 0x63,
+	0x1b, 0xf4, 0x60, 0x4c, 0xba, 0xe2, 0xd3, 0x2c, 0x9d, 0x9b, 0x33, 0x05, 0x6e, 0x2c, 0xf4, 0xe5,
+	0x3a, 0x64, 0x7d, 0xeb, 0x41, 0x57, 0x20, 0x33, 0xd0, 0x4e, 0x78, 0x0f, 0x89, 0x75, 0x01, 0xd2,
+	0x03, 0xed, 0x84, 0xb5, 0x8f, 0x2e, 0x41, 0x8a, 0xbc, 0xec, 0x6a, 0x6c, 0xff, 0xc7, 0x94, 0xe4,
+	0x40, 0x3b, 0x79, 0x5f, 0x73, 0xe4, 0x8f, 0xa1, 0x10, 0xec, 0x9f, 0x90, 0x9d, 0x38, 0xb4, 0x46,
+	0xa6, 0x4e, 0x7d, 0x24, 0x14, 0x36, 0x20, 0x3d, 0xf5, 0x63, 0x8b, 0x81, 0xdb, 0xec, 0x14, 0x7f,
+	0x62, 0xb9, 0xd8, 0xd7, 0x7f, 0x61, 0xda, 0xf2, 0xe7, 0x90, 0xa0, 0x60, 0x45, 0x80, 0x87, 0x76,
+	0x42, 0x38, 0xe1, 0x24, 0xcf, 0xe8, 0x63, 0x00, 0xcd, 0x75, 0x87, 0x46, 0x7b, 0x34, 0x76, 0xbc,
+	0x36, 0x1b, 0xec, 0xaa, 0x42, 0xaf, 0x76, 0x95, 0xa3, 0xde, 0xca, 0xd8, 0xd4, 0x87, 0x7c, 0x3e,
+	0x87, 0xf2, 0x2e, 0x14, 0x82, 0xb6, 0x82, 0x23, 0x49, 0x33, 0x38, 0x52, 0xd4, 0xcf, 0x91, 0x3c,
+	0x86, 0x15, 0x63, 0x5d, 0x2f, 0x3a, 0x90, 0x9f, 0x4b, 0x90, 0x6e, 0x9d, 0xf0, 0x6d, 0x1d, 0xd2,
+	0x70, 0x19, 0x9b, 0x46, 0xfd, 0x6d, 0x03, 0xd6, 0xc1, 0x89, 0x79, 0x7d, 0xa1, 0xf7, 0xbc, 0xc4,
+	0x8d, 0x2f, 0x7a, 0x3a, 0x14, 0x0d, 0x32, 0x66, 0x27, 0xbf, 0x0b, 0x19, 0x6f, 0x57, 0x11, 0xe6,
+	0xae, 0xe9, 0xfa, 0x10, 0x3b, 0x0e, 0x5f, 0x9b, 0x18, 0x92, 0xe9, 0xd8, 0xd6, 0x33, 0xde, 0x98,
+	0x88, 0x29, 0x6c, 0x20, 0xeb, 0x50, 0x9c, 0x28, 0x73, 0xe8, 0x5d, 0x48, 0xd9, 0xa3, 0xb6, 0x2a,
+	0xc2, 0x33, 0x91, 0x3c, 0x82, 0x14, 0x8e, 0xda, 0x7d, 0xa3, 0xb3, 0x8d, 0x4f, 0xc5, 0x64, 0xec,
+	0x51, 0x7b, 0x9b, 0x45, 0x91, 0xfd, 0x4a, 0xd4, 0xff, 0x2b, 0xc7, 0x90, 0x16, 0x9b, 0x02, 0x7d,
+	0xdf, 0x9f, 0x27, 0xa2, 0xab, 0x1b, 0x5a, 0x7a, 0xb9, 0xfb, 0xb1, 0x09, 0x39, 0x60, 0x38, 0x46,
+	0xd7, 0xc4, 0xba, 0x3a, 0x3e, 0x3b, 0x70, 0x24, 0x2f, 0xb2, 0x17, 0x3b, 0xe2, 0xe0, 0x20, 0xff,
+	0x4b, 0x82, 0xb4, 0x48, 0x58, 0xf4, 0xb6, 0x6f, 0xdf, 0x15, 0x66, 0x34, 0x31, 0x84, 0xe2, 0xb8,
+	0x05, 0x17, 0x9c, 0x6b, 0xf4, 0xfc, 0x73, 0x0d, 0xeb, 0xa5, 0x8a, 0xa6, 0x76, 0xfc, 0xdc, 0x4d,
+	0xed, 0x37, 0x01, 0xb9, 0x96, 0xab, 0xf5, 0xd5, 0x63, 0xcb, 0x35, 0xcc, 0xae, 0xca, 0x82, 0xcd,
+	0x18, 0x58, 0x89, 0xbe, 0x79, 0x42, 0x5f, 0xec, 0xd3, 0xb8, 0xff, 0x5c, 0x82, 0xb4, 0x57, 0x4b,
+	0xcf, 0xdb, 0x29, 0xbb, 0x08, 0x49, 0xaf, 0x29, 0x47, 0xe5, 0x6c, 0xe4, 0x35, 0x77, 0xe3, 0xbe,
+	0xe6, 0x6e, 0x05, 0xd2, 0x03, 0xec, 0x6a, 0x94, 0x50, 0xb0, 0xe3, 0x9b, 0x37, 0xbe, 0xf3, 0x00,
+	0xb2, 0xbe, 0xe6, 0x26, 0xc9, 0xbc, 0xdd, 0xc6, 0x47, 0xa5, 0x48, 0x25, 0xf5, 0xfc, 0x8b, 0xeb,
+	0xb1, 0x5d, 0xfc, 0x8c, 0xec, 0x59, 0xa5, 0x51, 0x6f, 0x36, 0xea, 0xdb, 0x25, 0xa9, 0x92, 0x7d,
+	0xfe, 0xc5, 0xf5, 0x94, 0x82, 0x69, 0x03, 0xe5, 0xce, 0x63, 0xc8, 0xf9, 0xbf, 0x4a, 0xb0, 0x82,
+	0x20, 0x28, 0x3c, 0x7c, 0xbc, 0xbf, 0xb3, 0x55, 0xaf, 0xb6, 0x1a, 0xea, 0x93, 0xbd, 0x56, 0xa3,
+	0x24, 0xa1, 0x4b, 0xb0, 0xbc, 0xb3, 0xf5, 0x7e, 0xb3, 0xa5, 0xd6, 0x77, 0xb6, 0x1a, 0xbb, 0x2d,
+	0xb5, 0xda, 0x6a, 0x55, 0xeb, 0xdb, 0xa5, 0x28, 0xb1, 0xac, 0x7e, 0xb8, 0xdb, 0x38, 0xd8, 0xaa,
+	0x96, 0x62, 0x9b, 0xbf, 0xcf, 0x40, 0xb1, 0x5a, 0xab, 0x6f, 0xd1, 0xa2, 0xdf, 0xd1, 0xe8, 0x41,
+	0xbb, 0x0e, 0x71, 0x7a, 0x94, 0x3e, 0xf3, 0x1a, 0xb4, 0x72, 0x76, 0x9f, 0x0d, 0x3d, 0x82, 0x04,
+	0x3d, 0x65, 0xa3, 0xb3, 0xef, 0x45, 0x2b, 0x73, 0x1a, 0x6f, 0x64, 0x32, 0x34, 0x57, 0xce, 0xbc,
+	0x28, 0xad, 0x9c, 0xdd, 0x87, 0x43, 0x0a, 0x64, 0xc6, 0xfc, 0x7f, 0xfe, 0xc5, 0x61, 0x65, 0x01,
+	0xe4, 0x41, 0x3b, 0x90, 0x12, 0x27, 0xab, 0x79, 0x57, 0x99, 0x95, 0xb9, 0x8d, 0x32, 0x12, 0x2e,
+	0x76, 0x02, 0x3e, 0xfb, 0x5e, 0xb6, 0x32, 0xa7, 0xeb, 0x87, 0xb6, 0x20, 0xc9, 0x49, 0xed, 0x9c,
+	0xeb, 0xc9, 0xca, 0xbc, 0xc6, 0x17, 0x09, 0xda, 0xb8, 0xb5, 0x30, 0xff, 0xb6, 0xb9, 0xb2, 0x40,
+	0x43, 0x13, 0x3d, 0x06, 0xf0, 0x9d, 0x77, 0x17, 0xb8, 0x46, 0xae, 0x2c, 0xd2, 0xa8, 0x44, 0x7b,
+	0x90, 0xf6, 0xce, 0x35, 0x73, 0x2f, 0x75, 0x2b, 0xf3, 0x3b, 0x86, 0xe8, 0x29, 0xe4, 0x83, 0x84,
+	0x7e, 0xb1, 0xab, 0xda, 0xca, 0x82, 0xad, 0x40, 0xe2, 0x3f, 0xc8, 0xee, 0x17, 0xbb, 0xba, 0xad,
+	0x2c, 0xd8, 0x19, 0x44, 0x9f, 0xc2, 0xd2, 0x34, 0x9b, 0x5e, 0xfc, 0x26, 0xb7, 0x72, 0x8e, 0x5e,
+	0x21, 0x1a, 0x00, 0x9a, 0xc1, 0xc2, 0xcf, 0x71, 0xb1, 0x5b, 0x39, 0x4f, 0xeb, 0xb0, 0xd6, 0xf8,
+	0xf2, 0xe5, 0xaa, 0xf4, 0xd5, 0xcb, 0x55, 0xe9, 0x6f, 0x2f, 0x57, 0xa5, 0x17, 0xaf, 0x56, 0x23,
+	0x5f, 0xbd, 0x5a, 0x8d, 0xfc, 0xe5, 0xd5, 0x6a, 0xe4, 0x27, 0x6f, 0x74, 0x0d, 0xb7, 0x37, 0x6a,
+	0xaf, 0x77, 0xac, 0xc1, 0x86, 0xff, 0x1f, 0x23, 0xb3, 0xfe, 0xc5, 0xd2, 0x4e, 0xd2, 0x0a, 0x73,
+	0xf7, 0x3f, 0x03, 0x00, 0x74, 0x0e, 0x80, 0x34, 0xe5, 0x22, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4562,6 +4583,24 @@ func (m *RequestOfferSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AppliedChunks) > 0 {
+		dAtA20 := make([]byte, len(m.AppliedChunks)*10)
+		var j19 int
+		for _, num := range m.AppliedChunks {
+			for num >= 1<<7 {
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j19++
+			}
+			dAtA20[j19] = uint8(num)
+			j19++
+		}
+		i -= j19
+		copy(dAtA[i:], dAtA20[:j19])
+		i = encodeVarintTypes(dAtA, i, uint64(j19))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.AppHash) > 0 {
 		i -= len(m.AppHash)
 		copy(dAtA[i:], m.AppHash)
@@ -5641,6 +5680,16 @@ func (m *ResponseOfferSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SkipAppliedChunks {
+		i--
+		if m.SkipAppliedChunks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Result != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
 		i--
@@ -5709,20 +5758,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA39 := make([]byte, len(m.RefetchChunks)*10)
-		var j38 int
+		dAtA41 := make([]byte, len(m.RefetchChunks)*10)
+		var j40 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA41[j40] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j40++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA41[j40] = uint8(num)
+			j40++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintTypes(dAtA, i, uint64(j38))
+		i -= j40
+		copy(dAtA[i:], dAtA41[:j40])
+		i = encodeVarintTypes(dAtA, i, uint64(j40))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6162,12 +6211,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n47, err47 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err47 != nil {
-		return 0, err47
+	n49, err49 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err49 != nil {
+		return 0, err49
 	}
-	i -= n47
-	i = encodeVarintTypes(dAtA, i, uint64(n47))
+	i -= n49
+	i = encodeVarintTypes(dAtA, i, uint64(n49))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6632,6 +6681,13 @@ func (m *RequestOfferSnapshot) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.AppliedChunks) > 0 {
+		l = 0
+		for _, e := range m.AppliedChunks {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
 	return n
 }
 
@@ -7152,6 +7208,9 @@ func (m *ResponseOfferSnapshot) Size() (n int) {
 	if m.Result != 0 {
 		n += 1 + sovTypes(uint64(m.Result))
 	}
+	if m.SkipAppliedChunks {
+		n += 2
+	}
 	return n
 }
 
@@ -9301,6 +9360,82 @@ func (m *RequestOfferSnapshot) Unmarshal(dAtA []byte) error {
 				m.AppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.AppliedChunks = append(m.AppliedChunks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.AppliedChunks) == 0 {
+					m.AppliedChunks = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.AppliedChunks = append(m.AppliedChunks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedChunks", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12063,6 +12198,26 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipAppliedChunks", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SkipAppliedChunks = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,134 @@
+package v0
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// verifiedBlock is a block whose commit has been verified and which has been
+// written to the block store, ready to be executed.
+type verifiedBlock struct {
+	block   *types.Block
+	blockID types.BlockID
+}
+
+// blockApplier executes verified blocks against the application. By default
+// blocks are executed inline. Once the pipeline is started, blocks are executed
+// in a separate goroutine instead, so that the pool routine can verify and
+// write the next block to the store while the current one is being executed.
+//
+// Blocks are only committed to the block store right before they are
+// executed, which keeps the store at most one block ahead of the state.
+type blockApplier struct {
+	logger    log.Logger
+	blockExec *sm.BlockExecutor
+	store     *store.BlockStore
+	pool      *BlockPool
+
+	blocksCh chan verifiedBlock
+	doneCh   chan struct{}
+
+	mtx          tmsync.RWMutex
+	state        sm.State
+	blocksSynced uint64
+	lastHundred  time.Time
+	lastRate     float64
+}
+
+func newBlockApplier(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
+	pool *BlockPool, logger log.Logger) *blockApplier {
+	return &blockApplier{
+		logger:      logger,
+		blockExec:   blockExec,
+		store:       store,
+		pool:        pool,
+		state:       state,
+		lastHundred: time.Now(),
+	}
+}
+
+// startPipeline starts executing blocks in a separate goroutine. appliedCh is
+// notified, without blocking, every time a block has been executed.
+func (a *blockApplier) startPipeline(appliedCh chan<- struct{}) {
+	// One block can wait while another is executed, which is as far ahead as
+	// the pool routine can verify blocks.
+	a.blocksCh = make(chan verifiedBlock, 1)
+	a.doneCh = make(chan struct{})
+
+	go func() {
+		defer close(a.doneCh)
+		for vb := range a.blocksCh {
+			a.applyBlock(vb)
+			select {
+			case appliedCh <- struct{}{}:
+			default:
+			}
+		}
+	}()
+}
+
+// submit executes the block, or queues it for execution if the pipeline is
+// running. Blocks must be submitted in order.
+func (a *blockApplier) submit(vb verifiedBlock) {
+	if a.blocksCh == nil {
+		a.applyBlock(vb)
+		return
+	}
+	a.blocksCh <- vb
+}
+
+// stop waits for all submitted blocks to be executed and stops the pipeline,
+// if running.
+func (a *blockApplier) stop() {
+	if a.blocksCh == nil {
+		return
+	}
+	close(a.blocksCh)
+	<-a.doneCh
+}
+
+// State returns the state after the last executed block.
+func (a *blockApplier) State() sm.State {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.state
+}
+
+// BlocksSynced returns the number of executed blocks.
+func (a *blockApplier) BlocksSynced() uint64 {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.blocksSynced
+}
+
+func (a *blockApplier) applyBlock(vb verifiedBlock) {
+	a.store.CommitBlock(vb.block.Height)
+
+	// TODO: same thing for app - but we would need a way to get the hash
+	// without persisting the state.
+	state, _, err := a.blockExec.ApplyBlock(a.State(), vb.blockID, vb.block)
+	if err != nil {
+		// TODO This is bad, are we zombie?
+		panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", vb.block.Height, vb.block.Hash(), err))
+	}
+
+	a.mtx.Lock()
+	a.state = state
+	a.blocksSynced++
+	blocksSynced := a.blocksSynced
+	a.mtx.Unlock()
+
+	if blocksSynced%100 == 0 {
+		height, _, _ := a.pool.GetStatus()
+		a.lastRate = 0.9*a.lastRate + 0.1*(100/time.Since(a.lastHundred).Seconds())
+		a.logger.Info("Fast Sync Rate",
+			"height", height, "max_peer_height", a.pool.MaxPeerHeight(), "blocks/s", a.lastRate)
+		a.lastHundred = time.Now()
+	}
+}
@@ -1,6 +1,9 @@
 package v0
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -31,8 +34,25 @@ const (
 
 	// switch to consensus after this duration of inactivity
 	syncTimeout = 60 * time.Second
+
+	// how long to wait for the light client to verify a block's header
+	lightBlockTimeout = 30 * time.Second
+	// how long to wait before retrying after the light client failed
+	lightBlockRetryInterval = 1 * time.Second
+	// how long to ban peers sending blocks that don't match the light client
+	lightBlockMismatchBanTime = 24 * time.Hour
 )
 
+// LightBlockVerifier returns light blocks verified against a trusted root, e.g.
+// a *light.Client.
+type LightBlockVerifier interface {
+	VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*types.LightBlock, error)
+}
+
+// errLightBlockMismatch is returned when a block doesn't match the header
+// verified by the light client.
+var errLightBlockMismatch = errors.New("block does not match the verified light block")
+
 type consensusReactor interface {
 	// for when we switch from blockchain reactor and fast sync to
 	// the consensus machine
@@ -60,13 +80,35 @@ type BlockchainReactor struct {
 	pool      *BlockPool
 	fastSync  bool
 
+	// optional, verifies synced blocks against a light client
+	lightVerifier LightBlockVerifier
+	// execute blocks concurrently with the verification of the next one
+	pipelined bool
+
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 }
 
+// ReactorOption sets an optional parameter on the BlockchainReactor.
+type ReactorOption func(*BlockchainReactor)
+
+// WithLightBlockVerifier sets a verifier that every synced block's hash is
+// checked against before it is saved and applied. Peers that send blocks
+// which don't match are banned.
+func WithLightBlockVerifier(v LightBlockVerifier) ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.lightVerifier = v }
+}
+
+// WithPipelining makes the reactor execute blocks in a separate goroutine, so
+// that the next block's commit is verified and the block written to the store
+// while the current block is executed by the application.
+func WithPipelining() ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.pipelined = true }
+}
+
 // NewBlockchainReactor returns new reactor instance.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	fastSync bool) *BlockchainReactor {
+	fastSync bool, options ...ReactorOption) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
@@ -93,6 +135,9 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
 	}
+	for _, option := range options {
+		option(bcR)
+	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
 }
@@ -267,17 +312,19 @@ func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 		statusUpdateTicker      = time.NewTicker(statusUpdateIntervalSeconds * time.Second)
 		switchToConsensusTicker = time.NewTicker(switchToConsensusIntervalSeconds * time.Second)
 
-		blocksSynced = uint64(0)
-
 		chainID = bcR.initialState.ChainID
-		state   = bcR.initialState
+		applier = newBlockApplier(bcR.initialState, bcR.blockExec, bcR.store, bcR.pool, bcR.Logger)
 
-		lastHundred = time.Now()
-		lastRate    = 0.0
+		// don't ask the light client again before this time after it failed
+		lightRetryAt = time.Time{}
 
 		didProcessCh = make(chan struct{}, 1)
 	)
 
+	if bcR.pipelined {
+		applier.startPipeline(didProcessCh)
+	}
+
 	go func() {
 		for {
 			select {
@@ -349,9 +396,10 @@ FOR_LOOP:
 			if err := bcR.pool.Stop(); err != nil {
 				bcR.Logger.Error("Error stopping pool", "err", err)
 			}
+			applier.stop()
 			conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor)
 			if ok {
-				conR.SwitchToConsensus(state, blocksSynced > 0 || stateSynced)
+				conR.SwitchToConsensus(applier.State(), applier.BlocksSynced() > 0 || stateSynced)
 			}
 
 			break FOR_LOOP
@@ -371,15 +419,40 @@ FOR_LOOP:
 			// coupling them as it's written here.  TODO uncouple from request
 			// routine.
 
+			// Wait for the light client to recover before syncing more blocks.
+			if time.Now().Before(lightRetryAt) {
+				continue FOR_LOOP
+			}
+
 			// See if there are any blocks to sync.
 			first, second := bcR.pool.PeekTwoBlocks()
 			// bcR.Logger.Info("TrySync peeked", "first", first, "second", second)
 			if first == nil || second == nil {
 				// We need both to sync the first block.
 				continue FOR_LOOP
-			} else {
-				// Try again quickly next loop.
-				didProcessCh <- struct{}{}
+			}
+
+			// The validators for a height are known once the block two heights
+			// below has been executed, so when pipelining the first block can be
+			// verified while its parent is still being executed. Otherwise, wait
+			// for the applier to notify us.
+			var (
+				state = applier.State()
+				vals  *types.ValidatorSet
+			)
+			switch first.Height {
+			case state.LastBlockHeight + 1:
+				vals = state.Validators
+			case state.LastBlockHeight + 2:
+				vals = state.NextValidators
+			default:
+				continue FOR_LOOP
+			}
+
+			// Try again quickly next loop. The applier may have already notified us.
+			select {
+			case didProcessCh <- struct{}{}:
+			default:
 			}
 
 			var (
@@ -392,7 +465,7 @@ FOR_LOOP:
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+			err := vals.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
 			if err != nil {
 				err = fmt.Errorf("invalid last commit: %w", err)
 				bcR.Logger.Error(err.Error(),
@@ -414,37 +487,79 @@ FOR_LOOP:
 				}
 
 				continue FOR_LOOP
-			} else {
-				bcR.pool.PopRequest()
+			}
+
+			// The commit is valid for our validator set, but the block may still be
+			// on a fork. Check it against the header verified by the light client.
+			if err := bcR.verifyLightBlock(first); err != nil {
+				if !errors.Is(err, errLightBlockMismatch) {
+					bcR.Logger.Error("Failed to verify light block, retrying",
+						"height", first.Height, "err", err)
+					lightRetryAt = time.Now().Add(lightBlockRetryInterval)
+					continue FOR_LOOP
+				}
 
-				// TODO: batch saves so we dont persist to disk every block
-				bcR.store.SaveBlock(first, firstParts, second.LastCommit)
+				bcR.Logger.Error(err.Error(), "block_id", firstID, "height", first.Height)
 
-				// TODO: same thing for app - but we would need a way to get the hash
-				// without persisting the state.
-				var err error
-				state, _, err = bcR.blockExec.ApplyBlock(state, firstID, first)
-				if err != nil {
-					// TODO This is bad, are we zombie?
-					panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
+				// The second block commits to the first one, so it's from the same
+				// chain and both peers get banned.
+				peerID := bcR.pool.RedoRequest(first.Height)
+				if peer := bcR.Switch.Peers().Get(peerID); peer != nil {
+					bcR.Switch.BanPeerForError(peer, err, lightBlockMismatchBanTime)
 				}
-				blocksSynced++
 
-				if blocksSynced%100 == 0 {
-					lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
-					bcR.Logger.Info("Fast Sync Rate",
-						"height", bcR.pool.height, "max_peer_height", bcR.pool.MaxPeerHeight(), "blocks/s", lastRate)
-					lastHundred = time.Now()
+				peerID2 := bcR.pool.RedoRequest(second.Height)
+				if peerID2 != peerID {
+					if peer2 := bcR.Switch.Peers().Get(peerID2); peer2 != nil {
+						bcR.Switch.BanPeerForError(peer2, err, lightBlockMismatchBanTime)
+					}
 				}
+
+				continue FOR_LOOP
 			}
+
+			bcR.pool.PopRequest()
+
+			// The block is written now, but only committed to the store by the
+			// applier right before it's executed.
+			// TODO: batch saves so we dont persist to disk every block
+			bcR.store.WriteBlock(first, firstParts, second.LastCommit)
+
+			applier.submit(verifiedBlock{block: first, blockID: firstID})
+
 			continue FOR_LOOP
 
 		case <-bcR.Quit():
+			applier.stop()
 			break FOR_LOOP
 		}
 	}
 }
 
+// verifyLightBlock checks the block against the light block verified at its
+// height, if a light block verifier is set. errLightBlockMismatch is returned
+// if the hashes don't match.
+func (bcR *BlockchainReactor) verifyLightBlock(block *types.Block) error {
+	if bcR.lightVerifier == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lightBlockTimeout)
+	defer cancel()
+
+	lb, err := bcR.lightVerifier.VerifyLightBlockAtHeight(ctx, block.Height, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(lb.Hash(), block.Hash()) {
+		return fmt.Errorf("%w at height %d: expected %X, got %X",
+			errLightBlockMismatch, block.Height, lb.Hash(), block.Hash())
+	}
+
+	return nil
+}
+
 // BroadcastStatusRequest broadcasts `BlockStore` base and height.
 func (bcR *BlockchainReactor) BroadcastStatusRequest() {
 	bm, err := bc.EncodeMsg(&bcproto.StatusRequest{})
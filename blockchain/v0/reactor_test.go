@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -55,7 +56,8 @@ func newBlockchainReactor(
 	logger log.Logger,
 	genDoc *types.GenesisDoc,
 	privVals []types.PrivValidator,
-	maxBlockHeight int64) BlockchainReactorPair {
+	maxBlockHeight int64,
+	options ...ReactorOption) BlockchainReactorPair {
 	if len(privVals) != 1 {
 		panic("only support one validator")
 	}
@@ -125,7 +127,7 @@ func newBlockchainReactor(
 		blockStore.SaveBlock(thisBlock, thisParts, lastCommit)
 	}
 
-	bcReactor := NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+	bcReactor := NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync, options...)
 	bcReactor.SetLogger(logger.With("module", "blockchain"))
 
 	return BlockchainReactorPair{bcReactor, proxyApp}
@@ -278,6 +280,155 @@ func TestBadBlockStopsPeer(t *testing.T) {
 	assert.True(t, lastReactorPair.reactor.Switch.Peers().Size() < len(reactorPairs)-1)
 }
 
+func TestLightBlockMismatchBansPeer(t *testing.T) {
+	testcases := map[string]struct {
+		tamper     bool
+		expectSync bool
+	}{
+		"matching light blocks":   {false, true},
+		"mismatching light block": {true, false},
+	}
+
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			config = cfg.ResetTestRoot("blockchain_reactor_test")
+			defer os.RemoveAll(config.RootDir)
+			genDoc, privVals := randGenesisDoc(1, false, 30)
+
+			maxBlockHeight := int64(20)
+
+			reactorPairs := make([]BlockchainReactorPair, 2)
+			reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+
+			verifier := &mockLightBlockVerifier{store: reactorPairs[0].reactor.store, tamper: tc.tamper}
+			reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0,
+				WithLightBlockVerifier(verifier))
+
+			switches := p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+				s.SetAddrBook(&p2p.AddrBookMock{
+					Addrs:    make(map[string]struct{}),
+					OurAddrs: make(map[string]struct{})})
+				s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+				return s
+			}, p2p.Connect2Switches)
+
+			defer func() {
+				for _, s := range switches {
+					require.NoError(t, s.Stop())
+				}
+				for _, r := range reactorPairs {
+					require.NoError(t, r.app.Stop())
+				}
+			}()
+
+			if tc.expectSync {
+				require.Eventually(t, func() bool {
+					return reactorPairs[1].reactor.store.Height() == maxBlockHeight-1
+				}, 10*time.Second, 10*time.Millisecond)
+				assert.Equal(t, 1, switches[1].Peers().Size())
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return switches[1].Peers().Size() == 0
+			}, 10*time.Second, 10*time.Millisecond)
+			assert.EqualValues(t, 0, reactorPairs[1].reactor.store.Height())
+
+			// the peer is banned and can't reconnect
+			err := switches[1].DialPeerWithAddress(switches[0].NetAddress())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPipelinedSync(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+
+	reactorPairs := make([]BlockchainReactorPair, 2)
+	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0, WithPipelining())
+
+	switches := p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, s := range switches {
+			require.NoError(t, s.Stop())
+		}
+		for _, r := range reactorPairs {
+			require.NoError(t, r.app.Stop())
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return reactorPairs[1].reactor.pool.IsCaughtUp()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// the last block can't be verified without the next block's commit
+	require.Eventually(t, func() bool {
+		return reactorPairs[1].reactor.store.Height() == maxBlockHeight-1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for h := int64(1); h < maxBlockHeight; h++ {
+		expected := reactorPairs[0].reactor.store.LoadBlockMeta(h)
+		synced := reactorPairs[1].reactor.store.LoadBlockMeta(h)
+		require.NotNil(t, synced)
+		assert.Equal(t, expected.BlockID, synced.BlockID)
+	}
+}
+
+func BenchmarkFastSync(b *testing.B) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(200)
+
+	benchmarks := map[string][]ReactorOption{
+		"sequential": nil,
+		"pipelined":  {WithPipelining()},
+	}
+
+	for name, options := range benchmarks {
+		options := options
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				reactorPairs := make([]BlockchainReactorPair, 2)
+				reactorPairs[0] = newBlockchainReactor(log.NewNopLogger(), genDoc, privVals, maxBlockHeight)
+				reactorPairs[1] = newBlockchainReactor(log.NewNopLogger(), genDoc, privVals, 0, options...)
+				b.StartTimer()
+
+				switches := p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+					s.SetLogger(log.NewNopLogger())
+					s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+					return s
+				}, p2p.Connect2Switches)
+
+				for reactorPairs[1].reactor.store.Height() < maxBlockHeight-1 {
+					time.Sleep(time.Millisecond)
+				}
+
+				b.StopTimer()
+				for _, s := range switches {
+					require.NoError(b, s.Stop())
+				}
+				for _, r := range reactorPairs {
+					require.NoError(b, r.app.Stop())
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
+
 //----------------------------------------------
 // utility funcs
 
@@ -326,3 +477,23 @@ func (app *testApp) Commit() abci.ResponseCommit {
 func (app *testApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuery) {
 	return
 }
+
+// mockLightBlockVerifier returns light blocks built from the headers in a
+// block store, with a tampered app hash if tamper is set.
+type mockLightBlockVerifier struct {
+	store  *store.BlockStore
+	tamper bool
+}
+
+func (v *mockLightBlockVerifier) VerifyLightBlockAtHeight(
+	ctx context.Context, height int64, now time.Time) (*types.LightBlock, error) {
+	meta := v.store.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+	header := meta.Header
+	if v.tamper {
+		header.AppHash = []byte("tampered")
+	}
+	return &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &header}}, nil
+}
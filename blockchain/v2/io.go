@@ -3,7 +3,8 @@ package v2
 import (
 	"errors"
 
-	bc "github.com/tendermint/tendermint/blockchain"
+	"github.com/gogo/protobuf/proto"
+
 	"github.com/tendermint/tendermint/p2p"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	"github.com/tendermint/tendermint/state"
@@ -11,34 +12,42 @@ import (
 )
 
 var (
-	errPeerQueueFull = errors.New("peer queue full")
+	errReactorClosed = errors.New("reactor is closed")
 )
 
 type iIO interface {
-	sendBlockRequest(peer p2p.Peer, height int64) error
-	sendBlockToPeer(block *types.Block, peer p2p.Peer) error
-	sendBlockNotFound(height int64, peer p2p.Peer) error
-	sendStatusResponse(base, height int64, peer p2p.Peer) error
+	sendBlockRequest(peerID p2p.ID, height int64) error
+	sendBlockToPeer(block *types.Block, peerID p2p.ID) error
+	sendBlockNotFound(height int64, peerID p2p.ID) error
+	sendStatusResponse(base, height int64, peerID p2p.ID) error
 
-	sendStatusRequest(peer p2p.Peer) error
+	sendStatusRequest(peerID p2p.ID) error
 	broadcastStatusRequest() error
 
+	sendPeerError(peerID p2p.ID, err error) error
+
 	trySwitchToConsensus(state state.State, skipWAL bool) bool
 }
 
-type switchIO struct {
-	sw *p2p.Switch
+// channelIO sends messages to peers over the blockchain p2p Channel. Sends
+// block until the envelope is picked up by the router or closeCh is closed.
+type channelIO struct {
+	blockchainCh *p2p.Channel
+	consReactor  consensusReactor
+	closeCh      <-chan struct{}
 }
 
-func newSwitchIo(sw *p2p.Switch) *switchIO {
-	return &switchIO{
-		sw: sw,
+func newChannelIO(blockchainCh *p2p.Channel, consReactor consensusReactor, closeCh <-chan struct{}) *channelIO {
+	return &channelIO{
+		blockchainCh: blockchainCh,
+		consReactor:  consReactor,
+		closeCh:      closeCh,
 	}
 }
 
 const (
 	// BlockchainChannel is a channel for blocks and status updates (`BlockStore` height)
-	BlockchainChannel = byte(0x40)
+	BlockchainChannel = p2p.ChannelID(0x40)
 )
 
 type consensusReactor interface {
@@ -47,33 +56,15 @@ type consensusReactor interface {
 	SwitchToConsensus(state state.State, skipWAL bool)
 }
 
-func (sio *switchIO) sendBlockRequest(peer p2p.Peer, height int64) error {
-	msgBytes, err := bc.EncodeMsg(&bcproto.BlockRequest{Height: height})
-	if err != nil {
-		return err
-	}
-
-	queued := peer.TrySend(BlockchainChannel, msgBytes)
-	if !queued {
-		return errPeerQueueFull
-	}
-	return nil
+func (cio *channelIO) sendBlockRequest(peerID p2p.ID, height int64) error {
+	return cio.sendTo(peerID, &bcproto.BlockRequest{Height: height})
 }
 
-func (sio *switchIO) sendStatusResponse(base int64, height int64, peer p2p.Peer) error {
-	msgBytes, err := bc.EncodeMsg(&bcproto.StatusResponse{Height: height, Base: base})
-	if err != nil {
-		return err
-	}
-
-	if queued := peer.TrySend(BlockchainChannel, msgBytes); !queued {
-		return errPeerQueueFull
-	}
-
-	return nil
+func (cio *channelIO) sendStatusResponse(base int64, height int64, peerID p2p.ID) error {
+	return cio.sendTo(peerID, &bcproto.StatusResponse{Height: height, Base: base})
 }
 
-func (sio *switchIO) sendBlockToPeer(block *types.Block, peer p2p.Peer) error {
+func (cio *channelIO) sendBlockToPeer(block *types.Block, peerID p2p.ID) error {
 	if block == nil {
 		panic("trying to send nil block")
 	}
@@ -83,59 +74,64 @@ func (sio *switchIO) sendBlockToPeer(block *types.Block, peer p2p.Peer) error {
 		return err
 	}
 
-	msgBytes, err := bc.EncodeMsg(&bcproto.BlockResponse{Block: bpb})
-	if err != nil {
-		return err
-	}
-	if queued := peer.TrySend(BlockchainChannel, msgBytes); !queued {
-		return errPeerQueueFull
-	}
-
-	return nil
+	return cio.sendTo(peerID, &bcproto.BlockResponse{Block: bpb})
 }
 
-func (sio *switchIO) sendBlockNotFound(height int64, peer p2p.Peer) error {
-	msgBytes, err := bc.EncodeMsg(&bcproto.NoBlockResponse{Height: height})
-	if err != nil {
-		return err
-	}
+func (cio *channelIO) sendBlockNotFound(height int64, peerID p2p.ID) error {
+	return cio.sendTo(peerID, &bcproto.NoBlockResponse{Height: height})
+}
 
-	if queued := peer.TrySend(BlockchainChannel, msgBytes); !queued {
-		return errPeerQueueFull
+func (cio *channelIO) trySwitchToConsensus(state state.State, skipWAL bool) bool {
+	if cio.consReactor == nil {
+		return false
 	}
+	cio.consReactor.SwitchToConsensus(state, skipWAL)
+	return true
+}
 
-	return nil
+func (cio *channelIO) sendStatusRequest(peerID p2p.ID) error {
+	return cio.sendTo(peerID, &bcproto.StatusRequest{})
 }
 
-func (sio *switchIO) trySwitchToConsensus(state state.State, skipWAL bool) bool {
-	conR, ok := sio.sw.Reactor("CONSENSUS").(consensusReactor)
-	if ok {
-		conR.SwitchToConsensus(state, skipWAL)
-	}
-	return ok
+func (cio *channelIO) broadcastStatusRequest() error {
+	return cio.send(p2p.Envelope{
+		Broadcast: true,
+		Message:   &bcproto.StatusRequest{},
+	})
 }
 
-func (sio *switchIO) sendStatusRequest(peer p2p.Peer) error {
-	msgBytes, err := bc.EncodeMsg(&bcproto.StatusRequest{})
-	if err != nil {
-		return err
+func (cio *channelIO) sendPeerError(peerID p2p.ID, err error) error {
+	pID, perr := p2p.PeerIDFromString(string(peerID))
+	if perr != nil {
+		return perr
 	}
 
-	if queued := peer.TrySend(BlockchainChannel, msgBytes); !queued {
-		return errPeerQueueFull
+	select {
+	case cio.blockchainCh.Error() <- p2p.PeerError{
+		PeerID:   pID,
+		Err:      err,
+		Severity: p2p.PeerErrorSeverityHigh,
+	}:
+		return nil
+	case <-cio.closeCh:
+		return errReactorClosed
 	}
-
-	return nil
 }
 
-func (sio *switchIO) broadcastStatusRequest() error {
-	msgBytes, err := bc.EncodeMsg(&bcproto.StatusRequest{})
+func (cio *channelIO) sendTo(peerID p2p.ID, msg proto.Message) error {
+	pID, err := p2p.PeerIDFromString(string(peerID))
 	if err != nil {
 		return err
 	}
 
-	// XXX: maybe we should use an io specific peer list here
-	sio.sw.Broadcast(BlockchainChannel, msgBytes)
+	return cio.send(p2p.Envelope{To: pID, Message: msg})
+}
 
-	return nil
+func (cio *channelIO) send(envelope p2p.Envelope) error {
+	select {
+	case cio.blockchainCh.Out() <- envelope:
+		return nil
+	case <-cio.closeCh:
+		return errReactorClosed
+	}
 }
@@ -3,11 +3,12 @@ package v2
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	"github.com/tendermint/tendermint/behaviour"
 	bc "github.com/tendermint/tendermint/blockchain"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
@@ -15,6 +16,31 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+var (
+	_ service.Service = (*BlockchainReactor)(nil)
+	_ p2p.Wrapper     = (*bcproto.Message)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		BlockchainChannel: {
+			MsgType: new(bcproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(BlockchainChannel),
+				Priority:            10,
+				SendQueueCapacity:   2000,
+				RecvBufferCapacity:  50 * 4096,
+				RecvMessageCapacity: bc.MaxMsgSize,
+			},
+		},
+	}
+)
+
 const (
 	// chBufferSize is the buffer size of all event channels.
 	chBufferSize int = 1000
@@ -29,7 +55,7 @@ type blockStore interface {
 
 // BlockchainReactor handles fast sync protocol.
 type BlockchainReactor struct {
-	p2p.BaseReactor
+	service.BaseService
 
 	fastSync    bool // if true, enable fast sync on start
 	stateSynced bool // set to true when SwitchToFastSync is called by state sync
@@ -42,9 +68,16 @@ type BlockchainReactor struct {
 	syncHeight    int64
 	events        chan Event // non-nil during a fast sync
 
-	reporter behaviour.Reporter
-	io       iIO
-	store    blockStore
+	io    iIO
+	store blockStore
+
+	blockchainCh *p2p.Channel
+	peerUpdates  *p2p.PeerUpdatesCh
+	closeCh      chan struct{}
+
+	// tracks all goroutines that may send on blockchainCh, so that it is only
+	// closed once they have all exited
+	wg sync.WaitGroup
 }
 
 type blockApplier interface {
@@ -52,8 +85,8 @@ type blockApplier interface {
 }
 
 // XXX: unify naming in this package around tmState
-func newReactor(state state.State, store blockStore, reporter behaviour.Reporter,
-	blockApplier blockApplier, fastSync bool) *BlockchainReactor {
+func newReactor(state state.State, store blockStore, blockApplier blockApplier, consReactor consensusReactor,
+	blockchainCh *p2p.Channel, peerUpdates *p2p.PeerUpdatesCh, fastSync bool) *BlockchainReactor {
 	initHeight := state.LastBlockHeight + 1
 	if initHeight == 1 {
 		initHeight = state.InitialHeight
@@ -64,34 +97,37 @@ func newReactor(state state.State, store blockStore, reporter behaviour.Reporter
 	// newPcState requires a processorContext
 	processor := newPcState(pContext)
 
-	return &BlockchainReactor{
-		scheduler: newRoutine("scheduler", scheduler.handle, chBufferSize),
-		processor: newRoutine("processor", processor.handle, chBufferSize),
-		store:     store,
-		reporter:  reporter,
-		logger:    log.NewNopLogger(),
-		fastSync:  fastSync,
+	closeCh := make(chan struct{})
+	r := &BlockchainReactor{
+		scheduler:    newRoutine("scheduler", scheduler.handle, chBufferSize),
+		processor:    newRoutine("processor", processor.handle, chBufferSize),
+		store:        store,
+		logger:       log.NewNopLogger(),
+		fastSync:     fastSync,
+		io:           newChannelIO(blockchainCh, consReactor, closeCh),
+		blockchainCh: blockchainCh,
+		peerUpdates:  peerUpdates,
+		closeCh:      closeCh,
 	}
+	r.BaseService = *service.NewBaseService(nil, "BlockchainReactor", r)
+	return r
 }
 
-// NewBlockchainReactor creates a new reactor instance.
+// NewBlockchainReactor returns a reference to a new fast sync reactor, which
+// implements the service.Service interface. It accepts the state to sync from,
+// a block applier and store, the consensus reactor to switch to once caught
+// up, a reference to the blockchain p2p Channel and a channel to listen for
+// peer updates on. Note, the reactor will close the p2p Channel and the peer
+// updates channel when stopping.
 func NewBlockchainReactor(
 	state state.State,
 	blockApplier blockApplier,
 	store blockStore,
+	consReactor consensusReactor,
+	blockchainCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
 	fastSync bool) *BlockchainReactor {
-	reporter := behaviour.NewMockReporter()
-	return newReactor(state, store, reporter, blockApplier, fastSync)
-}
-
-// SetSwitch implements Reactor interface.
-func (r *BlockchainReactor) SetSwitch(sw *p2p.Switch) {
-	r.Switch = sw
-	if sw != nil {
-		r.io = newSwitchIo(sw)
-	} else {
-		r.io = nil
-	}
+	return newReactor(state, store, blockApplier, consReactor, blockchainCh, peerUpdates, fastSync)
 }
 
 func (r *BlockchainReactor) setMaxPeerHeight(height int64) {
@@ -117,20 +153,28 @@ func (r *BlockchainReactor) SyncHeight() int64 {
 
 // SetLogger sets the logger of the reactor.
 func (r *BlockchainReactor) SetLogger(logger log.Logger) {
+	r.BaseService.SetLogger(logger)
 	r.logger = logger
 	r.scheduler.setLogger(logger)
 	r.processor.setLogger(logger)
 }
 
-// Start implements cmn.Service interface
-func (r *BlockchainReactor) Start() error {
-	r.reporter = behaviour.NewSwitchReporter(r.BaseReactor.Switch)
+// OnStart starts separate go routines to listen for envelopes on the
+// blockchain p2p Channel and for peer updates, and begins a fast sync if
+// enabled. The caller must be sure to execute OnStop to ensure the outbound
+// p2p Channel is closed.
+func (r *BlockchainReactor) OnStart() error {
 	if r.fastSync {
 		err := r.startSync(nil)
 		if err != nil {
 			return fmt.Errorf("failed to start fast sync: %w", err)
 		}
 	}
+
+	r.wg.Add(2)
+	go r.processBlockchainCh()
+	go r.processPeerUpdates()
+
 	return nil
 }
 
@@ -151,6 +195,7 @@ func (r *BlockchainReactor) startSync(state *state.State) error {
 		r.scheduler.send(bcResetState{state: *state})
 		r.processor.send(bcResetState{state: *state})
 	}
+	r.wg.Add(1)
 	go r.demux(r.events)
 	return nil
 }
@@ -279,6 +324,8 @@ func (e bcResetState) String() string {
 
 // Takes the channel as a parameter to avoid race conditions on r.events.
 func (r *BlockchainReactor) demux(events <-chan Event) {
+	defer r.wg.Done()
+
 	var lastRate = 0.0
 	var lastHundred = time.Now()
 
@@ -374,16 +421,11 @@ func (r *BlockchainReactor) demux(events <-chan Event) {
 				r.processor.send(event)
 			case scPeerError:
 				r.processor.send(event)
-				if err := r.reporter.Report(behaviour.BadMessage(event.peerID, "scPeerError")); err != nil {
+				if err := r.io.sendPeerError(event.peerID, event.reason); err != nil {
 					r.logger.Error("Error reporting peer", "err", err)
 				}
 			case scBlockRequest:
-				peer := r.Switch.Peers().Get(event.peerID)
-				if peer == nil {
-					r.logger.Error("Wanted to send block request, but no such peer", "peerID", event.peerID)
-					continue
-				}
-				if err := r.io.sendBlockRequest(peer, event.height); err != nil {
+				if err := r.io.sendBlockRequest(event.peerID, event.height); err != nil {
 					r.logger.Error("Error sending block request", "err", err)
 				}
 			case scFinishedEv:
@@ -452,128 +494,195 @@ func (r *BlockchainReactor) demux(events <-chan Event) {
 	}
 }
 
-// Stop implements cmn.Service interface.
-func (r *BlockchainReactor) Stop() error {
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit. It then closes the blockchain p2p Channel and
+// the peer updates channel.
+func (r *BlockchainReactor) OnStop() {
 	r.logger.Info("reactor stopping")
+
+	// Close closeCh to signal to all spawned goroutines, and any pending sends
+	// on the p2p Channel, to gracefully exit.
+	close(r.closeCh)
 	r.endSync()
+
+	// Wait for all goroutines to exit before closing the p2p Channel, as any
+	// send on a closed Channel panics.
+	r.wg.Wait()
+	r.blockchainCh.Close()
+	r.peerUpdates.Close()
+
 	r.logger.Info("reactor stopped")
-	return nil
 }
 
-// Receive implements Reactor by handling different message types.
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (r *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	logger := r.logger.With("src", src.ID(), "chID", chID)
-
-	msg, err := bc.DecodeMsg(msgBytes)
-	if err != nil {
-		logger.Error("error decoding message", "err", err)
-		_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
+// sendEvent sends an event to the running fast sync, if any. It must be called
+// with r.mtx read-locked.
+func (r *BlockchainReactor) sendEvent(event Event) {
+	if r.events == nil {
 		return
 	}
 
-	if err = bc.ValidateMsg(msg); err != nil {
-		logger.Error("peer sent us invalid msg", "msg", msg, "err", err)
-		_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
-		return
+	select {
+	case r.events <- event:
+	case <-r.closeCh:
 	}
+}
+
+// handleBlockchainMessage handles envelopes sent from peers on the
+// BlockchainChannel. It returns an error only if the Envelope.Message is
+// unknown for this channel or invalid.
+func (r *BlockchainReactor) handleBlockchainMessage(envelope p2p.Envelope) error {
+	logger := r.logger.With("peer", envelope.From.String())
+	peerID := p2p.ID(envelope.From.String())
 
-	r.logger.Debug("Receive", "msg", msg)
+	logger.Debug("Receive", "msg", envelope.Message)
 
-	switch msg := msg.(type) {
+	switch msg := envelope.Message.(type) {
 	case *bcproto.StatusRequest:
-		if err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), src); err != nil {
-			logger.Error("Could not send status message to src peer")
+		if err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), peerID); err != nil {
+			logger.Error("Could not send status message to src peer", "err", err)
 		}
 
 	case *bcproto.BlockRequest:
 		block := r.store.LoadBlock(msg.Height)
 		if block != nil {
-			if err = r.io.sendBlockToPeer(block, src); err != nil {
+			if err := r.io.sendBlockToPeer(block, peerID); err != nil {
 				logger.Error("Could not send block message to src peer", "err", err)
 			}
 		} else {
 			logger.Info("peer asking for a block we don't have", "height", msg.Height)
-			if err = r.io.sendBlockNotFound(msg.Height, src); err != nil {
+			if err := r.io.sendBlockNotFound(msg.Height, peerID); err != nil {
 				logger.Error("Couldn't send block not found msg", "err", err)
 			}
 		}
 
 	case *bcproto.StatusResponse:
 		r.mtx.RLock()
-		if r.events != nil {
-			r.events <- bcStatusResponse{peerID: src.ID(), base: msg.Base, height: msg.Height}
-		}
+		r.sendEvent(bcStatusResponse{peerID: peerID, base: msg.Base, height: msg.Height})
 		r.mtx.RUnlock()
 
 	case *bcproto.BlockResponse:
-		r.mtx.RLock()
 		bi, err := types.BlockFromProto(msg.Block)
 		if err != nil {
 			logger.Error("error transitioning block from protobuf", "err", err)
-			_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
-			return
-		}
-		if r.events != nil {
-			r.events <- bcBlockResponse{
-				peerID: src.ID(),
-				block:  bi,
-				size:   int64(len(msgBytes)),
-				time:   time.Now(),
-			}
+			return err
 		}
+
+		r.mtx.RLock()
+		r.sendEvent(bcBlockResponse{
+			peerID: peerID,
+			block:  bi,
+			size:   int64(msg.Size()),
+			time:   time.Now(),
+		})
 		r.mtx.RUnlock()
 
 	case *bcproto.NoBlockResponse:
 		r.mtx.RLock()
-		if r.events != nil {
-			r.events <- bcNoBlockResponse{peerID: src.ID(), height: msg.Height, time: time.Now()}
-		}
+		r.sendEvent(bcNoBlockResponse{peerID: peerID, height: msg.Height, time: time.Now()})
 		r.mtx.RUnlock()
+
+	default:
+		logger.Error("received unknown message", "msg", msg)
+		return fmt.Errorf("received unknown message: %T", msg)
 	}
+
+	return nil
 }
 
-// AddPeer implements Reactor interface
-func (r *BlockchainReactor) AddPeer(peer p2p.Peer) {
-	err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), peer)
-	if err != nil {
-		r.logger.Error("could not send our status to the new peer", "peer", peer.ID, "err", err)
-	}
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *BlockchainReactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.logger.Error("recovering from processing message panic", "err", err)
+		}
+	}()
+
+	switch chID {
+	case BlockchainChannel:
+		err = r.handleBlockchainMessage(envelope)
 
-	err = r.io.sendStatusRequest(peer)
-	if err != nil {
-		r.logger.Error("could not send status request to the new peer", "peer", peer.ID, "err", err)
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
 	}
 
-	r.mtx.RLock()
-	defer r.mtx.RUnlock()
-	if r.events != nil {
-		r.events <- bcAddNewPeer{peerID: peer.ID()}
+	return err
+}
+
+// processBlockchainCh initiates a blocking process where we listen for and
+// handle envelopes on the BlockchainChannel. Any error encountered during
+// message execution will result in a PeerError being sent on the
+// BlockchainChannel.
+func (r *BlockchainReactor) processBlockchainCh() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case envelope := <-r.blockchainCh.In():
+			if err := r.handleMessage(r.blockchainCh.ID(), envelope); err != nil {
+				select {
+				case r.blockchainCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
+				}:
+				case <-r.closeCh:
+				}
+			}
+
+		case <-r.closeCh:
+			r.logger.Debug("stopped listening on blockchain channel")
+			return
+		}
 	}
 }
 
-// RemovePeer implements Reactor interface.
-func (r *BlockchainReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
-	r.mtx.RLock()
-	defer r.mtx.RUnlock()
-	if r.events != nil {
-		r.events <- bcRemovePeer{
-			peerID: peer.ID(),
-			reason: reason,
+// processPeerUpdate processes a PeerUpdate. When a peer comes up, we exchange
+// statuses with it and add it to the running fast sync, if any. When it goes
+// down, it is removed from the fast sync.
+func (r *BlockchainReactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
+
+	peerID := p2p.ID(peerUpdate.PeerID.String())
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), peerID)
+		if err != nil {
+			r.logger.Error("could not send our status to the new peer", "peer", peerID, "err", err)
 		}
+
+		err = r.io.sendStatusRequest(peerID)
+		if err != nil {
+			r.logger.Error("could not send status request to the new peer", "peer", peerID, "err", err)
+		}
+
+		r.mtx.RLock()
+		r.sendEvent(bcAddNewPeer{peerID: peerID})
+		r.mtx.RUnlock()
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		r.mtx.RLock()
+		r.sendEvent(bcRemovePeer{peerID: peerID, reason: peerUpdate.Status})
+		r.mtx.RUnlock()
 	}
 }
 
-// GetChannels implements Reactor
-func (r *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  BlockchainChannel,
-			Priority:            10,
-			SendQueueCapacity:   2000,
-			RecvBufferCapacity:  50 * 4096,
-			RecvMessageCapacity: bc.MaxMsgSize,
-		},
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages.
+func (r *BlockchainReactor) processPeerUpdates() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.logger.Debug("stopped listening on peer updates channel")
+			return
+		}
 	}
 }
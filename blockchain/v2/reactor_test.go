@@ -2,26 +2,21 @@ package v2
 
 import (
 	"fmt"
-	"net"
 	"os"
 	"sort"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/behaviour"
-	bc "github.com/tendermint/tendermint/blockchain"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/mempool/mock"
 	"github.com/tendermint/tendermint/p2p"
-	"github.com/tendermint/tendermint/p2p/conn"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -30,35 +25,6 @@ import (
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
-type mockPeer struct {
-	service.Service
-	id p2p.ID
-}
-
-func (mp mockPeer) FlushStop()           {}
-func (mp mockPeer) ID() p2p.ID           { return mp.id }
-func (mp mockPeer) RemoteIP() net.IP     { return net.IP{} }
-func (mp mockPeer) RemoteAddr() net.Addr { return &net.TCPAddr{IP: mp.RemoteIP(), Port: 8800} }
-
-func (mp mockPeer) IsOutbound() bool   { return true }
-func (mp mockPeer) IsPersistent() bool { return true }
-func (mp mockPeer) CloseConn() error   { return nil }
-
-func (mp mockPeer) NodeInfo() p2p.NodeInfo {
-	return p2p.NodeInfo{
-		DefaultNodeID: "",
-		ListenAddr:    "",
-	}
-}
-func (mp mockPeer) Status() conn.ConnectionStatus { return conn.ConnectionStatus{} }
-func (mp mockPeer) SocketAddr() *p2p.NetAddress   { return &p2p.NetAddress{} }
-
-func (mp mockPeer) Send(byte, []byte) bool    { return true }
-func (mp mockPeer) TrySend(byte, []byte) bool { return true }
-
-func (mp mockPeer) Set(string, interface{}) {}
-func (mp mockPeer) Get(string) interface{}  { return struct{}{} }
-
 //nolint:unused
 type mockBlockStore struct {
 	blocks map[int64]*types.Block
@@ -76,6 +42,24 @@ func (ml *mockBlockStore) SaveBlock(block *types.Block, part *types.PartSet, com
 	ml.blocks[block.Height] = block
 }
 
+// heightLimitedStore serves the blocks of a shared store up to height, so
+// that several peers can serve the same chain at different heights.
+type heightLimitedStore struct {
+	*store.BlockStore
+	height int64
+}
+
+func (s heightLimitedStore) Height() int64 {
+	return s.height
+}
+
+func (s heightLimitedStore) LoadBlock(height int64) *types.Block {
+	if height > s.height {
+		return nil
+	}
+	return s.BlockStore.LoadBlock(height)
+}
+
 type mockBlockApplier struct {
 }
 
@@ -87,97 +71,223 @@ func (mba *mockBlockApplier) ApplyBlock(
 	return state, 0, nil
 }
 
-type mockSwitchIo struct {
-	mtx                 sync.Mutex
-	switchedToConsensus bool
-	numStatusResponse   int
-	numBlockResponse    int
-	numNoBlockResponse  int
-	numStatusRequest    int
+type mockConsensusReactor struct {
+	switchedCh chan sm.State
 }
 
-var _ iIO = (*mockSwitchIo)(nil)
+func newMockConsensusReactor() *mockConsensusReactor {
+	return &mockConsensusReactor{switchedCh: make(chan sm.State, 1)}
+}
 
-func (sio *mockSwitchIo) sendBlockRequest(_ p2p.Peer, _ int64) error {
-	return nil
+func (cr *mockConsensusReactor) SwitchToConsensus(state sm.State, skipWAL bool) {
+	cr.switchedCh <- state
 }
 
-func (sio *mockSwitchIo) sendStatusResponse(_, _ int64, _ p2p.Peer) error {
-	sio.mtx.Lock()
-	defer sio.mtx.Unlock()
-	sio.numStatusResponse++
-	return nil
+type testReactorParams struct {
+	logger      log.Logger
+	genDoc      *types.GenesisDoc
+	privVals    []types.PrivValidator
+	startHeight int64
+	mockA       bool
+	fastSync    bool
 }
 
-func (sio *mockSwitchIo) sendBlockToPeer(_ *types.Block, _ p2p.Peer) error {
-	sio.mtx.Lock()
-	defer sio.mtx.Unlock()
-	sio.numBlockResponse++
-	return nil
+type reactorTestSuite struct {
+	reactor   *BlockchainReactor
+	consensus *mockConsensusReactor
+
+	blockchainChannel   *p2p.Channel
+	blockchainInCh      chan p2p.Envelope
+	blockchainOutCh     chan p2p.Envelope
+	blockchainPeerErrCh chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+	peerUpdates   *p2p.PeerUpdatesCh
 }
 
-func (sio *mockSwitchIo) sendBlockNotFound(_ int64, _ p2p.Peer) error {
-	sio.mtx.Lock()
-	defer sio.mtx.Unlock()
-	sio.numNoBlockResponse++
-	return nil
+func setup(t *testing.T, state sm.State, store blockStore, appl blockApplier, fastSync bool,
+	chBuf uint) *reactorTestSuite {
+	t.Helper()
+
+	rts := &reactorTestSuite{
+		consensus:           newMockConsensusReactor(),
+		blockchainInCh:      make(chan p2p.Envelope, chBuf),
+		blockchainOutCh:     make(chan p2p.Envelope, chBuf),
+		blockchainPeerErrCh: make(chan p2p.PeerError, chBuf),
+		peerUpdatesCh:       make(chan p2p.PeerUpdate),
+	}
+
+	rts.blockchainChannel = p2p.NewChannel(
+		BlockchainChannel,
+		new(bcproto.Message),
+		rts.blockchainInCh,
+		rts.blockchainOutCh,
+		rts.blockchainPeerErrCh,
+	)
+	rts.peerUpdates = p2p.NewPeerUpdates(rts.peerUpdatesCh)
+
+	rts.reactor = newReactor(state, store, appl, rts.consensus, rts.blockchainChannel, rts.peerUpdates, fastSync)
+	rts.reactor.SetLogger(log.TestingLogger().With("module", "blockchain"))
+
+	require.NoError(t, rts.reactor.Start())
+	require.True(t, rts.reactor.IsRunning())
+
+	t.Cleanup(func() {
+		require.NoError(t, rts.reactor.Stop())
+		require.False(t, rts.reactor.IsRunning())
+	})
+
+	return rts
 }
 
-func (sio *mockSwitchIo) trySwitchToConsensus(_ sm.State, _ bool) bool {
-	sio.mtx.Lock()
-	defer sio.mtx.Unlock()
-	sio.switchedToConsensus = true
-	return true
+func newTestApplier(p testReactorParams, state sm.State) blockApplier {
+	if p.mockA {
+		return &mockBlockApplier{}
+	}
+
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	if err != nil {
+		panic(fmt.Errorf("error start app: %w", err))
+	}
+	db := dbm.NewMemDB()
+	stateStore := sm.NewStore(db)
+	if err = stateStore.Save(state); err != nil {
+		panic(err)
+	}
+	return sm.NewBlockExecutor(stateStore, p.logger, proxyApp.Consensus(), mock.Mempool{}, sm.EmptyEvidencePool{})
 }
 
-func (sio *mockSwitchIo) broadcastStatusRequest() error {
-	return nil
+func newTestReactor(t *testing.T, p testReactorParams) *reactorTestSuite {
+	store, state, _ := newReactorStore(p.genDoc, p.privVals, p.startHeight)
+	return setup(t, state, store, newTestApplier(p, state), p.fastSync, 100)
 }
 
-func (sio *mockSwitchIo) sendStatusRequest(_ p2p.Peer) error {
-	sio.mtx.Lock()
-	defer sio.mtx.Unlock()
-	sio.numStatusRequest++
-	return nil
+// testNetwork routes the envelopes sent by a set of reactors to their
+// connected peers, as the p2p router would. A peer may also be scripted,
+// responding to each message it receives with the messages returned by its
+// respond function.
+type testNetwork struct {
+	t *testing.T
+
+	mtx       sync.Mutex
+	nodes     map[string]*reactorTestSuite
+	scripted  map[string]func(msg proto.Message) []proto.Message
+	connected map[string]map[string]bool
 }
 
-type testReactorParams struct {
-	logger      log.Logger
-	genDoc      *types.GenesisDoc
-	privVals    []types.PrivValidator
-	startHeight int64
-	mockA       bool
+func newTestNetwork(t *testing.T) *testNetwork {
+	return &testNetwork{
+		t:         t,
+		nodes:     make(map[string]*reactorTestSuite),
+		scripted:  make(map[string]func(msg proto.Message) []proto.Message),
+		connected: make(map[string]map[string]bool),
+	}
 }
 
-func newTestReactor(p testReactorParams) *BlockchainReactor {
-	store, state, _ := newReactorStore(p.genDoc, p.privVals, p.startHeight)
-	reporter := behaviour.NewMockReporter()
+// addNode adds a reactor to the network and starts routing its envelopes.
+func (n *testNetwork) addNode(peerID p2p.PeerID, rts *reactorTestSuite) {
+	n.mtx.Lock()
+	n.nodes[peerID.String()] = rts
+	n.connected[peerID.String()] = make(map[string]bool)
+	n.mtx.Unlock()
+
+	go func() {
+		// the reactor closes its outbound channel when stopping
+		for envelope := range rts.blockchainOutCh {
+			n.route(peerID, envelope)
+		}
+	}()
+}
 
-	var appl blockApplier
+// addScriptedPeer adds a peer answering each message with the result of respond.
+func (n *testNetwork) addScriptedPeer(peerID p2p.PeerID, respond func(msg proto.Message) []proto.Message) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.scripted[peerID.String()] = respond
+	n.connected[peerID.String()] = make(map[string]bool)
+}
 
-	if p.mockA {
-		appl = &mockBlockApplier{}
-	} else {
-		app := &testApp{}
-		cc := proxy.NewLocalClientCreator(app)
-		proxyApp := proxy.NewAppConns(cc)
-		err := proxyApp.Start()
-		if err != nil {
-			panic(fmt.Errorf("error start app: %w", err))
+func (n *testNetwork) route(from p2p.PeerID, envelope p2p.Envelope) {
+	n.mtx.Lock()
+	var targets []string
+	for peer := range n.connected[from.String()] {
+		if envelope.Broadcast || peer == envelope.To.String() {
+			targets = append(targets, peer)
 		}
-		db := dbm.NewMemDB()
-		stateStore := sm.NewStore(db)
-		appl = sm.NewBlockExecutor(stateStore, p.logger, proxyApp.Consensus(), mock.Mempool{}, sm.EmptyEvidencePool{})
-		if err = stateStore.Save(state); err != nil {
-			panic(err)
+	}
+	n.mtx.Unlock()
+
+	for _, to := range targets {
+		n.deliver(from, to, envelope.Message)
+	}
+}
+
+func (n *testNetwork) deliver(from p2p.PeerID, to string, msg proto.Message) {
+	n.mtx.Lock()
+	rts, isNode := n.nodes[to]
+	respond := n.scripted[to]
+	n.mtx.Unlock()
+
+	if isNode {
+		select {
+		case rts.blockchainInCh <- p2p.Envelope{From: from, Message: msg}:
+		case <-rts.blockchainChannel.Done():
 		}
+		return
 	}
 
-	r := newReactor(state, store, reporter, appl, true)
-	logger := log.TestingLogger()
-	r.SetLogger(logger.With("module", "blockchain"))
+	toID, err := p2p.PeerIDFromString(to)
+	require.NoError(n.t, err)
+	for _, resp := range respond(msg) {
+		n.deliver(toID, from.String(), resp)
+	}
+}
+
+// connect connects two peers, notifying the reactors among them.
+func (n *testNetwork) connect(a, b p2p.PeerID) {
+	n.mtx.Lock()
+	n.connected[a.String()][b.String()] = true
+	n.connected[b.String()][a.String()] = true
+	n.mtx.Unlock()
 
-	return r
+	n.sendPeerUpdate(a, b, p2p.PeerStatusUp)
+	n.sendPeerUpdate(b, a, p2p.PeerStatusUp)
+}
+
+// disconnect disconnects two peers, notifying the reactors among them.
+func (n *testNetwork) disconnect(a, b p2p.PeerID) {
+	n.mtx.Lock()
+	delete(n.connected[a.String()], b.String())
+	delete(n.connected[b.String()], a.String())
+	n.mtx.Unlock()
+
+	n.sendPeerUpdate(a, b, p2p.PeerStatusDown)
+	n.sendPeerUpdate(b, a, p2p.PeerStatusDown)
+}
+
+func (n *testNetwork) sendPeerUpdate(to, peerID p2p.PeerID, status p2p.PeerStatus) {
+	n.mtx.Lock()
+	rts, ok := n.nodes[to.String()]
+	n.mtx.Unlock()
+
+	if ok {
+		rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: status}
+	}
+}
+
+func waitForConsensus(t *testing.T, rts *reactorTestSuite) sm.State {
+	t.Helper()
+
+	select {
+	case state := <-rts.consensus.switchedCh:
+		return state
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the reactor to switch to consensus")
+	}
+	return sm.State{}
 }
 
 // This test is left here and not deleted to retain the termination cases for
@@ -357,10 +467,6 @@ func newTestReactor(p testReactorParams) *BlockchainReactor {
 // }
 
 func TestReactorHelperMode(t *testing.T) {
-	var (
-		channelID = byte(0x40)
-	)
-
 	config := cfg.ResetTestRoot("blockchain_reactor_v2_test")
 	defer os.RemoveAll(config.RootDir)
 	genDoc, privVals := randGenesisDoc(config.ChainID(), 1, false, 30)
@@ -374,8 +480,9 @@ func TestReactorHelperMode(t *testing.T) {
 	}
 
 	type testEvent struct {
-		peer  string
-		event interface{}
+		peer     p2p.PeerID
+		request  proto.Message
+		response proto.Message
 	}
 
 	tests := []struct {
@@ -387,10 +494,10 @@ func TestReactorHelperMode(t *testing.T) {
 			name:   "status request",
 			params: params,
 			msgs: []testEvent{
-				{"P1", bcproto.StatusRequest{}},
-				{"P1", bcproto.BlockRequest{Height: 13}},
-				{"P1", bcproto.BlockRequest{Height: 20}},
-				{"P1", bcproto.BlockRequest{Height: 22}},
+				{p2p.PeerID{0x01}, &bcproto.StatusRequest{}, &bcproto.StatusResponse{Base: 1, Height: 20}},
+				{p2p.PeerID{0x01}, &bcproto.BlockRequest{Height: 13}, &bcproto.BlockResponse{}},
+				{p2p.PeerID{0x02}, &bcproto.BlockRequest{Height: 20}, &bcproto.BlockResponse{}},
+				{p2p.PeerID{0x01}, &bcproto.BlockRequest{Height: 22}, &bcproto.NoBlockResponse{Height: 22}},
 			},
 		},
 	}
@@ -398,58 +505,193 @@ func TestReactorHelperMode(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			reactor := newTestReactor(params)
-			mockSwitch := &mockSwitchIo{switchedToConsensus: false}
-			reactor.io = mockSwitch
-			err := reactor.Start()
-			require.NoError(t, err)
+			rts := newTestReactor(t, tt.params)
+
+			for _, step := range tt.msgs {
+				rts.blockchainInCh <- p2p.Envelope{From: step.peer, Message: step.request}
+
+				envelope := <-rts.blockchainOutCh
+				require.Equal(t, step.peer, envelope.To)
+				require.IsType(t, step.response, envelope.Message)
 
-			for i := 0; i < len(tt.msgs); i++ {
-				step := tt.msgs[i]
-				switch ev := step.event.(type) {
-				case bcproto.StatusRequest:
-					old := mockSwitch.numStatusResponse
-					msg, err := bc.EncodeMsg(&ev)
-					assert.NoError(t, err)
-					reactor.Receive(channelID, mockPeer{id: p2p.ID(step.peer)}, msg)
-					assert.Equal(t, old+1, mockSwitch.numStatusResponse)
-				case bcproto.BlockRequest:
-					if ev.Height > params.startHeight {
-						old := mockSwitch.numNoBlockResponse
-						msg, err := bc.EncodeMsg(&ev)
-						assert.NoError(t, err)
-						reactor.Receive(channelID, mockPeer{id: p2p.ID(step.peer)}, msg)
-						assert.Equal(t, old+1, mockSwitch.numNoBlockResponse)
-					} else {
-						old := mockSwitch.numBlockResponse
-						msg, err := bc.EncodeMsg(&ev)
-						assert.NoError(t, err)
-						assert.NoError(t, err)
-						reactor.Receive(channelID, mockPeer{id: p2p.ID(step.peer)}, msg)
-						assert.Equal(t, old+1, mockSwitch.numBlockResponse)
-					}
+				if resp, ok := envelope.Message.(*bcproto.BlockResponse); ok {
+					req := step.request.(*bcproto.BlockRequest)
+					require.Equal(t, req.Height, resp.Block.Header.Height)
+				} else {
+					require.Equal(t, step.response, envelope.Message)
 				}
 			}
-			err = reactor.Stop()
-			require.NoError(t, err)
+			require.Empty(t, rts.blockchainPeerErrCh)
 		})
 	}
 }
 
-func TestReactorSetSwitchNil(t *testing.T) {
+func TestReactorInvalidMessage(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_v2_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(config.ChainID(), 1, false, 30)
+
+	rts := newTestReactor(t, testReactorParams{
+		logger:   log.TestingLogger(),
+		genDoc:   genDoc,
+		privVals: privVals,
+		mockA:    true,
+	})
+
+	rts.blockchainInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &bcproto.Message{},
+	}
+
+	peerErr := <-rts.blockchainPeerErrCh
+	require.Error(t, peerErr.Err)
+	require.Contains(t, peerErr.Err.Error(), "received unknown message")
+	require.Equal(t, p2p.PeerID{0xAA}, peerErr.PeerID)
+	require.Empty(t, rts.blockchainOutCh)
+}
+
+func TestReactorPeerUpdates(t *testing.T) {
 	config := cfg.ResetTestRoot("blockchain_reactor_v2_test")
 	defer os.RemoveAll(config.RootDir)
 	genDoc, privVals := randGenesisDoc(config.ChainID(), 1, false, 30)
 
-	reactor := newTestReactor(testReactorParams{
+	rts := newTestReactor(t, testReactorParams{
+		logger:      log.TestingLogger(),
+		genDoc:      genDoc,
+		privVals:    privVals,
+		startHeight: 10,
+		mockA:       true,
+	})
+
+	// a new peer is sent our status, and is asked for its own
+	peerID := p2p.PeerID{0xAA}
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
+
+	envelope := <-rts.blockchainOutCh
+	require.Equal(t, peerID, envelope.To)
+	require.Equal(t, &bcproto.StatusResponse{Base: 1, Height: 10}, envelope.Message)
+
+	envelope = <-rts.blockchainOutCh
+	require.Equal(t, peerID, envelope.To)
+	require.Equal(t, &bcproto.StatusRequest{}, envelope.Message)
+
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusDown}
+	require.Empty(t, rts.blockchainOutCh)
+}
+
+// newSyncNetwork creates a network made of a syncing reactor at genesis and
+// helper reactors serving the same chain up to the given heights, none of
+// them connected yet.
+func newSyncNetwork(t *testing.T, helperHeights map[string]int64) (*testNetwork, *reactorTestSuite, *store.BlockStore) {
+	config := cfg.ResetTestRoot("blockchain_reactor_v2_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	genDoc, privVals := randGenesisDoc(config.ChainID(), 1, false, 30)
+
+	maxHeight := int64(0)
+	for _, height := range helperHeights {
+		if height > maxHeight {
+			maxHeight = height
+		}
+	}
+	refStore, _, _ := newReactorStore(genDoc, privVals, maxHeight)
+
+	network := newTestNetwork(t)
+	params := testReactorParams{
 		logger:   log.TestingLogger(),
 		genDoc:   genDoc,
 		privVals: privVals,
+		mockA:    true,
+		fastSync: true,
+	}
+	syncing := newTestReactor(t, params)
+	network.addNode(p2p.PeerID{0xFF}, syncing)
+
+	for id, height := range helperHeights {
+		peerID, err := p2p.PeerIDFromString(id)
+		require.NoError(t, err)
+
+		helper := setup(t, sm.State{}, heightLimitedStore{refStore, height}, &mockBlockApplier{}, false, 100)
+		network.addNode(peerID, helper)
+	}
+
+	return network, syncing, refStore
+}
+
+func TestReactorMultiPeerSync(t *testing.T) {
+	network, syncing, _ := newSyncNetwork(t, map[string]int64{
+		"01": 30,
+		"02": 25,
+		"03": 15,
+	})
+
+	syncingID := p2p.PeerID{0xFF}
+	network.connect(syncingID, p2p.PeerID{0x01})
+	network.connect(syncingID, p2p.PeerID{0x02})
+	network.connect(syncingID, p2p.PeerID{0x03})
+
+	// the last block can't be verified without the commit of the next one
+	state := waitForConsensus(t, syncing)
+	require.EqualValues(t, 29, state.LastBlockHeight)
+	require.EqualValues(t, 29, syncing.reactor.SyncHeight())
+	require.Empty(t, syncing.blockchainPeerErrCh)
+}
+
+func TestReactorMultiPeerSyncPeerDisconnects(t *testing.T) {
+	network, syncing, _ := newSyncNetwork(t, map[string]int64{
+		"01": 30,
+		"02": 30,
+	})
+
+	syncingID := p2p.PeerID{0xFF}
+	network.connect(syncingID, p2p.PeerID{0x01})
+	network.connect(syncingID, p2p.PeerID{0x02})
+
+	// drop a peer halfway through, the other one serves the remaining blocks
+	require.Eventually(t, func() bool {
+		return syncing.reactor.SyncHeight() >= 10
+	}, 10*time.Second, 10*time.Millisecond)
+	network.disconnect(syncingID, p2p.PeerID{0x01})
+
+	state := waitForConsensus(t, syncing)
+	require.EqualValues(t, 29, state.LastBlockHeight)
+}
+
+func TestReactorMultiPeerSyncBadPeer(t *testing.T) {
+	network, syncing, refStore := newSyncNetwork(t, map[string]int64{
+		"02": 30,
+	})
+
+	// the bad peer claims to be at height 20, but answers every block
+	// request with a block it was not asked for
+	badID := p2p.PeerID{0x01}
+	network.addScriptedPeer(badID, func(msg proto.Message) []proto.Message {
+		switch msg.(type) {
+		case *bcproto.StatusRequest:
+			return []proto.Message{&bcproto.StatusResponse{Base: 1, Height: 20}}
+		case *bcproto.BlockRequest:
+			block, err := refStore.LoadBlock(30).ToProto()
+			require.NoError(t, err)
+			return []proto.Message{&bcproto.BlockResponse{Block: block}}
+		}
+		return nil
 	})
-	reactor.SetSwitch(nil)
 
-	assert.Nil(t, reactor.Switch)
-	assert.Nil(t, reactor.io)
+	// connect the bad peer alone first, so it is sent the first block request
+	syncingID := p2p.PeerID{0xFF}
+	network.connect(syncingID, badID)
+
+	select {
+	case peerErr := <-syncing.blockchainPeerErrCh:
+		require.Equal(t, badID, peerErr.PeerID)
+		require.Contains(t, peerErr.Err.Error(), "without being requested")
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the bad peer to be reported")
+	}
+
+	network.connect(syncingID, p2p.PeerID{0x02})
+
+	state := waitForConsensus(t, syncing)
+	require.EqualValues(t, 29, state.LastBlockHeight)
 }
 
 //----------------------------------------------
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 
 	dbm "github.com/tendermint/tm-db"
@@ -21,6 +22,10 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	ldaemon "github.com/tendermint/tendermint/light/daemon"
+	"github.com/tendermint/tendermint/light/provider"
+	lightgrpc "github.com/tendermint/tendermint/light/provider/grpc"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
@@ -43,12 +48,29 @@ need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
-When /abci_query is called, the Merkle key path format is:
+In daemon mode (--daemon), the light client also keeps syncing to the latest
+header of the primary every --sync-interval, cross-checking it with the
+witnesses. The sync status (latest trusted height and time, witness health and
+detected attacks) is served as JSON on /status, and Prometheus metrics on
+/metrics, at --status-laddr.
+
+When /abci_query is called, the proof returned by the primary is verified
+against the app hash of a verified header, using the proof verifiers given by
+--proof-verifiers ("simple" for simple merkle proofs, "ics23" for ICS23
+commitment proofs). The Merkle key path is built according to
+--key-path-format, which is either "store":
 
 	/{store name}/{key}
 
-Please verify with your application that this Merkle key format is used (true
-for applications built w/ Cosmos SDK).
+for a query path like /store/{store name}/key (true for applications built w/
+Cosmos SDK), or "key":
+
+	/{key}
+
+for applications that keep all of their state in a single tree.
+
+Proofs of the transactions returned by /tx and /tx_search with prove=true are
+verified against the data hash of a verified header.
 `,
 	RunE: runProxy,
 	Args: cobra.ExactArgs(1),
@@ -64,14 +86,22 @@ var (
 	dir                string
 	maxOpenConnections int
 
-	sequential     bool
-	trustingPeriod time.Duration
-	trustedHeight  int64
-	trustedHash    []byte
-	trustLevelStr  string
+	sequential          bool
+	headerOnlyBisection bool
+	trustingPeriod      time.Duration
+	trustedHeight       int64
+	trustedHash         []byte
+	trustLevelStr       string
 
 	verbose bool
 
+	proofVerifiers []string
+	keyPathFormat  string
+
+	daemonMode   bool
+	syncInterval time.Duration
+	statusAddr   string
+
 	primaryKey   = []byte("primary")
 	witnessesKey = []byte("witnesses")
 )
@@ -82,8 +112,9 @@ func init() {
 	LightCmd.Flags().StringVarP(&primaryAddr, "primary", "p", "",
 		"connect to a Tendermint node at this address")
 	LightCmd.Flags().StringVarP(&witnessAddrsJoined, "witnesses", "w", "",
-		"tendermint nodes to cross-check the primary node, comma-separated")
-	LightCmd.Flags().StringVarP(&dir, "dir", "d", os.ExpandEnv(filepath.Join("$HOME", ".tendermint-light")),
+		"tendermint nodes to cross-check the primary node, comma-separated. "+
+			"Nodes given as grpc://host:port are reached through their gRPC API")
+	LightCmd.PersistentFlags().StringVarP(&dir, "dir", "d", os.ExpandEnv(filepath.Join("$HOME", ".tendermint-light")),
 		"specify the directory")
 	LightCmd.Flags().IntVar(
 		&maxOpenConnections,
@@ -101,6 +132,26 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().BoolVar(&headerOnlyBisection, "header-only-bisection", false,
+		"fetch the validator sets of the intermediate headers of skipping verification only once they can be trusted "+
+			"(from providers supporting /commit_signers)",
+	)
+	LightCmd.Flags().StringSliceVar(&proofVerifiers, "proof-verifiers", []string{"simple", "ics23"},
+		"verifiers of the proofs returned by /abci_query, comma-separated. One of: "+
+			strings.Join(lproxy.ProofVerifiers(), ", "),
+	)
+	LightCmd.Flags().StringVar(&keyPathFormat, "key-path-format", "store",
+		`format of the Merkle key path of the proofs returned by /abci_query. "store" or "key"`,
+	)
+	LightCmd.Flags().BoolVar(&daemonMode, "daemon", false,
+		"keep syncing headers in the background and serve the sync status",
+	)
+	LightCmd.Flags().DurationVar(&syncInterval, "sync-interval", ldaemon.DefaultSyncInterval,
+		"interval between two header syncs in daemon mode",
+	)
+	LightCmd.Flags().StringVar(&statusAddr, "status-laddr", "tcp://localhost:8889",
+		"serve the sync status (/status) and Prometheus metrics (/metrics) on the given address in daemon mode",
+	)
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -149,6 +200,15 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("can't parse trust level: %w", err)
 	}
 
+	prt, err := lproxy.NewProofRuntime(proofVerifiers...)
+	if err != nil {
+		return err
+	}
+	keyPathFn, err := merkleKeyPathFn(keyPathFormat)
+	if err != nil {
+		return err
+	}
+
 	options := []light.Option{
 		light.Logger(logger),
 		light.ConfirmationFunction(func(action string) bool {
@@ -173,11 +233,26 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		options = append(options, light.SequentialVerification())
 	} else {
 		options = append(options, light.SkippingVerification(trustLevel))
+		if headerOnlyBisection {
+			options = append(options, light.HeaderOnlyBisection())
+		}
+	}
+
+	primary, err := lighthttp.New(chainID, primaryAddr)
+	if err != nil {
+		return fmt.Errorf("can't create primary provider: %w", err)
+	}
+	witnesses := make([]provider.Provider, len(witnessesAddrs))
+	for i, addr := range witnessesAddrs {
+		witnesses[i], err = newProvider(chainID, addr)
+		if err != nil {
+			return fmt.Errorf("can't create witness provider %s: %w", addr, err)
+		}
 	}
 
 	var c *light.Client
 	if trustedHeight > 0 && len(trustedHash) > 0 { // fresh installation
-		c, err = light.NewHTTPClient(
+		c, err = light.NewClient(
 			context.Background(),
 			chainID,
 			light.TrustOptions{
@@ -185,17 +260,17 @@ func runProxy(cmd *cobra.Command, args []string) error {
 				Height: trustedHeight,
 				Hash:   trustedHash,
 			},
-			primaryAddr,
-			witnessesAddrs,
+			primary,
+			witnesses,
 			dbs.New(db, chainID),
 			options...,
 		)
 	} else { // continue from latest state
-		c, err = light.NewHTTPClientFromTrustedStore(
+		c, err = light.NewClientFromTrustedStore(
 			chainID,
 			trustingPeriod,
-			primaryAddr,
-			witnessesAddrs,
+			primary,
+			witnesses,
 			dbs.New(db, chainID),
 			options...,
 		)
@@ -219,15 +294,33 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	if cfg.WriteTimeout <= config.RPC.TimeoutBroadcastTxCommit {
 		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
+	// Same for EventsMaxWaitTime.
+	if cfg.WriteTimeout <= config.RPC.EventsMaxWaitTime {
+		cfg.WriteTimeout = config.RPC.EventsMaxWaitTime + 1*time.Second
+	}
 
 	p := lproxy.Proxy{
 		Addr:   listenAddr,
 		Config: cfg,
-		Client: lrpc.NewClient(rpcClient, c, lrpc.KeyPathFn(defaultMerkleKeyPathFn())),
+		Client: lrpc.NewClient(rpcClient, c, lrpc.KeyPathFn(keyPathFn), lrpc.ProofRuntime(prt)),
 		Logger: logger,
 	}
+
+	var d *ldaemon.Daemon
+	if daemonMode {
+		d, err = startDaemon(c, logger, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Stop upon receiving SIGTERM or CTRL-C.
 	tmos.TrapSignal(logger, func() {
+		if d != nil {
+			if err := d.Stop(); err != nil {
+				logger.Error("Failed to stop light daemon", "err", err)
+			}
+		}
 		p.Listener.Close()
 	})
 
@@ -240,6 +333,47 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// startDaemon starts syncing the light client in the background and serves the
+// sync status and metrics on statusAddr.
+func startDaemon(c *light.Client, logger log.Logger, cfg *rpcserver.Config) (*ldaemon.Daemon, error) {
+	d := ldaemon.NewDaemon(c, logger.With("module", "daemon"),
+		ldaemon.SyncInterval(syncInterval),
+		ldaemon.WithMetrics(ldaemon.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)),
+	)
+	if err := d.Start(); err != nil {
+		return nil, fmt.Errorf("can't start light daemon: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", d.StatusHandler)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	listener, err := rpcserver.Listen(statusAddr, cfg)
+	if err != nil {
+		if err := d.Stop(); err != nil {
+			logger.Error("Failed to stop light daemon", "err", err)
+		}
+		return nil, err
+	}
+	go func() {
+		logger.Info("Serving light daemon status...", "laddr", statusAddr)
+		if err := rpcserver.Serve(listener, mux, logger, cfg); err != nil {
+			logger.Error("light daemon status server", "err", err)
+		}
+	}()
+
+	return d, nil
+}
+
+// newProvider returns a gRPC provider for addresses prefixed with grpc://, and
+// an HTTP provider otherwise.
+func newProvider(chainID, addr string) (provider.Provider, error) {
+	if strings.HasPrefix(addr, "grpc://") {
+		return lightgrpc.New(chainID, "tcp://"+strings.TrimPrefix(addr, "grpc://"))
+	}
+	return lighthttp.New(chainID, addr)
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
@@ -265,6 +399,19 @@ func saveProviders(db dbm.DB, primaryAddr, witnessesAddrs string) error {
 	return nil
 }
 
+func merkleKeyPathFn(format string) (lrpc.KeyPathFunc, error) {
+	switch format {
+	case "store":
+		return defaultMerkleKeyPathFn(), nil
+	case "key":
+		return func(_ string, key []byte) (merkle.KeyPath, error) {
+			return merkle.KeyPath{}.AppendKey(key, merkle.KeyEncodingURL), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf(`unknown key path format %q, must be "store" or "key"`, format)
+	}
+}
+
 func defaultMerkleKeyPathFn() lrpc.KeyPathFunc {
 	// regexp for extracting store name from /abci_query path
 	storeNameRegexp := regexp.MustCompile(`\/store\/(.+)\/key`)
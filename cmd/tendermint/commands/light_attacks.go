@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/tendermint/tm-db"
+
+	dbs "github.com/tendermint/tendermint/light/store/db"
+)
+
+// LightAttacksCmd lists the attacks detected by the light client.
+var LightAttacksCmd = &cobra.Command{
+	Use:   "attacks [chainID]",
+	Short: "List the attacks detected by the light client",
+	Long: `List the attacks detected by the light client of the given chain, along with
+the full nodes which acknowledged their evidence.
+
+The evidence of an attack is reported to the primary and all the witnesses. If
+a node can't be reached, the light client retries on every sync (see --daemon)
+until all of them acknowledged it.
+
+The attack log is read from the light client's database, which is locked while
+the light client runs, so stop it first. A running daemon reports the pending
+attacks on its /status endpoint instead (see --status-laddr).`,
+	RunE:    listAttacks,
+	Args:    cobra.ExactArgs(1),
+	Example: `light attacks cosmoshub-3 --dir ~/.tendermint-light`,
+}
+
+func init() {
+	LightCmd.AddCommand(LightAttacksCmd)
+}
+
+func listAttacks(cmd *cobra.Command, args []string) error {
+	db, err := dbm.NewGoLevelDB("light-client-db", dir)
+	if err != nil {
+		return fmt.Errorf("can't open db: %w", err)
+	}
+	defer db.Close()
+
+	attacks, err := dbs.New(db, args[0]).Attacks()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DETECTED AT\tCONFLICTING HEIGHT\tCOMMON HEIGHT\tEVIDENCE HASH\tACKNOWLEDGED BY")
+	for _, attack := range attacks {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%X\t%s\n",
+			attack.DetectedAt.Format(time.RFC3339),
+			attack.Evidence.ConflictingBlock.Height,
+			attack.Evidence.CommonHeight,
+			attack.Evidence.Hash(),
+			strings.Join(attack.AcknowledgedBy, ", "))
+	}
+	return w.Flush()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	cfg "github.com/tendermint/tendermint/config"
+	cs "github.com/tendermint/tendermint/consensus"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	nm "github.com/tendermint/tendermint/node"
 )
@@ -40,6 +41,9 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64("consensus.double-sign-check-height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
+	cmd.Flags().Int32("consensus.double-sign-check-rounds", config.Consensus.DoubleSignCheckRounds,
+		"how many rounds to listen to vote gossip for votes signed with the node's "+
+			"consensus key before signing")
 
 	// abci flags
 	cmd.Flags().String(
@@ -127,8 +131,15 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 				}
 			})
 
-			// Run forever.
-			select {}
+			// Run until the node stops, which it does on its own if another
+			// instance is found signing with our validator key.
+			<-n.Quit()
+			select {
+			case <-n.ConsensusState().DoubleSignRisk():
+				return fmt.Errorf("node stopped: %w", cs.ErrSignatureFoundInGossip)
+			default:
+				return nil
+			}
 		},
 	}
 
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -128,6 +130,11 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.FastSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [fastsync] section: %w", err)
 	}
+	if cfg.FastSync.VerifyLightBlocks {
+		if err := cfg.StateSync.ValidateLightClient(); err != nil {
+			return fmt.Errorf("error in [statesync] section, required by fastsync verify-light-blocks: %w", err)
+		}
+	}
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
@@ -318,7 +325,7 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors-allowed-headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server only supports the routes of rpc/grpc/types.proto
 	GRPCListenAddress string `mapstructure:"grpc-laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -343,6 +350,7 @@ type RPCConfig struct {
 	// Maximum number of unique clientIDs that can /subscribe
 	// If you're using /broadcast_tx_commit, set to the estimated maximum number
 	// of broadcast_tx_commit calls per block.
+	// The calls to /events waiting for new events count as clients too.
 	MaxSubscriptionClients int `mapstructure:"max-subscription-clients"`
 
 	// Maximum number of unique queries a given client can /subscribe to
@@ -350,6 +358,18 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max-subscriptions-per-client"`
 
+	// Number of recent events kept by the node, so that the clients of
+	// /subscribe can resume their subscriptions from the last event they
+	// received (e.g. after reconnecting), by passing its sequence number and
+	// epoch in the since and epoch parameters (0 - no history). The events are
+	// kept with their data (e.g. the whole block of NewBlock events).
+	EventHistorySize int `mapstructure:"event-history-size"`
+
+	// Maximum time a call to /events may wait for new events
+	// WARNING: Using a value larger than 10s will result in increasing the
+	// global HTTP write timeout, which applies to all connections and endpoints.
+	EventsMaxWaitTime time.Duration `mapstructure:"events-max-wait-time"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -362,6 +382,48 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Maximum number of requests in a batch of JSON-RPC requests (0 - unlimited)
+	MaxBatchSize int `mapstructure:"max-batch-size"`
+
+	// Maximum total cost of the requests in a batch of JSON-RPC requests, where
+	// most methods cost 1 and expensive ones (e.g. /tx_search or
+	// /broadcast_tx_commit) cost 10 (0 - unlimited)
+	MaxBatchCost int `mapstructure:"max-batch-cost"`
+
+	// Number of requests of a batch of JSON-RPC requests executed concurrently.
+	// NOTE: with more than 1, transactions broadcast in the same batch may
+	// reach the mempool in any order.
+	BatchConcurrency int `mapstructure:"batch-concurrency"`
+
+	// Average number of calls per second each client can make, using a token
+	// bucket per client IP address (0 - unlimited). The IP address is the one
+	// of the connection, so the clients of a reverse proxy share its bucket.
+	RateLimit float64 `mapstructure:"rate-limit"`
+
+	// Number of calls each client can make at once
+	RateLimitBurst int `mapstructure:"rate-limit-burst"`
+
+	// Limits of the calls of each client to specific routes, with their own
+	// token buckets, as "route=rate:burst" (e.g. "tx_search=0.5:5"). A rate of 0
+	// means unlimited.
+	RateLimitRoutes []string `mapstructure:"rate-limit-routes"`
+
+	// The path to a JSON file with the API keys of the clients with their own
+	// limits, which replace RateLimit but not RateLimitRoutes, unless a key
+	// overrides them. Migth be either absolute path or path related to tendermint's
+	// config directory. The keys are reloaded on SIGHUP.
+	APIKeysFile string `mapstructure:"api-keys-file"`
+
+	// Maximum size of the cache of the encoded results of the immutable calls
+	// (/block, /block_results, /commit and /validators below the latest
+	// height), in bytes (0 - no caching)
+	ResponseCacheSize int64 `mapstructure:"response-cache-size"`
+
+	// How long the HTTP responses of the immutable calls may be cached by
+	// clients, proxies and CDNs, set in their Cache-Control header along with
+	// an ETag (0 - no caching headers)
+	ResponseCacheMaxAge time.Duration `mapstructure:"response-cache-max-age"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Migth be either absolute path or path related to tendermint's config directory.
 	//
@@ -390,7 +452,7 @@ func DefaultRPCConfig() *RPCConfig {
 		ListenAddress:          "tcp://127.0.0.1:26657",
 		CORSAllowedOrigins:     []string{},
 		CORSAllowedMethods:     []string{http.MethodHead, http.MethodGet, http.MethodPost},
-		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
+		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", "X-API-Key"},
 		GRPCListenAddress:      "",
 		GRPCMaxOpenConnections: 900,
 
@@ -399,11 +461,25 @@ func DefaultRPCConfig() *RPCConfig {
 
 		MaxSubscriptionClients:    100,
 		MaxSubscriptionsPerClient: 5,
+		EventHistorySize:          100,
+		EventsMaxWaitTime:         10 * time.Second,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxBatchSize:     100,
+		MaxBatchCost:     200,
+		BatchConcurrency: 4,
+
+		RateLimit:       0,
+		RateLimitBurst:  50,
+		RateLimitRoutes: []string{},
+		APIKeysFile:     "",
+
+		ResponseCacheSize:   16 << 20, // 16MB
+		ResponseCacheMaxAge: 24 * time.Hour,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -433,6 +509,12 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max-subscriptions-per-client can't be negative")
 	}
+	if cfg.EventHistorySize < 0 {
+		return errors.New("event-history-size can't be negative")
+	}
+	if cfg.EventsMaxWaitTime < 0 {
+		return errors.New("events-max-wait-time can't be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout-broadcast-tx-commit can't be negative")
 	}
@@ -442,9 +524,73 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.MaxBatchSize < 0 {
+		return errors.New("max-batch-size can't be negative")
+	}
+	if cfg.MaxBatchCost < 0 {
+		return errors.New("max-batch-cost can't be negative")
+	}
+	if cfg.BatchConcurrency < 1 {
+		return errors.New("batch-concurrency must be at least 1")
+	}
+	if cfg.ResponseCacheSize < 0 {
+		return errors.New("response-cache-size can't be negative")
+	}
+	if cfg.ResponseCacheMaxAge < 0 {
+		return errors.New("response-cache-max-age can't be negative")
+	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate-limit can't be negative")
+	}
+	if cfg.RateLimit > 0 && cfg.RateLimitBurst < 1 {
+		return errors.New("rate-limit-burst must be at least 1")
+	}
+	if _, err := cfg.RouteRateLimits(); err != nil {
+		return fmt.Errorf("rate-limit-routes: %w", err)
+	}
 	return nil
 }
 
+// RouteRateLimit is the rate limit of the calls to a route (see
+// RPCConfig.RateLimitRoutes).
+type RouteRateLimit struct {
+	Route string
+	Rate  float64
+	Burst int
+}
+
+// RouteRateLimits parses RateLimitRoutes.
+func (cfg *RPCConfig) RouteRateLimits() ([]RouteRateLimit, error) {
+	limits := make([]RouteRateLimit, len(cfg.RateLimitRoutes))
+	for i, s := range cfg.RateLimitRoutes {
+		errFormat := fmt.Errorf("%q isn't formatted as route=rate:burst", s)
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errFormat
+		}
+		limit := strings.SplitN(parts[1], ":", 2)
+		if len(limit) != 2 {
+			return nil, errFormat
+		}
+		l := RouteRateLimit{Route: parts[0]}
+		var err error
+		if l.Rate, err = strconv.ParseFloat(limit[0], 64); err != nil {
+			return nil, errFormat
+		}
+		if l.Burst, err = strconv.Atoi(limit[1]); err != nil {
+			return nil, errFormat
+		}
+		if l.Rate < 0 {
+			return nil, fmt.Errorf("rate of route %s can't be negative", l.Route)
+		}
+		if l.Rate > 0 && l.Burst < 1 {
+			return nil, fmt.Errorf("burst of route %s must be at least 1", l.Route)
+		}
+		limits[i] = l
+	}
+	return limits, nil
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -466,6 +612,15 @@ func (cfg RPCConfig) CertFile() string {
 	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
 }
 
+// APIKeysFilePath returns the path of the API keys file.
+func (cfg RPCConfig) APIKeysFilePath() string {
+	path := cfg.APIKeysFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
 func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
@@ -709,12 +864,27 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 // StateSyncConfig defines the configuration for the Tendermint state sync service
 type StateSyncConfig struct {
 	Enable        bool          `mapstructure:"enable"`
+	UseP2P        bool          `mapstructure:"use-p2p"`
 	TempDir       string        `mapstructure:"temp-dir"`
 	RPCServers    []string      `mapstructure:"rpc-servers"`
 	TrustPeriod   time.Duration `mapstructure:"trust-period"`
 	TrustHeight   int64         `mapstructure:"trust-height"`
 	TrustHash     string        `mapstructure:"trust-hash"`
 	DiscoveryTime time.Duration `mapstructure:"discovery-time"`
+
+	// RPC servers to discover snapshots and fetch their chunks from over HTTP,
+	// in addition to peers.
+	SnapshotServers []string `mapstructure:"snapshot-servers"`
+
+	// If true, chunks are only fetched from the snapshot servers and never
+	// requested from peers.
+	SnapshotServersOnly bool `mapstructure:"snapshot-servers-only"`
+
+	// Number of blocks, and duration before the restored snapshot, to fetch
+	// and verify headers, commits and validator sets for after state sync. A
+	// backfill is done once both are covered. Disabled when both are 0.
+	BackfillBlocks   int64         `mapstructure:"backfill-blocks"`
+	BackfillDuration time.Duration `mapstructure:"backfill-duration"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -741,32 +911,62 @@ func TestStateSyncConfig() *StateSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
-	if cfg.Enable {
-		if len(cfg.RPCServers) == 0 {
-			return errors.New("rpc-servers is required")
-		}
-		if len(cfg.RPCServers) < 2 {
-			return errors.New("at least two rpc-servers entries is required")
-		}
-		for _, server := range cfg.RPCServers {
-			if len(server) == 0 {
-				return errors.New("found empty rpc-servers entry")
-			}
+	for _, server := range cfg.SnapshotServers {
+		if len(server) == 0 {
+			return errors.New("found empty snapshot-servers entry")
 		}
-		if cfg.TrustPeriod <= 0 {
-			return errors.New("trusted-period is required")
-		}
-		if cfg.TrustHeight <= 0 {
-			return errors.New("trusted-height is required")
-		}
-		if len(cfg.TrustHash) == 0 {
-			return errors.New("trusted-hash is required")
+	}
+	if cfg.SnapshotServersOnly && len(cfg.SnapshotServers) == 0 {
+		return errors.New("snapshot-servers is required when snapshot-servers-only is set")
+	}
+	if cfg.BackfillBlocks < 0 {
+		return errors.New("backfill-blocks can't be negative")
+	}
+	if cfg.BackfillDuration < 0 {
+		return errors.New("backfill-duration can't be negative")
+	}
+	if cfg.Enable {
+		if cfg.UseP2P {
+			return cfg.validateTrustOptions()
 		}
-		_, err := hex.DecodeString(cfg.TrustHash)
-		if err != nil {
-			return fmt.Errorf("invalid trusted-hash: %w", err)
+		return cfg.ValidateLightClient()
+	}
+	return nil
+}
+
+// ValidateLightClient checks the RPC servers and trust options used to run a
+// light client, which are required by state sync without use-p2p and by fast
+// sync light block verification.
+func (cfg *StateSyncConfig) ValidateLightClient() error {
+	if len(cfg.RPCServers) == 0 {
+		return errors.New("rpc-servers is required")
+	}
+	if len(cfg.RPCServers) < 2 {
+		return errors.New("at least two rpc-servers entries is required")
+	}
+	for _, server := range cfg.RPCServers {
+		if len(server) == 0 {
+			return errors.New("found empty rpc-servers entry")
 		}
 	}
+	return cfg.validateTrustOptions()
+}
+
+// validateTrustOptions checks the light client trust options.
+func (cfg *StateSyncConfig) validateTrustOptions() error {
+	if cfg.TrustPeriod <= 0 {
+		return errors.New("trusted-period is required")
+	}
+	if cfg.TrustHeight <= 0 {
+		return errors.New("trusted-height is required")
+	}
+	if len(cfg.TrustHash) == 0 {
+		return errors.New("trusted-hash is required")
+	}
+	_, err := hex.DecodeString(cfg.TrustHash)
+	if err != nil {
+		return fmt.Errorf("invalid trusted-hash: %w", err)
+	}
 	return nil
 }
 
@@ -776,12 +976,25 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// If true, the hash of every fast synced block is checked against the
+	// header verified by a light client, using the RPC servers and trust
+	// options of the [statesync] section. Blocks that don't match are rejected
+	// and the peers that sent them are banned. Only supported by v0.
+	VerifyLightBlocks bool `mapstructure:"verify-light-blocks"`
+
+	// If true, the commit of the next block is verified and the block written to
+	// the store while the current block is executed by the application, instead
+	// of processing one block at a time. Only supported by v0.
+	Pipeline bool `mapstructure:"pipeline"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
-		Version: "v0",
+		Version:           "v0",
+		VerifyLightBlocks: false,
+		Pipeline:          false,
 	}
 }
 
@@ -796,6 +1009,12 @@ func (cfg *FastSyncConfig) ValidateBasic() error {
 	case "v0":
 		return nil
 	case "v2":
+		if cfg.VerifyLightBlocks {
+			return errors.New("verify-light-blocks is only supported by fastsync v0")
+		}
+		if cfg.Pipeline {
+			return errors.New("pipeline is only supported by fastsync v0")
+		}
 		return nil
 	default:
 		return fmt.Errorf("unknown fastsync version %s", cfg.Version)
@@ -841,6 +1060,18 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
+
+	// Number of rounds to listen to vote gossip after startup before signing
+	// anything. If a vote from our validator that we did not sign is seen in
+	// the meantime, consensus is halted (0 disables the check).
+	DoubleSignCheckRounds int32 `mapstructure:"double-sign-check-rounds"`
+
+	// Number of most recent commits in which the node checks for its own
+	// validator's signature (0 disables self-monitoring).
+	SignatureMonitorWindow int64 `mapstructure:"signature-monitor-window"`
+	// Number of missed signatures within the window after which a warning is
+	// logged (0 disables the warning).
+	SignatureMonitorThreshold int64 `mapstructure:"signature-monitor-threshold"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -860,6 +1091,9 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		DoubleSignCheckRounds:       0,
+		SignatureMonitorWindow:      100,
+		SignatureMonitorThreshold:   10,
 	}
 }
 
@@ -961,6 +1195,18 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
+	if cfg.DoubleSignCheckRounds < 0 {
+		return errors.New("double-sign-check-rounds can't be negative")
+	}
+	if cfg.SignatureMonitorWindow < 0 {
+		return errors.New("signature-monitor-window can't be negative")
+	}
+	if cfg.SignatureMonitorThreshold < 0 {
+		return errors.New("signature-monitor-threshold can't be negative")
+	}
+	if cfg.SignatureMonitorWindow > 0 && cfg.SignatureMonitorThreshold > cfg.SignatureMonitorWindow {
+		return errors.New("signature-monitor-threshold can't be greater than signature-monitor-window")
+	}
 	return nil
 }
 
@@ -73,9 +73,16 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"EventHistorySize",
+		"EventsMaxWaitTime",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxBatchSize",
+		"MaxBatchCost",
+		"BatchConcurrency",
+		"ResponseCacheSize",
+		"ResponseCacheMaxAge",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -85,6 +92,27 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestRPCConfigRateLimits(t *testing.T) {
+	cfg := TestRPCConfig()
+	cfg.RateLimit = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimit = 10
+	cfg.RateLimitBurst = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimitBurst = 20
+
+	cfg.RateLimitRoutes = []string{"tx_search=0.5:5", "health=0:0"}
+	assert.NoError(t, cfg.ValidateBasic())
+	limits, err := cfg.RouteRateLimits()
+	require.NoError(t, err)
+	assert.Equal(t, []RouteRateLimit{{"tx_search", 0.5, 5}, {"health", 0, 0}}, limits)
+
+	for _, route := range []string{"tx_search", "tx_search=1", "=1:1", "tx_search=a:1", "tx_search=1:0", "tx_search=-1:1"} {
+		cfg.RateLimitRoutes = []string{route}
+		assert.Error(t, cfg.ValidateBasic(), route)
+	}
+}
+
 func TestP2PConfigValidateBasic(t *testing.T) {
 	cfg := TestP2PConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -126,6 +154,34 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.SnapshotServersOnly = true
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg.SnapshotServers = []string{"127.0.0.1:26657"}
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.SnapshotServers = []string{"127.0.0.1:26657", ""}
+	require.Error(t, cfg.ValidateBasic())
+	cfg = TestStateSyncConfig()
+	cfg.Enable = true
+	require.Error(t, cfg.ValidateBasic())
+	cfg.UseP2P = true
+	require.Error(t, cfg.ValidateBasic())
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0A"
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg = TestStateSyncConfig()
+	cfg.BackfillBlocks = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.BackfillBlocks = 100
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.BackfillDuration = -time.Hour
+	require.Error(t, cfg.ValidateBasic())
+	cfg.BackfillDuration = time.Hour
+	require.NoError(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -138,6 +194,33 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// light block verification is only supported by v0
+	cfg.VerifyLightBlocks = true
+	cfg.Version = "v2"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Version = "v0"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// pipelining is only supported by v0
+	cfg.VerifyLightBlocks = false
+	cfg.Pipeline = true
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Version = "v2"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestConfigValidateBasicFastSyncVerifyLightBlocks(t *testing.T) {
+	cfg := TestConfig()
+	cfg.FastSync.VerifyLightBlocks = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.StateSync.RPCServers = []string{"127.0.0.1:26657", "127.0.0.1:26658"}
+	cfg.StateSync.TrustHeight = 1
+	cfg.StateSync.TrustHash = "0A"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -165,6 +248,11 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"DoubleSignCheckRounds negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckRounds = -1 }, true},
+		"SignatureMonitorWindow negative":      {func(c *ConsensusConfig) { c.SignatureMonitorWindow = -1 }, true},
+		"SignatureMonitorWindow disabled":      {func(c *ConsensusConfig) { c.SignatureMonitorWindow = 0 }, false},
+		"SignatureMonitorThreshold negative":   {func(c *ConsensusConfig) { c.SignatureMonitorThreshold = -1 }, true},
+		"SignatureMonitorThreshold > window":   {func(c *ConsensusConfig) { c.SignatureMonitorThreshold = c.SignatureMonitorWindow + 1 }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
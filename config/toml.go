@@ -173,7 +173,9 @@ cors-allowed-methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors-allowed-headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# The server mirrors the main JSON-RPC routes (status, blocks, txs, ABCI queries, broadcasts and
+# subscriptions streamed from the server) and serves the light client API (light blocks,
+# validator sets, consensus params and evidence submission)
 grpc-laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -200,6 +202,7 @@ max-open-connections = {{ .RPC.MaxOpenConnections }}
 # Maximum number of unique clientIDs that can /subscribe
 # If you're using /broadcast_tx_commit, set to the estimated maximum number
 # of broadcast_tx_commit calls per block.
+# The calls to /events waiting for new events count as clients too.
 max-subscription-clients = {{ .RPC.MaxSubscriptionClients }}
 
 # Maximum number of unique queries a given client can /subscribe to
@@ -207,6 +210,19 @@ max-subscription-clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max-subscriptions-per-client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Number of recent events kept by the node, so that the clients of /subscribe
+# can resume their subscriptions from the last event they received (e.g. after
+# reconnecting), by passing its sequence number and epoch in the since and
+# epoch parameters (0 - no history). A few dozen events are published per
+# block, and they're kept with their data (e.g. the whole block of NewBlock
+# events), so a large history takes a lot of memory.
+event-history-size = {{ .RPC.EventHistorySize }}
+
+# Maximum time a call to /events may wait for new events
+# WARNING: Using a value larger than 10s will result in increasing the
+# global HTTP write timeout, which applies to all connections and endpoints.
+events-max-wait-time = "{{ .RPC.EventsMaxWaitTime }}"
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -219,6 +235,59 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of requests in a batch of JSON-RPC requests (0 - unlimited)
+max-batch-size = {{ .RPC.MaxBatchSize }}
+
+# Maximum total cost of the requests in a batch of JSON-RPC requests, where
+# most methods cost 1 and expensive ones (e.g. /tx_search or
+# /broadcast_tx_commit) cost 10 (0 - unlimited)
+max-batch-cost = {{ .RPC.MaxBatchCost }}
+
+# Number of requests of a batch of JSON-RPC requests executed concurrently.
+# NOTE: with more than 1, transactions broadcast in the same batch may reach
+# the mempool in any order.
+batch-concurrency = {{ .RPC.BatchConcurrency }}
+
+# Average number of calls per second each client can make, using a token
+# bucket per client IP address (0 - unlimited). Calls over the limit get a
+# "Rate limit exceeded" JSON-RPC error. The IP address is the one the connection
+# comes from, so behind a reverse proxy all the clients share the bucket of the
+# proxy (X-Forwarded-For is ignored); use API keys to tell them apart.
+# The limits also apply to the gRPC
+# server, whose CoreAPI methods share the buckets of the JSON-RPC routes of the
+# same name; calls over the limit fail with RESOURCE_EXHAUSTED.
+rate-limit = {{ .RPC.RateLimit }}
+
+# Number of calls each client can make at once
+rate-limit-burst = {{ .RPC.RateLimitBurst }}
+
+# Limits of the calls of each client to specific routes, with their own token
+# buckets, as "route=rate:burst". A rate of 0 means unlimited.
+# Example: ["tx_search=0.5:5", "block_results=2:10", "health=0:0"]
+rate-limit-routes = [{{ range .RPC.RateLimitRoutes }}{{ printf "%q, " . }}{{end}}]
+
+# The path to a JSON file with the API keys of the clients with their own
+# limits, passed in the X-API-Key header or the api_key query parameter (the
+# x-api-key metadata for gRPC):
+#   [{"name": "explorer", "key": "...", "rate": 50, "burst": 100,
+#     "routes": {"tx_search": {"rate": 5, "burst": 10}}}]
+# The limit of a key replaces rate-limit for its calls, while the limits of
+# rate-limit-routes still apply to them, with buckets of the key, unless
+# overridden in "routes". A rate of 0 means unlimited. The file is reloaded on
+# SIGHUP.
+# Migth be either absolute path or path related to tendermint's config directory.
+api-keys-file = "{{ .RPC.APIKeysFile }}"
+
+# Maximum size of the cache of the encoded results of the immutable calls
+# (/block, /block_results, /commit and /validators below the latest height),
+# in bytes (0 - no caching)
+response-cache-size = {{ .RPC.ResponseCacheSize }}
+
+# How long the HTTP responses of the immutable calls may be cached by clients,
+# proxies and CDNs, set in their Cache-Control header along with an ETag
+# (0 - no caching headers)
+response-cache-max-age = "{{ .RPC.ResponseCacheMaxAge }}"
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Migth be either absolute path or path related to tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -352,9 +421,15 @@ max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 # starting from the height of the snapshot.
 enable = {{ .StateSync.Enable }}
 
+# If true, light blocks and consensus params used to verify snapshots are fetched from peers
+# instead of rpc-servers, which are then not required. The trust options below are still needed.
+use-p2p = {{ .StateSync.UseP2P }}
+
 # RPC servers (comma-separated) for light client verification of the synced state machine and
 # retrieval of state data for node bootstrapping. Also needs a trusted height and corresponding
 # header hash obtained from a trusted source, and a period during which validators can be trusted.
+# Servers given as grpc://host:port are reached through the gRPC API of the node (rpc.grpc-laddr)
+# instead of JSON-RPC.
 #
 # For Cosmos SDK-based chains, trust-period should usually be about 2/3 of the unbonding time (~2
 # weeks) during which they can be financially punished (slashed) for misbehavior.
@@ -366,10 +441,28 @@ trust-period = "{{ .StateSync.TrustPeriod }}"
 # Time to spend discovering snapshots before initiating a restore.
 discovery-time = "{{ .StateSync.DiscoveryTime }}"
 
-# Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
-# Will create a new, randomly named directory within, and remove it when done.
+# Directory for state sync snapshot chunks, defaults to the node's data directory. Chunks and
+# sync progress are stored in a "statesync" directory within (in a directory named after the
+# node ID if set, so it can be shared), which is removed when done. If the node is restarted
+# during a sync, the same snapshot is resumed with the chunks already fetched, and the app
+# restores it from scratch unless it continues its restoration (see OfferSnapshot).
 temp-dir = "{{ .StateSync.TempDir }}"
 
+# RPC servers (comma-separated) to discover snapshots and fetch their chunks from over HTTP, in
+# addition to peers, e.g. mirrors serving the snapshots of a trusted node. Snapshots are still
+# verified against the app hash obtained through the light client.
+snapshot-servers = "{{ StringsJoin .StateSync.SnapshotServers "," }}"
+
+# If true, snapshot chunks are only fetched from snapshot-servers and never requested from peers.
+snapshot-servers-only = {{ .StateSync.SnapshotServersOnly }}
+
+# Light blocks (headers, commits and validator sets) to backfill from peers after state sync,
+# going back from the restored snapshot, so the node can serve light clients and verify evidence
+# for recent heights. Backfilling stops once both backfill-blocks and backfill-duration have been
+# covered, or the initial height is reached. Disabled if both are 0.
+backfill-blocks = {{ .StateSync.BackfillBlocks }}
+backfill-duration = "{{ .StateSync.BackfillDuration }}"
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -380,6 +473,17 @@ temp-dir = "{{ .StateSync.TempDir }}"
 #   2) "v2" - complete redesign of v0, optimized for testability & readability
 version = "{{ .FastSync.Version }}"
 
+# If true, the hash of every fast synced block is checked against the header
+# verified by a light client, using the rpc-servers and trust options of the
+# [statesync] section. Blocks that don't match are rejected and the peers that
+# sent them are banned. Only supported by "v0".
+verify-light-blocks = {{ .FastSync.VerifyLightBlocks }}
+
+# If true, the commit of the next block is verified and the block written to
+# the store while the current block is executed by the application, instead of
+# processing one block at a time. Only supported by "v0".
+pipeline = {{ .FastSync.Pipeline }}
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -410,6 +514,22 @@ timeout-commit = "{{ .Consensus.TimeoutCommit }}"
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# How many rounds to listen to vote gossip after startup before signing anything.
+# When non-zero, the node will shut down if it sees a vote signed with its
+# consensus key that it did not produce, i.e. another instance is running with the same key.
+# Signing is held for at most the time these rounds take at round 0, during which the
+# validator misses its proposals and votes.
+double-sign-check-rounds = {{ .Consensus.DoubleSignCheckRounds }}
+
+# How many of the most recent commits to check for this node's own validator signature.
+# The number of missed signatures is exposed through metrics, events and /status.
+# Set to 0 to disable validator self-monitoring.
+signature-monitor-window = {{ .Consensus.SignatureMonitorWindow }}
+
+# Log a warning once the number of missed signatures within signature-monitor-window
+# reaches this value. Set to 0 to disable the warning.
+signature-monitor-threshold = {{ .Consensus.SignatureMonitorThreshold }}
+
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip-timeout-commit = {{ .Consensus.SkipTimeoutCommit }}
 
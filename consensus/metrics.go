@@ -33,6 +33,8 @@ type Metrics struct {
 	ValidatorPower metrics.Gauge
 	// Amount of blocks missed by a validator.
 	ValidatorMissedBlocks metrics.Gauge
+	// Number of signatures missed by our own validator within the monitored window.
+	ValidatorMissedSignatures metrics.Gauge
 	// Number of validators who did not sign.
 	MissingValidators metrics.Gauge
 	// Total power of the missing validators.
@@ -102,6 +104,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "validator_missed_blocks",
 			Help:      "Total missed blocks for a validator",
 		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorMissedSignatures: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_missed_signatures",
+			Help:      "Number of signatures missed by our own validator within the monitored window",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
 		ValidatorsPower: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -198,14 +206,15 @@ func NopMetrics() *Metrics {
 
 		Rounds: discard.NewGauge(),
 
-		Validators:               discard.NewGauge(),
-		ValidatorsPower:          discard.NewGauge(),
-		ValidatorPower:           discard.NewGauge(),
-		ValidatorMissedBlocks:    discard.NewGauge(),
-		MissingValidators:        discard.NewGauge(),
-		MissingValidatorsPower:   discard.NewGauge(),
-		ByzantineValidators:      discard.NewGauge(),
-		ByzantineValidatorsPower: discard.NewGauge(),
+		Validators:                discard.NewGauge(),
+		ValidatorsPower:           discard.NewGauge(),
+		ValidatorPower:            discard.NewGauge(),
+		ValidatorMissedBlocks:     discard.NewGauge(),
+		ValidatorMissedSignatures: discard.NewGauge(),
+		MissingValidators:         discard.NewGauge(),
+		MissingValidatorsPower:    discard.NewGauge(),
+		ByzantineValidators:       discard.NewGauge(),
+		ByzantineValidatorsPower:  discard.NewGauge(),
 
 		BlockIntervalSeconds: discard.NewHistogram(),
 
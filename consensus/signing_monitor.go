@@ -0,0 +1,96 @@
+package consensus
+
+import (
+	"bytes"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// signingMonitor keeps track of whether the node's own validator signed each
+// of the most recent commits. It is not thread safe; State guards it with its
+// mutex.
+type signingMonitor struct {
+	window    int64
+	threshold int64
+
+	address types.Address
+	// ring buffer of the last `window` commits, true if signed
+	signed []bool
+	next   int64
+	filled int64
+	missed int64
+
+	lastSignedHeight int64
+	// true while missed is at or above threshold
+	alerting bool
+}
+
+func newSigningMonitor(window, threshold int64) *signingMonitor {
+	return &signingMonitor{
+		window:    window,
+		threshold: threshold,
+		signed:    make([]bool, window),
+	}
+}
+
+// enabled returns true if the monitor tracks any commits at all.
+func (m *signingMonitor) enabled() bool {
+	return m.window > 0
+}
+
+// reset clears the history and starts tracking the given address.
+func (m *signingMonitor) reset(address types.Address) {
+	m.address = address
+	m.signed = make([]bool, m.window)
+	m.next = 0
+	m.filled = 0
+	m.missed = 0
+	m.lastSignedHeight = 0
+	m.alerting = false
+}
+
+// record stores whether address signed the commit for the given height. The
+// history is reset if the address differs from the one previously tracked
+// (e.g. the validator key was rotated). It returns true if the number of
+// missed signatures has just crossed the threshold in either direction, in
+// which case alerting reports the new state.
+func (m *signingMonitor) record(address types.Address, height int64, signed bool) (changed bool) {
+	if !m.enabled() {
+		return false
+	}
+	if !bytes.Equal(m.address, address) {
+		m.reset(address)
+	}
+
+	if m.filled == m.window {
+		// evict the oldest entry
+		if !m.signed[m.next] {
+			m.missed--
+		}
+	} else {
+		m.filled++
+	}
+
+	m.signed[m.next] = signed
+	m.next = (m.next + 1) % m.window
+	if signed {
+		m.lastSignedHeight = height
+	} else {
+		m.missed++
+	}
+
+	alerting := m.threshold > 0 && m.missed >= m.threshold
+	changed = alerting != m.alerting
+	m.alerting = alerting
+	return changed
+}
+
+// info returns a summary of the tracked signatures.
+func (m *signingMonitor) info() types.ValidatorSigningInfo {
+	return types.ValidatorSigningInfo{
+		Address:          m.address,
+		Window:           m.filled,
+		Missed:           m.missed,
+		LastSignedHeight: m.lastSignedHeight,
+	}
+}
@@ -0,0 +1,54 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSigningMonitor(t *testing.T) {
+	addr := types.Address(tmrand.Bytes(tmhash.TruncatedSize))
+	m := newSigningMonitor(4, 2)
+
+	assert.False(t, m.record(addr, 1, true))
+	assert.False(t, m.record(addr, 2, false))
+	assert.Equal(t, types.ValidatorSigningInfo{Address: addr, Window: 2, Missed: 1, LastSignedHeight: 1}, m.info())
+
+	// reaching the threshold changes the alerting state
+	assert.True(t, m.record(addr, 3, false))
+	assert.True(t, m.alerting)
+	assert.False(t, m.record(addr, 4, true))
+	assert.Equal(t, types.ValidatorSigningInfo{Address: addr, Window: 4, Missed: 2, LastSignedHeight: 4}, m.info())
+
+	// the window is full, so height 1 (signed) and then 2 (missed) get evicted
+	assert.False(t, m.record(addr, 5, true))
+	assert.Equal(t, int64(2), m.info().Missed)
+	assert.True(t, m.record(addr, 6, true))
+	assert.False(t, m.alerting)
+	assert.Equal(t, types.ValidatorSigningInfo{Address: addr, Window: 4, Missed: 1, LastSignedHeight: 6}, m.info())
+
+	// a different address resets the history
+	newAddr := types.Address(tmrand.Bytes(tmhash.TruncatedSize))
+	assert.False(t, m.record(newAddr, 7, false))
+	assert.Equal(t, types.ValidatorSigningInfo{Address: newAddr, Window: 1, Missed: 1}, m.info())
+}
+
+func TestSigningMonitorDisabled(t *testing.T) {
+	addr := types.Address(tmrand.Bytes(tmhash.TruncatedSize))
+
+	m := newSigningMonitor(0, 0)
+	assert.False(t, m.enabled())
+	assert.False(t, m.record(addr, 1, false))
+	assert.Equal(t, types.ValidatorSigningInfo{}, m.info())
+
+	// no threshold means no alerting, but signatures are still tracked
+	m = newSigningMonitor(2, 0)
+	assert.False(t, m.record(addr, 1, false))
+	assert.False(t, m.record(addr, 2, false))
+	assert.False(t, m.alerting)
+	assert.Equal(t, int64(2), m.info().Missed)
+}
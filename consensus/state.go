@@ -38,6 +38,7 @@ var (
 	ErrInvalidProposalPOLRound    = errors.New("error invalid proposal POL round")
 	ErrAddingVote                 = errors.New("error adding vote")
 	ErrSignatureFoundInPastBlocks = errors.New("found signature from the same key")
+	ErrSignatureFoundInGossip     = errors.New("found vote from the same key that we did not sign")
 
 	errPubKeyIsNotSet = errors.New("pubkey is not set. Look for \"Can't get private validator pubkey\" errors")
 )
@@ -147,6 +148,17 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// tracks whether our own validator signed recent commits
+	signingMonitor *signingMonitor
+
+	// signing is held back at startup while we listen to vote gossip for votes
+	// from our own validator (see ConsensusConfig.DoubleSignCheckRounds)
+	signingHeld           bool
+	doubleSignCheckRounds int32     // rounds left to listen
+	doubleSignCheckUntil  time.Time // signing is released at the latest by then
+	doubleSignErr         error     // set once a vote we did not sign was seen
+	doubleSignRisk        chan struct{}
 }
 
 // StateOption sets an optional parameter on the State.
@@ -177,6 +189,8 @@ func NewState(
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		signingMonitor:   newSigningMonitor(config.SignatureMonitorWindow, config.SignatureMonitorThreshold),
+		doubleSignRisk:   make(chan struct{}),
 	}
 	// set function defaults (may be overwritten before calling Start)
 	cs.decideProposal = cs.defaultDecideProposal
@@ -241,6 +255,14 @@ func (cs *State) GetLastHeight() int64 {
 	return cs.RoundState.Height - 1
 }
 
+// GetValidatorSigningInfo returns how many of the most recent commits our own
+// validator failed to sign.
+func (cs *State) GetValidatorSigningInfo() types.ValidatorSigningInfo {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.signingMonitor.info()
+}
+
 // GetRoundState returns a shallow copy of the internal consensus state.
 func (cs *State) GetRoundState() *cstypes.RoundState {
 	cs.mtx.RLock()
@@ -374,6 +396,7 @@ func (cs *State) OnStart() error {
 	if err := cs.checkDoubleSigningRisk(cs.Height); err != nil {
 		return err
 	}
+	cs.startDoubleSignGossipCheck()
 
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
@@ -798,6 +821,14 @@ func (cs *State) handleMsg(mi msgInfo) {
 			err = nil
 		}
 	case *VoteMessage:
+		// while we hold back signing, make sure no one else signs with our key
+		if cs.signingHeld && cs.doubleSignErr == nil && peerID != "" {
+			if err := cs.checkDoubleSigningGossip(msg.Vote); err != nil {
+				cs.haltOnDoubleSignRisk(err, msg.Vote, peerID)
+				return
+			}
+		}
+
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
 		added, err = cs.tryAddVote(msg.Vote, peerID)
@@ -950,6 +981,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	}
 	cs.Votes.SetRound(tmmath.SafeAddInt32(round, 1)) // also track next round (round+1) to allow round-skipping
 	cs.TriggeredTimeoutPrecommit = false
+	cs.updateDoubleSignGossipCheck(logger)
 
 	if err := cs.eventBus.PublishEventNewRound(cs.NewRoundEvent()); err != nil {
 		cs.Logger.Error("Error publishing new round", "err", err)
@@ -1040,6 +1072,11 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
+	if cs.signingHeld {
+		logger.Info("enterPropose: Signing is held back by the double sign check")
+		return
+	}
+
 	if cs.isProposer(address) {
 		logger.Info("enterPropose: Our turn to propose",
 			"proposer",
@@ -1587,6 +1624,9 @@ func (cs *State) finalizeCommit(height int64) {
 		}
 	}
 
+	// must be called before the votes of this height are reset
+	cs.reportAmnesia(block.Time)
+
 	// must be called before we update state
 	cs.recordMetrics(height, block)
 
@@ -1610,6 +1650,24 @@ func (cs *State) finalizeCommit(height int64) {
 	// * cs.StartTime is set to when we will start round0.
 }
 
+// reportAmnesia adds evidence to the evidence pool for every validator that,
+// according to the votes seen for this height, prevoted against the block it
+// was locked on without a proof of lock change.
+func (cs *State) reportAmnesia(blockTime time.Time) {
+	for _, ev := range cs.Votes.AmnesiaEvidence(blockTime) {
+		if cs.privValidatorPubKey != nil &&
+			bytes.Equal(ev.Precommit.ValidatorAddress, cs.privValidatorPubKey.Address()) {
+			cs.Logger.Error("Found amnesia evidence against ourselves", "ev", ev)
+			continue
+		}
+		if err := cs.evpool.AddEvidenceFromConsensus(ev); err != nil {
+			cs.Logger.Error("Failed to add evidence to the evidence pool", "err", err)
+		} else {
+			cs.Logger.Info("Added amnesia evidence to the evidence pool", "ev", ev)
+		}
+	}
+}
+
 func (cs *State) pruneBlocks(retainHeight int64) (uint64, error) {
 	base := cs.blockStore.Base()
 	if retainHeight <= base {
@@ -1676,6 +1734,7 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 				} else {
 					cs.metrics.ValidatorMissedBlocks.With(label...).Add(float64(1))
 				}
+				cs.monitorSigning(val.Address, block.LastCommit.Height, commitSig.ForBlock())
 			}
 
 		}
@@ -1683,17 +1742,25 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 	cs.metrics.MissingValidators.Set(float64(missingValidators))
 	cs.metrics.MissingValidatorsPower.Set(float64(missingValidatorsPower))
 
-	// NOTE: byzantine validators power and count is only for consensus evidence i.e. duplicate vote
+	// NOTE: byzantine validators power and count is only for consensus evidence
+	// i.e. duplicate vote and amnesia
 	var (
 		byzantineValidatorsPower = int64(0)
 		byzantineValidatorsCount = int64(0)
 	)
 	for _, ev := range block.Evidence.Evidence {
-		if dve, ok := ev.(*types.DuplicateVoteEvidence); ok {
-			if _, val := cs.Validators.GetByAddress(dve.VoteA.ValidatorAddress); val != nil {
-				byzantineValidatorsCount++
-				byzantineValidatorsPower += val.VotingPower
-			}
+		var address types.Address
+		switch e := ev.(type) {
+		case *types.DuplicateVoteEvidence:
+			address = e.VoteA.ValidatorAddress
+		case *types.AmnesiaEvidence:
+			address = e.Precommit.ValidatorAddress
+		default:
+			continue
+		}
+		if _, val := cs.Validators.GetByAddress(address); val != nil {
+			byzantineValidatorsCount++
+			byzantineValidatorsPower += val.VotingPower
 		}
 	}
 	cs.metrics.ByzantineValidators.Set(float64(byzantineValidatorsCount))
@@ -1714,6 +1781,38 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 	cs.metrics.CommittedHeight.Set(float64(block.Height))
 }
 
+// monitorSigning records whether our own validator signed the commit for the
+// given height, updates the metrics and publishes EventValidatorSigning.
+func (cs *State) monitorSigning(address types.Address, height int64, signed bool) {
+	if !cs.signingMonitor.enabled() {
+		return
+	}
+
+	changed := cs.signingMonitor.record(address, height, signed)
+	info := cs.signingMonitor.info()
+
+	cs.metrics.ValidatorMissedSignatures.With("validator_address", address.String()).Set(float64(info.Missed))
+
+	if changed {
+		if cs.signingMonitor.alerting {
+			cs.Logger.Error("Our validator missed too many recent signatures",
+				"address", address, "missed", info.Missed, "window", info.Window,
+				"lastSignedHeight", info.LastSignedHeight)
+		} else {
+			cs.Logger.Info("Our validator missed signatures are back below the threshold",
+				"address", address, "missed", info.Missed, "window", info.Window)
+		}
+	}
+
+	if err := cs.eventBus.PublishEventValidatorSigning(types.EventDataValidatorSigning{
+		Height:      height,
+		Signed:      signed,
+		SigningInfo: info,
+	}); err != nil {
+		cs.Logger.Error("Error publishing validator signing", "err", err)
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
@@ -2139,6 +2238,11 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 		return nil
 	}
 
+	// If we're still listening for votes signed with our key, do nothing.
+	if cs.signingHeld {
+		return nil
+	}
+
 	// TODO: pass pubKey to signVote
 	vote, err := cs.signVote(msgType, hash, header)
 	if err == nil {
@@ -2191,6 +2295,83 @@ func (cs *State) checkDoubleSigningRisk(height int64) error {
 	return nil
 }
 
+// startDoubleSignGossipCheck holds back signing for the configured number of
+// rounds, during which we listen to vote gossip for votes from our own
+// validator that we did not sign. Since round timeouts grow with the round,
+// the hold is also limited to the time the configured number of rounds take
+// at round 0.
+func (cs *State) startDoubleSignGossipCheck() {
+	if cs.privValidator == nil || cs.privValidatorPubKey == nil || cs.config.DoubleSignCheckRounds == 0 {
+		return
+	}
+	rounds := cs.config.DoubleSignCheckRounds
+	roundDuration := cs.config.Propose(0) + cs.config.Prevote(0) + cs.config.Precommit(0) + cs.config.TimeoutCommit
+	cs.signingHeld = true
+	cs.doubleSignCheckRounds = rounds
+	cs.doubleSignCheckUntil = tmtime.Now().Add(time.Duration(rounds) * roundDuration)
+	cs.Logger.Info("Listening to vote gossip before signing",
+		"rounds", rounds, "until", cs.doubleSignCheckUntil)
+}
+
+// updateDoubleSignGossipCheck is called upon entering a new round. It releases
+// signing once we listened for the configured number of rounds (or the
+// corresponding time) without seeing a vote we did not sign.
+func (cs *State) updateDoubleSignGossipCheck(logger log.Logger) {
+	if !cs.signingHeld || cs.doubleSignErr != nil {
+		return
+	}
+	if cs.doubleSignCheckRounds > 0 && tmtime.Now().Before(cs.doubleSignCheckUntil) {
+		cs.doubleSignCheckRounds--
+		logger.Info("Listening to vote gossip before signing", "roundsLeft", cs.doubleSignCheckRounds)
+		return
+	}
+	cs.signingHeld = false
+	logger.Info("Found no votes signed with our key by someone else. Signing enabled")
+}
+
+// checkDoubleSigningGossip returns an error if the given vote from a peer was
+// signed with our key for the current height, but is not a vote we signed
+// ourselves (i.e. replayed from the WAL).
+func (cs *State) checkDoubleSigningGossip(vote *types.Vote) error {
+	if vote.Height != cs.Height || !bytes.Equal(vote.ValidatorAddress, cs.privValidatorPubKey.Address()) {
+		return nil
+	}
+
+	var voteSet *types.VoteSet
+	switch vote.Type {
+	case tmproto.PrevoteType:
+		voteSet = cs.Votes.Prevotes(vote.Round)
+	case tmproto.PrecommitType:
+		voteSet = cs.Votes.Precommits(vote.Round)
+	}
+	if voteSet != nil {
+		if ours := voteSet.GetByAddress(vote.ValidatorAddress); ours != nil && bytes.Equal(ours.Signature, vote.Signature) {
+			return nil
+		}
+	}
+
+	return ErrSignatureFoundInGossip
+}
+
+// haltOnDoubleSignRisk keeps signing held for good, so we never sign anything
+// while another instance might be running with our key, and signals the node
+// to shut down (see DoubleSignRisk). The state machine keeps running until
+// then, so peer messages are still drained.
+func (cs *State) haltOnDoubleSignRisk(err error, vote *types.Vote, peerID p2p.ID) {
+	cs.doubleSignErr = err
+	cs.Logger.Error("Found vote from our validator that we did not sign. "+
+		"Is another instance running with the same key? Halting",
+		"vote", vote, "peer", peerID, "err", err)
+	close(cs.doubleSignRisk)
+}
+
+// DoubleSignRisk returns a channel, which is closed once a vote signed with
+// our key that we did not produce was seen during the double sign check (see
+// ConsensusConfig.DoubleSignCheckRounds). The node should shut down then.
+func (cs *State) DoubleSignRisk() <-chan struct{} {
+	return cs.doubleSignRisk
+}
+
 //---------------------------------------------------------
 
 func CompareHRS(h1 int64, r1 int32, s1 cstypes.RoundStepType, h2 int64, r2 int32, s2 cstypes.RoundStepType) int {
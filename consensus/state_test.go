@@ -19,6 +19,7 @@ import (
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 /*
@@ -1877,6 +1878,99 @@ func TestStateOutputVoteStats(t *testing.T) {
 
 }
 
+func TestStateValidatorSigningEvent(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	signingCh := subscribe(cs.eventBus, types.EventQueryValidatorSigning)
+
+	startTestRound(cs, height, round)
+
+	// the commit for the first height is checked once the second block is committed
+	select {
+	case <-time.After(ensureTimeout):
+		t.Fatal("Timeout expired while waiting for ValidatorSigning event")
+	case msg := <-signingCh:
+		event, ok := msg.Data().(types.EventDataValidatorSigning)
+		require.True(t, ok, "expected a EventDataValidatorSigning, got %T", msg.Data())
+		assert.Equal(t, height, event.Height)
+		assert.True(t, event.Signed)
+		assert.EqualValues(t, 1, event.SigningInfo.Window)
+		assert.EqualValues(t, 0, event.SigningInfo.Missed)
+		assert.Equal(t, height, event.SigningInfo.LastSignedHeight)
+	}
+
+	info := cs.GetValidatorSigningInfo()
+	assert.EqualValues(t, 0, info.Missed)
+	assert.NotEmpty(t, info.Address)
+}
+
+func TestStateDoubleSignGossipCheck(t *testing.T) {
+	cs, vss := randState(2)
+	vs2 := vss[1]
+	cs.config.DoubleSignCheckRounds = 1
+	peer := p2pmock.NewPeer(nil)
+
+	cs.startDoubleSignGossipCheck()
+	require.True(t, cs.signingHeld)
+
+	// nothing is signed while we listen
+	assert.Nil(t, cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{}))
+
+	// votes from other validators are fine
+	cs.handleMsg(msgInfo{&VoteMessage{signVote(vs2, tmproto.PrevoteType, nil, types.PartSetHeader{})}, peer.ID()})
+	require.NoError(t, cs.doubleSignErr)
+
+	// signing is released after listening for the configured number of rounds
+	cs.updateDoubleSignGossipCheck(cs.Logger)
+	assert.True(t, cs.signingHeld)
+	cs.updateDoubleSignGossipCheck(cs.Logger)
+	assert.False(t, cs.signingHeld)
+
+	// rounds taking longer than at round 0 don't extend the hold
+	cs.startDoubleSignGossipCheck()
+	require.True(t, cs.signingHeld)
+	cs.doubleSignCheckUntil = tmtime.Now().Add(-time.Second)
+	cs.updateDoubleSignGossipCheck(cs.Logger)
+	assert.False(t, cs.signingHeld)
+}
+
+func TestStateDoubleSignGossipCheckOwnVote(t *testing.T) {
+	cs, vss := randState(2)
+	vs1 := vss[0]
+	incrementHeight(vs1)
+	cs.config.DoubleSignCheckRounds = 1
+	peer := p2pmock.NewPeer(nil)
+
+	// a vote we signed before the restart is replayed from the WAL, and later
+	// gossiped back to us
+	ownVote := signVote(vs1, tmproto.PrevoteType, nil, types.PartSetHeader{})
+	cs.handleMsg(msgInfo{&VoteMessage{ownVote}, ""})
+
+	cs.startDoubleSignGossipCheck()
+	cs.handleMsg(msgInfo{&VoteMessage{ownVote}, peer.ID()})
+	require.NoError(t, cs.doubleSignErr)
+
+	// a vote signed with our key we don't know of means someone else uses it
+	blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	foreignVote := signVote(vs1, tmproto.PrecommitType, blockID.Hash, blockID.PartSetHeader)
+	cs.handleMsg(msgInfo{&VoteMessage{foreignVote}, peer.ID()})
+	assert.Equal(t, ErrSignatureFoundInGossip, cs.doubleSignErr)
+
+	// the node is told to shut down
+	select {
+	case <-cs.DoubleSignRisk():
+	default:
+		t.Fatal("expected DoubleSignRisk to be closed")
+	}
+
+	// signing is never released, even after the hold expired
+	cs.doubleSignCheckUntil = tmtime.Now().Add(-time.Second)
+	cs.updateDoubleSignGossipCheck(cs.Logger)
+	cs.updateDoubleSignGossipCheck(cs.Logger)
+	assert.True(t, cs.signingHeld)
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -163,6 +164,68 @@ func (hvs *HeightVoteSet) POLInfo() (polRound int32, polBlockID types.BlockID) {
 	return -1, types.BlockID{}
 }
 
+// AmnesiaEvidence returns evidence against every validator that precommitted
+// a block in some round and later prevoted for a different block although the
+// prevotes seen for each round after the precommit, up to and including the
+// round of the prevote, hold +1/3 for the precommitted block, which rules out a
+// proof of lock change the validator could have unlocked on (a validator also
+// unlocks on +2/3 prevotes of its current round). These prevotes are included
+// in the evidence as the lock proof. Only the first such pair of votes is
+// reported for each validator. blockTime is the time of the block committed at
+// this height.
+func (hvs *HeightVoteSet) AmnesiaEvidence(blockTime time.Time) []*types.AmnesiaEvidence {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+
+	maxRound := hvs.round
+	for round := range hvs.roundVoteSets {
+		if round > maxRound {
+			maxRound = round
+		}
+	}
+
+	var evidence []*types.AmnesiaEvidence
+	for valIdx := int32(0); valIdx < int32(hvs.valSet.Size()); valIdx++ {
+		var (
+			lockedRound int32 = -1
+			lockedVote  *types.Vote
+		)
+		for round := int32(0); round <= maxRound; round++ {
+			prevote := hvs.getVoteSet(round, tmproto.PrevoteType).GetByIndex(valIdx)
+			if lockedVote != nil && prevote != nil && !prevote.BlockID.IsZero() &&
+				!prevote.BlockID.Equals(lockedVote.BlockID) {
+				if lockProof, ok := hvs.lockProof(lockedRound, round, lockedVote.BlockID); ok {
+					evidence = append(evidence,
+						types.NewAmnesiaEvidence(lockedVote, prevote, lockProof, blockTime, hvs.valSet))
+					break
+				}
+			}
+			precommit := hvs.getVoteSet(round, tmproto.PrecommitType).GetByIndex(valIdx)
+			if precommit != nil && !precommit.BlockID.IsZero() {
+				lockedRound, lockedVote = round, precommit
+			}
+		}
+	}
+	return evidence
+}
+
+// lockProof returns the prevotes for blockID of every round after from up to
+// and including to, if each of these rounds has prevotes holding +1/3 of the
+// voting power for blockID, which means no other block could have gathered +2/3
+// prevotes in those rounds.
+func (hvs *HeightVoteSet) lockProof(from, to int32, blockID types.BlockID) ([]*types.Vote, bool) {
+	total := hvs.valSet.TotalVotingPower()
+	var proof []*types.Vote
+	for round := from + 1; round <= to; round++ {
+		prevotes := hvs.getVoteSet(round, tmproto.PrevoteType)
+		if prevotes == nil || prevotes.VotingPowerFor(blockID) <= total/3 {
+			return nil, false
+		}
+		proof = append(proof, prevotes.VotesFor(blockID)...)
+	}
+	return proof, true
+}
+
 func (hvs *HeightVoteSet) getVoteSet(round int32, voteType tmproto.SignedMsgType) *types.VoteSet {
 	rvs, ok := hvs.roundVoteSets[round]
 	if !ok {
@@ -5,6 +5,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -55,6 +58,93 @@ func TestPeerCatchupRounds(t *testing.T) {
 
 }
 
+func TestAmnesiaEvidence(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(4, 1)
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+	hvs.SetRound(3)
+
+	blockA := makeBlockID()
+	blockB := makeBlockID()
+	addVote := func(valIndex, round int32, voteType tmproto.SignedMsgType, blockID types.BlockID) *types.Vote {
+		vote := makeVote(t, 1, valIndex, round, voteType, blockID, privVals)
+		added, err := hvs.AddVote(vote, "")
+		require.NoError(t, err)
+		require.True(t, added)
+		return vote
+	}
+
+	// every validator precommits A in round 0
+	precommits := make([]*types.Vote, 4)
+	for i := int32(0); i < 4; i++ {
+		precommits[i] = addVote(i, 0, tmproto.PrecommitType, blockA)
+	}
+	// validator 0 prevotes B right in the next round, although +1/3 prevotes for
+	// A in round 1 rule out a POL for B
+	prevote0 := addVote(0, 1, tmproto.PrevoteType, blockB)
+	lockProof0 := []*types.Vote{
+		addVote(1, 1, tmproto.PrevoteType, blockA),
+		addVote(2, 1, tmproto.PrevoteType, blockA),
+	}
+	// validator 1 prevotes B in round 2, with +1/3 prevotes for A in rounds 1
+	// and 2
+	prevote1 := addVote(1, 2, tmproto.PrevoteType, blockB)
+	lockProof1 := append(lockProof0[:2:2],
+		addVote(2, 2, tmproto.PrevoteType, blockA),
+		addVote(3, 2, tmproto.PrevoteType, blockA),
+	)
+	// validator 2 prevotes B in round 3 on the +2/3 prevotes for B of that
+	// round, which unlock it
+	addVote(0, 3, tmproto.PrevoteType, blockB)
+	addVote(1, 3, tmproto.PrevoteType, blockB)
+	addVote(2, 3, tmproto.PrevoteType, blockB)
+	// validator 3 keeps prevoting for the block it's locked on
+	addVote(3, 3, tmproto.PrevoteType, blockA)
+
+	blockTime := tmtime.Now()
+	evidence := hvs.AmnesiaEvidence(blockTime)
+	require.Len(t, evidence, 2)
+
+	assert.Equal(t, precommits[0], evidence[0].Precommit)
+	assert.Equal(t, prevote0, evidence[0].Prevote)
+	assert.Equal(t, lockProof0, evidence[0].LockProof)
+	assert.Equal(t, precommits[1], evidence[1].Precommit)
+	assert.Equal(t, prevote1, evidence[1].Prevote)
+	assert.Equal(t, lockProof1, evidence[1].LockProof)
+	for _, ev := range evidence {
+		assert.NoError(t, ev.ValidateBasic())
+		assert.Equal(t, blockTime, ev.Time())
+		assert.EqualValues(t, 4, ev.TotalVotingPower)
+		assert.EqualValues(t, 1, ev.ValidatorPower)
+	}
+}
+
+func makeBlockID() types.BlockID {
+	return types.BlockID{
+		Hash:          tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+	}
+}
+
+func makeVote(t *testing.T, height int64, valIndex, round int32, voteType tmproto.SignedMsgType,
+	blockID types.BlockID, privVals []types.PrivValidator) *types.Vote {
+	pubKey, err := privVals[valIndex].GetPubKey()
+	require.NoError(t, err)
+
+	vote := &types.Vote{
+		ValidatorAddress: pubKey.Address(),
+		ValidatorIndex:   valIndex,
+		Height:           height,
+		Round:            round,
+		Timestamp:        tmtime.Now(),
+		Type:             voteType,
+		BlockID:          blockID,
+	}
+	v := vote.ToProto()
+	require.NoError(t, privVals[valIndex].SignVote(config.ChainID(), v))
+	vote.Signature = v.Signature
+	return vote
+}
+
 func makeVoteHR(t *testing.T, height int64, valIndex, round int32, privVals []types.PrivValidator) *types.Vote {
 	privVal := privVals[valIndex]
 	pubKey, err := privVal.GetPubKey()
@@ -8,18 +8,24 @@ Gossiping
 The core functionality begins with the evidence reactor (see reactor.
 go) which operates both the sending and receiving of evidence.
 
-The `Receive` function takes a list of evidence and does the following:
+Evidence is received as a list on the evidence p2p Channel, and for each piece of evidence the reactor:
 
-1. Checks that it does not already have the evidence stored
+1. Skips it if it is for a height the node hasn't reached yet, without acknowledging it
 
-2. Verifies the evidence against the node's state (see state/validation.go#VerifyEvidence)
+2. Checks that it does not already have the evidence stored
 
-3. Stores the evidence to a db and a concurrent list
+3. Verifies the evidence against the node's state (see state/validation.go#VerifyEvidence)
 
-The gossiping of evidence is initiated when a peer is added which starts a go routine to broadcast currently
-uncommitted evidence at intervals of 60 seconds (set by the by broadcastEvidenceIntervalS).
-It uses a concurrent list to store the evidence and before sending verifies that each evidence is still valid in the
-sense that it has not exceeded the max evidence age and height (see types/params.go#EvidenceParams).
+4. Stores the evidence to a db and a concurrent list
+
+5. Acknowledges it to the peer by hash, such that the peer stops sending it
+
+Peers sending invalid evidence are reported with a peer error.
+
+The gossiping of evidence is initiated when a peer is added which starts a go routine to broadcast
+uncommitted evidence the peer hasn't acknowledged, going back over the list at intervals of 10 seconds (set by
+broadcastEvidenceInterval). The evidence acknowledged by each peer is remembered across reconnects, until it is no
+longer pending.
 
 There are two buckets that evidence can be stored in: Pending & Committed.
 
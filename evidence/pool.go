@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,8 +22,9 @@ import (
 )
 
 const (
-	baseKeyCommitted = byte(0x00)
-	baseKeyPending   = byte(0x01)
+	baseKeyCommitted            = byte(0x00)
+	baseKeyPending              = byte(0x01)
+	baseKeyCommittedByValidator = byte(0x02)
 )
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
@@ -44,11 +46,28 @@ type Pool struct {
 
 	pruningHeight int64
 	pruningTime   time.Time
+
+	metrics *Metrics
+}
+
+// PoolOption sets an optional parameter on the Pool.
+type PoolOption func(*Pool)
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) PoolOption {
+	return func(evpool *Pool) { evpool.metrics = metrics }
+}
+
+// CommittedEvidence is evidence along with the height of the block it was
+// committed in.
+type CommittedEvidence struct {
+	Evidence types.Evidence
+	Height   int64
 }
 
 // NewPool creates an evidence pool. If using an existing evidence store,
 // it will add all pending evidence to the concurrent list.
-func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool, error) {
+func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore, options ...PoolOption) (*Pool, error) {
 
 	state, err := stateDB.Load()
 	if err != nil {
@@ -62,6 +81,10 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		logger:        log.NewNopLogger(),
 		evidenceStore: evidenceDB,
 		evidenceList:  clist.New(),
+		metrics:       NopMetrics(),
+	}
+	for _, option := range options {
+		option(pool)
 	}
 
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
@@ -72,6 +95,7 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		return nil, err
 	}
 	atomic.StoreUint32(&pool.evidenceSize, uint32(len(evList)))
+	pool.metrics.Pending.Set(float64(len(evList)))
 	for _, ev := range evList {
 		pool.evidenceList.PushBack(ev)
 	}
@@ -91,6 +115,39 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	return evidence, size
 }
 
+// CommittedEvidenceByValidator returns the evidence committed against the
+// validator with the given address, ordered by the height of the block it was
+// committed in. Only evidence committed while the pool was running is indexed.
+func (evpool *Pool) CommittedEvidenceByValidator(address []byte) ([]CommittedEvidence, error) {
+	prefix := keyCommittedByValidatorPrefix(address)
+	iter, err := dbm.IteratePrefix(evpool.evidenceStore, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	defer iter.Close()
+
+	var evidence []CommittedEvidence
+	for ; iter.Valid(); iter.Next() {
+		// the key suffix is the padded hex height, a slash and the hash
+		suffix := iter.Key()[len(prefix):]
+		height, err := strconv.ParseInt(string(suffix[:16]), 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid committed evidence key %X: %w", iter.Key(), err)
+		}
+
+		ev, err := bytesToEv(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, CommittedEvidence{Evidence: ev, Height: height})
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
 // Update pulls the latest state to be used for expiration and evidence params and then prunes all expired evidence
 func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	// sanity check
@@ -107,7 +164,7 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	// update the state
 	evpool.updateState(state)
 
-	evpool.markEvidenceAsCommitted(ev)
+	evpool.markEvidenceAsCommitted(ev, state.LastBlockHeight)
 
 	// prune pending evidence when it has expired. This also updates when the next evidence will expire
 	if evpool.Size() > 0 && state.LastBlockHeight > evpool.pruningHeight &&
@@ -137,6 +194,7 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 	// 1) Verify against state.
 	err := evpool.verify(ev)
 	if err != nil {
+		evpool.metrics.Rejected.Add(1)
 		return types.NewErrInvalidEvidence(ev, err)
 	}
 
@@ -192,6 +250,7 @@ func (evpool *Pool) CheckEvidence(evList types.EvidenceList) error {
 
 			err := evpool.verify(ev)
 			if err != nil {
+				evpool.metrics.Rejected.Add(1)
 				return &types.ErrInvalidEvidence{Evidence: ev, Reason: err}
 			}
 
@@ -354,7 +413,7 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 	if err != nil {
 		return fmt.Errorf("can't persist evidence: %w", err)
 	}
-	atomic.AddUint32(&evpool.evidenceSize, 1)
+	evpool.metrics.Pending.Set(float64(atomic.AddUint32(&evpool.evidenceSize, 1)))
 	return nil
 }
 
@@ -363,14 +422,15 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	if err := evpool.evidenceStore.Delete(key); err != nil {
 		evpool.logger.Error("Unable to delete pending evidence", "err", err)
 	} else {
-		atomic.AddUint32(&evpool.evidenceSize, ^uint32(0))
+		evpool.metrics.Pending.Set(float64(atomic.AddUint32(&evpool.evidenceSize, ^uint32(0))))
 		evpool.logger.Info("Deleted pending evidence", "evidence", evidence)
 	}
 }
 
-// markEvidenceAsCommitted processes all the evidence in the block, marking it as
-// committed and removing it from the pending database.
-func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
+// markEvidenceAsCommitted processes all the evidence in the block at the given
+// height, marking it as committed, indexing it by the validators involved and
+// removing it from the pending database.
+func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height int64) {
 	blockEvidenceMap := make(map[string]struct{}, len(evidence))
 	for _, ev := range evidence {
 		if evpool.isPending(ev) {
@@ -391,6 +451,12 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 
 		if err := evpool.evidenceStore.Set(key, evBytes); err != nil {
 			evpool.logger.Error("Unable to save committed evidence", "err", err, "key(height/hash)", key)
+			continue
+		}
+		evpool.metrics.Committed.Add(1)
+
+		if err := evpool.indexCommittedEvidence(ev, height); err != nil {
+			evpool.logger.Error("Unable to index committed evidence", "err", err, "evidence", ev)
 		}
 	}
 
@@ -400,6 +466,31 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 	}
 }
 
+// indexCommittedEvidence stores the evidence committed in the block at the
+// given height under each of the validators involved, such that it can be
+// looked up without loading the blocks.
+func (evpool *Pool) indexCommittedEvidence(ev types.Evidence, height int64) error {
+	evpb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return fmt.Errorf("unable to convert to proto, err: %w", err)
+	}
+
+	evBytes, err := evpb.Marshal()
+	if err != nil {
+		return fmt.Errorf("unable to marshal evidence: %w", err)
+	}
+
+	batch := evpool.evidenceStore.NewBatch()
+	defer batch.Close()
+	for _, abciEv := range ev.ABCI() {
+		key := keyCommittedByValidator(abciEv.Validator.Address, height, ev)
+		if err := batch.Set(key, evBytes); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
 // listEvidence retrieves lists evidence from oldest to newest within maxBytes.
 // If maxBytes is -1, there's no cap on the size of returned evidence.
 func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Evidence, int64, error) {
@@ -527,3 +618,12 @@ func keyPending(evidence types.Evidence) []byte {
 func keySuffix(evidence types.Evidence) []byte {
 	return []byte(fmt.Sprintf("%s/%X", bE(evidence.Height()), evidence.Hash()))
 }
+
+func keyCommittedByValidatorPrefix(address []byte) []byte {
+	return append([]byte{baseKeyCommittedByValidator}, []byte(fmt.Sprintf("%X/", address))...)
+}
+
+func keyCommittedByValidator(address []byte, height int64, evidence types.Evidence) []byte {
+	return append(keyCommittedByValidatorPrefix(address),
+		[]byte(fmt.Sprintf("%s/%X", bE(height), evidence.Hash()))...)
+}
@@ -193,6 +193,38 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	}
 }
 
+func TestCommittedEvidenceByValidator(t *testing.T) {
+	height := int64(21)
+	pool, val := defaultTestPool(height)
+	state := pool.State()
+	address := val.PrivKey.PubKey().Address()
+
+	ev1 := types.NewMockDuplicateVoteEvidenceWithValidator(height-1, defaultEvidenceTime.Add(20*time.Minute),
+		val, evidenceChainID)
+	ev2 := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime.Add(21*time.Minute),
+		val, evidenceChainID)
+
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(state, types.EvidenceList{ev2})
+
+	state.LastBlockHeight = height + 2
+	state.LastBlockTime = defaultEvidenceTime.Add(23 * time.Minute)
+	pool.Update(state, types.EvidenceList{ev1})
+
+	committed, err := pool.CommittedEvidenceByValidator(address)
+	require.NoError(t, err)
+	assert.Equal(t, []evidence.CommittedEvidence{
+		{Evidence: ev2, Height: height + 1},
+		{Evidence: ev1, Height: height + 2},
+	}, committed)
+
+	// no evidence was committed against other validators
+	committed, err = pool.CommittedEvidenceByValidator(types.NewMockPV().PrivKey.PubKey().Address())
+	require.NoError(t, err)
+	assert.Empty(t, committed)
+}
+
 func TestVerifyPendingEvidencePasses(t *testing.T) {
 	var height int64 = 1
 	pool, val := defaultTestPool(height)
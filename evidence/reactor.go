@@ -2,108 +2,328 @@ package evidence
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	clist "github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
+	evproto "github.com/tendermint/tendermint/proto/tendermint/evidence"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
+var (
+	_ service.Service = (*Reactor)(nil)
+	_ p2p.Wrapper     = (*evproto.Message)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		EvidenceChannel: {
+			MsgType: new(evproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(EvidenceChannel),
+				Priority:            5,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+	}
+)
+
 const (
-	EvidenceChannel = byte(0x38)
+	EvidenceChannel = p2p.ChannelID(0x38)
 
 	maxMsgSize = 1048576 // 1MB TODO make it configurable
 
-	// broadcast all uncommitted evidence this often. This sets when the reactor
-	// goes back to the start of the list and begins sending the evidence again.
-	// Most evidence should be committed in the very next block that is why we wait
-	// just over the block production rate before sending evidence again.
-	broadcastEvidenceIntervalS = 10
-	// If a message fails wait this much before sending it again
-	peerRetryMessageIntervalMS = 100
+	// broadcast all evidence that a peer hasn't acknowledged this often. This
+	// sets when the reactor goes back to the start of the list and begins
+	// sending the evidence again. Most evidence should be committed in the very
+	// next block that is why we wait just over the block production rate before
+	// sending evidence again.
+	broadcastEvidenceInterval = 10 * time.Second
 )
 
-// Reactor handles evpool evidence broadcasting amongst peers.
+// Reactor handles evpool evidence broadcasting amongst peers. It keeps track of
+// the evidence each peer has acknowledged by hash, including across
+// reconnects, such that evidence is only sent to peers that don't have it yet.
 type Reactor struct {
-	p2p.BaseReactor
-	evpool   *Pool
-	eventBus *types.EventBus
+	service.BaseService
+
+	evpool      *Pool
+	evidenceCh  *p2p.Channel
+	peerUpdates *p2p.PeerUpdatesCh
+	closeCh     chan struct{}
+
+	peerWG sync.WaitGroup
+
+	mtx          tmsync.Mutex
+	peerRoutines map[string]chan struct{}       // closed to stop broadcasting to a peer
+	peerSeen     map[string]map[string]struct{} // hashes of evidence each peer has
+
+	// looks up the state of a peer, if set (see WithPeerState)
+	getPeerState func(p2p.PeerID) PeerState
 }
 
-// NewReactor returns a new Reactor with the given config and evpool.
-func NewReactor(evpool *Pool) *Reactor {
-	evR := &Reactor{
-		evpool: evpool,
-	}
-	evR.BaseReactor = *p2p.NewBaseReactor("Evidence", evR)
-	return evR
+// PeerState describes the state of a peer.
+type PeerState interface {
+	GetHeight() int64
 }
 
-// SetLogger sets the Logger on the reactor and the underlying Evidence.
-func (evR *Reactor) SetLogger(l log.Logger) {
-	evR.Logger = l
-	evR.evpool.SetLogger(l)
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithPeerState sets the function used to look up the state of a peer, such
+// that evidence is only sent to peers which have reached its height and for
+// which it isn't too old. The function returns nil if the state of the peer is
+// not known yet. If not set, evidence is sent to all peers.
+func WithPeerState(getPeerState func(p2p.PeerID) PeerState) ReactorOption {
+	return func(r *Reactor) { r.getPeerState = getPeerState }
 }
 
-// GetChannels implements Reactor.
-// It returns the list of channels for this reactor.
-func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  EvidenceChannel,
-			Priority:            5,
-			RecvMessageCapacity: maxMsgSize,
-		},
+// NewReactor returns a reference to a new evidence reactor, which implements
+// the service.Service interface. It accepts a logger, a p2p Channel used to
+// gossip evidence, a channel to listen for peer updates on, and the evidence
+// pool. Note, the reactor will close the p2p Channel when stopping.
+func NewReactor(
+	logger log.Logger,
+	evidenceCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
+	evpool *Pool,
+	options ...ReactorOption,
+) *Reactor {
+	r := &Reactor{
+		evpool:       evpool,
+		evidenceCh:   evidenceCh,
+		peerUpdates:  peerUpdates,
+		closeCh:      make(chan struct{}),
+		peerRoutines: make(map[string]chan struct{}),
+		peerSeen:     make(map[string]map[string]struct{}),
+	}
+	for _, option := range options {
+		option(r)
 	}
+
+	r.BaseService = *service.NewBaseService(logger, "Evidence", r)
+	return r
 }
 
-// AddPeer implements Reactor.
-func (evR *Reactor) AddPeer(peer p2p.Peer) {
-	go evR.broadcastEvidenceRoutine(peer)
+// OnStart starts separate go routines for the p2p Channel and peer updates,
+// and for pruning acknowledgements of evidence that is no longer pending. No
+// error is returned.
+func (r *Reactor) OnStart() error {
+	go r.processEvidenceCh()
+	go r.processPeerUpdates()
+	go r.pruneSeenRoutine()
+
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	// Close closeCh to signal to all spawned goroutines to gracefully exit,
+	// including the broadcast routine of each peer.
+	close(r.closeCh)
+	r.peerWG.Wait()
+
+	// Wait for the p2p Channel and peer updates to be closed before returning.
+	<-r.evidenceCh.Done()
+	<-r.peerUpdates.Done()
+}
+
+// handleEvidenceMessage handles envelopes sent from peers on the
+// EvidenceChannel. Evidence is added to the pool and acknowledged to the peer,
+// unless it is for a height we haven't reached yet, in which case the peer
+// will send it again. It returns an error if the Envelope.Message is unknown
+// for this channel, or if the peer sent invalid evidence. This should never be
+// called outside of handleMessage.
+func (r *Reactor) handleEvidenceMessage(envelope p2p.Envelope) (err error) {
+	switch msg := envelope.Message.(type) {
+	case *tmproto.EvidenceList:
+		var acks [][]byte
+		defer func() {
+			if len(acks) == 0 {
+				return
+			}
+			select {
+			case r.evidenceCh.Out() <- p2p.Envelope{
+				To:      envelope.From,
+				Message: &evproto.EvidenceAck{Hashes: acks},
+			}:
+			case <-r.closeCh:
+			}
+		}()
+
+		for i := range msg.Evidence {
+			ev, err := types.EvidenceFromProto(&msg.Evidence[i])
+			if err != nil {
+				return fmt.Errorf("failed to decode evidence: %w", err)
+			}
+			if err := ev.ValidateBasic(); err != nil {
+				return fmt.Errorf("invalid evidence: %w", err)
+			}
+
+			// The peer has this evidence, so we never need to send it back.
+			r.markSeen(envelope.From, ev.Hash())
+
+			state := r.evpool.State()
+			switch {
+			case ev.Height() > state.LastBlockHeight:
+				// We can't verify evidence for heights we haven't reached, and
+				// don't acknowledge it, so the peer sends it again later.
+				r.Logger.Debug("received evidence ahead of our height", "height", ev.Height(),
+					"last_block_height", state.LastBlockHeight, "peer", envelope.From.String())
+				continue
+
+			case r.evpool.isExpired(ev.Height(), ev.Time()):
+				// The peer may be behind, and will stop sending the evidence once it
+				// is expired for them too.
+				r.Logger.Debug("received expired evidence", "evidence", ev, "peer", envelope.From.String())
+				acks = append(acks, ev.Hash())
+				continue
+			}
+
+			err = r.evpool.AddEvidence(ev)
+			switch err.(type) {
+			case *types.ErrInvalidEvidence:
+				r.Logger.Error("received invalid evidence", "err", err, "peer", envelope.From.String())
+				return err
+
+			case nil:
+				acks = append(acks, ev.Hash())
+
+			default:
+				// continue to the next piece of evidence, which the peer sends again
+				r.Logger.Error("failed to add evidence", "evidence", ev, "err", err)
+			}
+		}
+
+	case *evproto.EvidenceAck:
+		for _, hash := range msg.Hashes {
+			r.markSeen(envelope.From, hash)
+		}
+
+	default:
+		r.Logger.Error("received unknown message", "msg", msg, "peer", envelope.From.String())
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
 }
 
-// Receive implements Reactor.
-// It adds any received evidence to the evpool.
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	evis, err := decodeMsg(msgBytes)
-	if err != nil {
-		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		evR.Switch.StopPeerForError(src, err)
-		return
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.Logger.Error("recovering from processing message panic", "err", err)
+		}
+	}()
+
+	switch chID {
+	case EvidenceChannel:
+		err = r.handleEvidenceMessage(envelope)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
 	}
 
-	for _, ev := range evis {
-		err := evR.evpool.AddEvidence(ev)
-		switch err.(type) {
-		case *types.ErrInvalidEvidence:
-			evR.Logger.Error(err.Error())
-			// punish peer
-			evR.Switch.StopPeerForError(src, err)
+	return err
+}
+
+// processEvidenceCh initiates a blocking process where we listen for and
+// handle envelopes on the EvidenceChannel. Any error encountered during
+// message execution, such as invalid evidence, will result in a PeerError
+// being sent on the EvidenceChannel. When the reactor is stopped, we will
+// catch the signal and close the p2p Channel gracefully.
+func (r *Reactor) processEvidenceCh() {
+	defer r.evidenceCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.evidenceCh.In():
+			if err := r.handleMessage(r.evidenceCh.ID(), envelope); err != nil {
+				select {
+				case r.evidenceCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityHigh,
+				}:
+				case <-r.closeCh:
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on evidence channel; closing...")
 			return
-		case nil:
-		default:
-			// continue to the next piece of evidence
-			evR.Logger.Error("Evidence has not been added", "evidence", evis, "err", err)
 		}
 	}
 }
 
-// SetEventBus implements events.Eventable.
-func (evR *Reactor) SetEventBus(b *types.EventBus) {
-	evR.eventBus = b
+// processPeerUpdate processes a PeerUpdate, starting or stopping the routine
+// broadcasting evidence to the peer. Evidence acknowledged by a peer is kept
+// when it disconnects, such that it isn't sent again when it reconnects.
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	key := peerUpdate.PeerID.String()
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		if _, ok := r.peerRoutines[key]; !ok {
+			closer := make(chan struct{})
+			r.peerRoutines[key] = closer
+			r.peerWG.Add(1)
+			go r.broadcastEvidenceLoop(peerUpdate.PeerID, closer)
+		}
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		if closer, ok := r.peerRoutines[key]; ok {
+			close(closer)
+			delete(r.peerRoutines, key)
+		}
+	}
+}
+
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
 }
 
 // Modeled after the mempool routine.
 // - Evidence accumulates in a clist.
 // - Each peer has a routine that iterates through the clist,
-// sending available evidence to the peer.
+// sending available evidence the peer hasn't acknowledged.
 // - If we're waiting for new evidence and the list is not empty,
 // start iterating from the beginning again.
-func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
+func (r *Reactor) broadcastEvidenceLoop(peerID p2p.PeerID, closer chan struct{}) {
+	defer r.peerWG.Done()
+
 	var next *clist.CElement
 	for {
 		// This happens because the CElement we were looking at got garbage
@@ -111,143 +331,143 @@ func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 		// start from the beginning.
 		if next == nil {
 			select {
-			case <-evR.evpool.EvidenceWaitChan(): // Wait until evidence is available
-				if next = evR.evpool.EvidenceFront(); next == nil {
+			case <-r.evpool.EvidenceWaitChan(): // Wait until evidence is available
+				if next = r.evpool.EvidenceFront(); next == nil {
 					continue
 				}
-			case <-peer.Quit():
+			case <-closer:
 				return
-			case <-evR.Quit():
+			case <-r.closeCh:
 				return
 			}
 		}
 
 		ev := next.Value.(types.Evidence)
-		evis := evR.prepareEvidenceMessage(peer, ev)
-		if len(evis) > 0 {
-			msgBytes, err := encodeMsg(evis)
+		if !r.hasSeen(peerID, ev.Hash()) && r.peerCanVerify(peerID, ev) {
+			evProto, err := types.EvidenceToProto(ev)
 			if err != nil {
-				panic(err)
+				panic(fmt.Errorf("failed to convert evidence: %w", err))
 			}
-			evR.Logger.Debug("Gossiping evidence to peer", "ev", ev, "peer", peer.ID())
-			success := peer.Send(EvidenceChannel, msgBytes)
-			if !success {
-				time.Sleep(peerRetryMessageIntervalMS * time.Millisecond)
-				continue
+
+			r.Logger.Debug("gossiping evidence to peer", "evidence", ev, "peer", peerID.String())
+			select {
+			case r.evidenceCh.Out() <- p2p.Envelope{
+				To:      peerID,
+				Message: &tmproto.EvidenceList{Evidence: []tmproto.Evidence{*evProto}},
+			}:
+			case <-closer:
+				return
+			case <-r.closeCh:
+				return
 			}
 		}
 
-		afterCh := time.After(time.Second * broadcastEvidenceIntervalS)
 		select {
-		case <-afterCh:
-			// start from the beginning every tick.
-			// TODO: only do this if we're at the end of the list!
+		case <-time.After(broadcastEvidenceInterval):
+			// start from the beginning every tick, resending evidence which
+			// hasn't been acknowledged.
 			next = nil
 		case <-next.NextWaitChan():
 			// see the start of the for loop for nil check
 			next = next.Next()
-		case <-peer.Quit():
+		case <-closer:
 			return
-		case <-evR.Quit():
+		case <-r.closeCh:
 			return
 		}
 	}
 }
 
-// Returns the message to send to the peer, or nil if the evidence is invalid for the peer.
-// If message is nil, we should sleep and try again.
-func (evR Reactor) prepareEvidenceMessage(
-	peer p2p.Peer,
-	ev types.Evidence,
-) (evis []types.Evidence) {
-
-	// make sure the peer is up to date
-	evHeight := ev.Height()
-	peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
-	if !ok {
-		// Peer does not have a state yet. We set it in the consensus reactor, but
-		// when we add peer in Switch, the order we call reactors#AddPeer is
-		// different every time due to us using a map. Sometimes other reactors
-		// will be initialized before the consensus reactor. We should wait a few
-		// milliseconds and retry.
-		return nil
+// peerCanVerify returns whether the peer is able to verify the evidence, i.e.
+// peerHeight - maxAge < evidenceHeight < peerHeight. If not, the evidence is
+// skipped until the broadcast routine starts from the beginning again.
+func (r *Reactor) peerCanVerify(peerID p2p.PeerID, ev types.Evidence) bool {
+	if r.getPeerState == nil {
+		return true
+	}
+	peerState := r.getPeerState(peerID)
+	if peerState == nil {
+		// Peer does not have a state yet. It's set by the consensus reactor,
+		// which may not have added the peer yet.
+		return false
 	}
 
-	// NOTE: We only send evidence to peers where
-	// peerHeight - maxAge < evidenceHeight < peerHeight
 	var (
+		evHeight     = ev.Height()
 		peerHeight   = peerState.GetHeight()
-		params       = evR.evpool.State().ConsensusParams.Evidence
+		params       = r.evpool.State().ConsensusParams.Evidence
 		ageNumBlocks = peerHeight - evHeight
 	)
-
-	if peerHeight <= evHeight { // peer is behind. sleep while he catches up
-		return nil
+	if peerHeight <= evHeight { // peer is behind, wait while it catches up
+		return false
 	} else if ageNumBlocks > params.MaxAgeNumBlocks { // evidence is too old relative to the peer, skip
-
 		// NOTE: if evidence is too old for an honest peer, then we're behind and
 		// either it already got committed or it never will!
-		evR.Logger.Info("Not sending peer old evidence",
+		r.Logger.Info("not sending peer old evidence",
 			"peerHeight", peerHeight,
 			"evHeight", evHeight,
 			"maxAgeNumBlocks", params.MaxAgeNumBlocks,
-			"lastBlockTime", evR.evpool.State().LastBlockTime,
+			"lastBlockTime", r.evpool.State().LastBlockTime,
 			"maxAgeDuration", params.MaxAgeDuration,
-			"peer", peer,
+			"peer", peerID.String(),
 		)
-
-		return nil
+		return false
 	}
-
-	// send evidence
-	return []types.Evidence{ev}
+	return true
 }
 
-// PeerState describes the state of a peer.
-type PeerState interface {
-	GetHeight() int64
-}
+// pruneSeenRoutine periodically removes evidence that is no longer pending,
+// e.g. because it was committed or expired, from the acknowledgements of each
+// peer, along with disconnected peers that have no acknowledgements left.
+func (r *Reactor) pruneSeenRoutine() {
+	ticker := time.NewTicker(broadcastEvidenceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pending := make(map[string]struct{})
+			for e := r.evpool.EvidenceFront(); e != nil; e = e.Next() {
+				pending[string(e.Value.(types.Evidence).Hash())] = struct{}{}
+			}
 
-// encodemsg takes a array of evidence
-// returns the byte encoding of the List Message
-func encodeMsg(evis []types.Evidence) ([]byte, error) {
-	evi := make([]tmproto.Evidence, len(evis))
-	for i := 0; i < len(evis); i++ {
-		ev, err := types.EvidenceToProto(evis[i])
-		if err != nil {
-			return nil, err
+			r.mtx.Lock()
+			for key, seen := range r.peerSeen {
+				for hash := range seen {
+					if _, ok := pending[hash]; !ok {
+						delete(seen, hash)
+					}
+				}
+				if _, connected := r.peerRoutines[key]; len(seen) == 0 && !connected {
+					delete(r.peerSeen, key)
+				}
+			}
+			r.mtx.Unlock()
+
+		case <-r.closeCh:
+			return
 		}
-		evi[i] = *ev
-	}
-	epl := tmproto.EvidenceList{
-		Evidence: evi,
 	}
-
-	return epl.Marshal()
 }
 
-// decodemsg takes an array of bytes
-// returns an array of evidence
-func decodeMsg(bz []byte) (evis []types.Evidence, err error) {
-	lm := tmproto.EvidenceList{}
-	if err := lm.Unmarshal(bz); err != nil {
-		return nil, err
-	}
+// markSeen records that a peer has the evidence with the given hash.
+func (r *Reactor) markSeen(peerID p2p.PeerID, hash []byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
 
-	evis = make([]types.Evidence, len(lm.Evidence))
-	for i := 0; i < len(lm.Evidence); i++ {
-		ev, err := types.EvidenceFromProto(&lm.Evidence[i])
-		if err != nil {
-			return nil, err
-		}
-		evis[i] = ev
+	seen, ok := r.peerSeen[peerID.String()]
+	if !ok {
+		seen = make(map[string]struct{})
+		r.peerSeen[peerID.String()] = seen
 	}
+	seen[string(hash)] = struct{}{}
+}
 
-	for i, ev := range evis {
-		if err := ev.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("invalid evidence (#%d): %v", i, err)
-		}
-	}
+// hasSeen returns whether a peer has the evidence with the given hash.
+func (r *Reactor) hasSeen(peerID p2p.PeerID, hash []byte) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
 
-	return evis, nil
+	_, ok := r.peerSeen[peerID.String()][string(hash)]
+	return ok
 }
@@ -14,13 +14,14 @@ import (
 
 	dbm "github.com/tendermint/tm-db"
 
-	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/evidence/mocks"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p"
+	evproto "github.com/tendermint/tendermint/proto/tendermint/evidence"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
@@ -31,12 +32,195 @@ var (
 	timeout     = 120 * time.Second // ridiculously high because CircleCI is slow
 )
 
+type reactorTestSuite struct {
+	peerID  p2p.PeerID
+	reactor *evidence.Reactor
+	pool    *evidence.Pool
+
+	evidenceChannel   *p2p.Channel
+	evidenceInCh      chan p2p.Envelope
+	evidenceOutCh     chan p2p.Envelope
+	evidencePeerErrCh chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+	peerUpdates   *p2p.PeerUpdatesCh
+}
+
+func setup(
+	t *testing.T,
+	logger log.Logger,
+	pool *evidence.Pool,
+	chBuf uint,
+	options ...evidence.ReactorOption,
+) *reactorTestSuite {
+	t.Helper()
+
+	peerUpdatesCh := make(chan p2p.PeerUpdate)
+
+	rts := &reactorTestSuite{
+		peerID:            tmrand.Bytes(16),
+		pool:              pool,
+		evidenceInCh:      make(chan p2p.Envelope, chBuf),
+		evidenceOutCh:     make(chan p2p.Envelope, chBuf),
+		evidencePeerErrCh: make(chan p2p.PeerError, chBuf),
+		peerUpdatesCh:     peerUpdatesCh,
+		peerUpdates:       p2p.NewPeerUpdates(peerUpdatesCh),
+	}
+
+	rts.evidenceChannel = p2p.NewChannel(
+		evidence.EvidenceChannel,
+		new(evproto.Message),
+		rts.evidenceInCh,
+		rts.evidenceOutCh,
+		rts.evidencePeerErrCh,
+	)
+
+	rts.reactor = evidence.NewReactor(
+		logger,
+		rts.evidenceChannel,
+		rts.peerUpdates,
+		pool,
+		options...,
+	)
+
+	require.NoError(t, rts.reactor.Start())
+	require.True(t, rts.reactor.IsRunning())
+
+	t.Cleanup(func() {
+		require.NoError(t, rts.reactor.Stop())
+		require.False(t, rts.reactor.IsRunning())
+	})
+
+	return rts
+}
+
+// createTestSuites creates a reactor test suite for each state store, with an
+// evidence pool backed by a mock block store.
+func createTestSuites(t *testing.T, stateStores []sm.Store, chBuf uint) []*reactorTestSuite {
+	t.Helper()
+
+	logger := evidenceLogger()
+	testSuites := make([]*reactorTestSuite, len(stateStores))
+	for i := range stateStores {
+		blockStore := &mocks.BlockStore{}
+		blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+			&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
+		)
+
+		pool, err := evidence.NewPool(dbm.NewMemDB(), stateStores[i], blockStore)
+		require.NoError(t, err)
+
+		testSuites[i] = setup(t, logger.With("validator", i), pool, chBuf)
+	}
+
+	return testSuites
+}
+
+// connectTestSuites routes the envelopes sent by each reactor to the reactor
+// they are addressed to, and connects every reactor to every other reactor.
+// The routing stops when the test finishes, after the reactors are stopped.
+func connectTestSuites(t *testing.T, testSuites []*reactorTestSuite) {
+	t.Helper()
+
+	suitesByID := make(map[string]*reactorTestSuite, len(testSuites))
+	for _, rts := range testSuites {
+		suitesByID[rts.peerID.String()] = rts
+	}
+
+	doneCh := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	for _, rts := range testSuites {
+		wg.Add(1)
+		go func(rts *reactorTestSuite) {
+			defer wg.Done()
+
+			for {
+				select {
+				case envelope := <-rts.evidenceOutCh:
+					to, ok := suitesByID[envelope.To.String()]
+					if !ok {
+						continue
+					}
+					select {
+					case to.evidenceInCh <- p2p.Envelope{From: rts.peerID, Message: envelope.Message}:
+					case <-doneCh:
+						return
+					}
+
+				case <-doneCh:
+					return
+				}
+			}
+		}(rts)
+	}
+
+	// registered before the reactors are stopped, but cleanups run last-in
+	// first-out, so the routing keeps going until they are.
+	t.Cleanup(func() {
+		close(doneCh)
+		wg.Wait()
+	})
+
+	for _, rts := range testSuites {
+		for _, other := range testSuites {
+			if rts != other {
+				rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: other.peerID, Status: p2p.PeerStatusUp}
+			}
+		}
+	}
+}
+
+// requireNoPeerErrors asserts that no reactor reported an error for a peer.
+func requireNoPeerErrors(t *testing.T, testSuites []*reactorTestSuite) {
+	t.Helper()
+
+	for _, rts := range testSuites {
+		require.Empty(t, rts.evidencePeerErrCh)
+	}
+}
+
+// requireEnvelope waits for the reactor to send an envelope to the given peer,
+// and returns its message.
+func requireEnvelope(t *testing.T, rts *reactorTestSuite, to p2p.PeerID) interface{} {
+	t.Helper()
+
+	select {
+	case envelope := <-rts.evidenceOutCh:
+		require.Equal(t, to, envelope.To)
+		return envelope.Message
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for envelope")
+	}
+	return nil
+}
+
+// requireNoEnvelope asserts that the reactor doesn't send any envelope for a
+// while.
+func requireNoEnvelope(t *testing.T, rts *reactorTestSuite) {
+	t.Helper()
+
+	select {
+	case envelope := <-rts.evidenceOutCh:
+		require.FailNow(t, "unexpected envelope", "%v", envelope)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func evidenceListMsg(t *testing.T, evs ...types.Evidence) *tmproto.EvidenceList {
+	list := &tmproto.EvidenceList{Evidence: make([]tmproto.Evidence, len(evs))}
+	for i, ev := range evs {
+		evProto, err := types.EvidenceToProto(ev)
+		require.NoError(t, err)
+		list.Evidence[i] = *evProto
+	}
+	return list
+}
+
 // We have N evidence reactors connected to one another. The first reactor
 // receives a number of evidence at varying heights. We test that all
 // other reactors receive the evidence and add it to their own respective
 // evidence pools.
 func TestReactorBroadcastEvidence(t *testing.T) {
-	config := cfg.TestConfig()
 	N := 7
 
 	// create statedb for everyone
@@ -48,29 +232,26 @@ func TestReactorBroadcastEvidence(t *testing.T) {
 		stateDBs[i] = initializeValidatorState(val, height)
 	}
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, stateDBs)
+	testSuites := createTestSuites(t, stateDBs, uint(numEvidence*N))
+	connectTestSuites(t, testSuites)
 
-	// set the peer height on each reactor
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			ps := peerState{height}
-			peer.Set(types.PeerStateKey, ps)
-		}
+	pools := make([]*evidence.Pool, N)
+	for i, rts := range testSuites {
+		pools[i] = rts.pool
 	}
 
 	// send a bunch of valid evidence to the first reactor's evpool
 	// and wait for them all to be received in the others
 	evList := sendEvidence(t, pools[0], val, numEvidence)
 	waitForEvidence(t, evList, pools)
+
+	requireNoPeerErrors(t, testSuites)
 }
 
 // We have two evidence reactors connected to one another but are at different heights.
-// Reactor 1 which is ahead receives a number of evidence. It should only send the evidence
-// that is below the height of the peer to that peer.
+// Reactor 1 which is ahead receives a number of evidence. Only the evidence up to the
+// height of reactor 2 should be added to its pool, without punishing reactor 1 for the rest.
 func TestReactorSelectiveBroadcast(t *testing.T) {
-	config := cfg.TestConfig()
-
 	val := types.NewMockPV()
 	height1 := int64(numEvidence) + 10
 	height2 := int64(numEvidence) / 2
@@ -79,110 +260,149 @@ func TestReactorSelectiveBroadcast(t *testing.T) {
 	stateDB1 := initializeValidatorState(val, height1)
 	stateDB2 := initializeValidatorState(val, height2)
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+	testSuites := createTestSuites(t, []sm.Store{stateDB1, stateDB2}, uint(numEvidence*2))
+	connectTestSuites(t, testSuites)
 
-	// set the peer height on each reactor
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			ps := peerState{height1}
-			peer.Set(types.PeerStateKey, ps)
-		}
-	}
+	// send a bunch of valid evidence to the first reactor's evpool
+	evList := sendEvidence(t, testSuites[0].pool, val, numEvidence)
 
-	// update the first reactor peer's height to be very small
-	peer := reactors[0].Switch.Peers().List()[0]
-	ps := peerState{height2}
-	peer.Set(types.PeerStateKey, ps)
+	// only ones up to the height of the peer should make it through
+	waitForEvidence(t, evList[:height2], []*evidence.Pool{testSuites[1].pool})
+	time.Sleep(300 * time.Millisecond)
+	require.EqualValues(t, height2, testSuites[1].pool.Size())
 
-	// send a bunch of valid evidence to the first reactor's evpool
-	evList := sendEvidence(t, pools[0], val, numEvidence)
+	requireNoPeerErrors(t, testSuites)
+}
+
+// Evidence acknowledged by a peer is not sent to it again, not even when it
+// reconnects, while evidence it hasn't acknowledged is.
+func TestReactorAcknowledgedEvidenceNotResent(t *testing.T) {
+	val := types.NewMockPV()
+	stateDB := initializeValidatorState(val, int64(numEvidence))
+	rts := createTestSuites(t, []sm.Store{stateDB}, 10)[0]
+	peerID := p2p.PeerID{0xAA}
 
-	// only ones less than the peers height should make it through
-	waitForEvidence(t, evList[:numEvidence/2-1], []*evidence.Pool{pools[1]})
+	evList := sendEvidence(t, rts.pool, val, 2)
 
-	// peers should still be connected
-	peers := reactors[1].Switch.Peers().List()
-	assert.Equal(t, 1, len(peers))
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
+	for _, ev := range evList {
+		msg := requireEnvelope(t, rts, peerID)
+		require.Equal(t, evidenceListMsg(t, ev), msg)
+	}
+
+	// the peer acknowledges the first evidence only
+	rts.evidenceInCh <- p2p.Envelope{
+		From:    peerID,
+		Message: &evproto.EvidenceAck{Hashes: [][]byte{evList[0].Hash()}},
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// after reconnecting, only the second evidence is sent again
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusDown}
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
+
+	msg := requireEnvelope(t, rts, peerID)
+	require.Equal(t, evidenceListMsg(t, evList[1]), msg)
+	requireNoEnvelope(t, rts)
+	require.Empty(t, rts.evidencePeerErrCh)
 }
 
-// This tests aims to ensure that reactors don't send evidence that they have committed or that ar
-// not ready for the peer through three scenarios.
-// First, committed evidence to a newly connected peer
-// Second, evidence to a peer that is behind
-// Third, evidence that was pending and became committed just before the peer caught up
-func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
-	config := cfg.TestConfig()
+type peerState int64
 
-	val := types.NewMockPV()
-	var height int64 = 10
+func (ps peerState) GetHeight() int64 { return int64(ps) }
 
-	// DB1 is ahead of DB2
-	stateDB1 := initializeValidatorState(val, height-1)
-	stateDB2 := initializeValidatorState(val, height-2)
-	state, err := stateDB1.Load()
+// Evidence is only sent to peers which have reached its height, and not to
+// peers whose state is not known yet.
+func TestReactorBroadcastEvidenceToPeersAtHeight(t *testing.T) {
+	val := types.NewMockPV()
+	stateDB := initializeValidatorState(val, int64(numEvidence))
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
+	)
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateDB, blockStore)
 	require.NoError(t, err)
-	state.LastBlockHeight++
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+	peerAtHeight, peerUnknown := p2p.PeerID{0xAA}, p2p.PeerID{0xBB}
+	rts := setup(t, evidenceLogger(), pool, 10, evidence.WithPeerState(func(peerID p2p.PeerID) evidence.PeerState {
+		if peerID.Equal(peerAtHeight) {
+			return peerState(2)
+		}
+		return nil
+	}))
 
-	evList := sendEvidence(t, pools[0], val, 2)
-	pools[0].Update(state, evList)
-	require.EqualValues(t, uint32(0), pools[0].Size())
+	evList := sendEvidence(t, pool, val, 3)
 
-	time.Sleep(100 * time.Millisecond)
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerUnknown, Status: p2p.PeerStatusUp}
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerAtHeight, Status: p2p.PeerStatusUp}
 
-	peer := reactors[0].Switch.Peers().List()[0]
-	ps := peerState{height - 2}
-	peer.Set(types.PeerStateKey, ps)
+	msg := requireEnvelope(t, rts, peerAtHeight)
+	require.Equal(t, evidenceListMsg(t, evList[0]), msg)
+	requireNoEnvelope(t, rts)
+	require.Empty(t, rts.evidencePeerErrCh)
+}
 
-	peer = reactors[1].Switch.Peers().List()[0]
-	ps = peerState{height}
-	peer.Set(types.PeerStateKey, ps)
+// Evidence received from a peer is added to the pool and acknowledged, and is
+// not sent back to the peer.
+func TestReactorReceiveEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	stateDB := initializeValidatorState(val, int64(numEvidence))
+	rts := createTestSuites(t, []sm.Store{stateDB}, 10)[0]
+	peerID := p2p.PeerID{0xAA}
 
-	// wait to see that no evidence comes through
-	time.Sleep(300 * time.Millisecond)
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
 
-	// the second pool should not have received any evidence because it has already been committed
-	assert.Equal(t, uint32(0), pools[1].Size(), "second reactor should not have received evidence")
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, defaultEvidenceTime, val, evidenceChainID)
+	rts.evidenceInCh <- p2p.Envelope{From: peerID, Message: evidenceListMsg(t, ev)}
 
-	// the first reactor receives three more evidence
-	evList = make([]types.Evidence, 3)
-	for i := 0; i < 3; i++ {
-		ev := types.NewMockDuplicateVoteEvidenceWithValidator(height-3+int64(i),
-			time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), val, state.ChainID)
-		err := pools[0].AddEvidence(ev)
-		require.NoError(t, err)
-		evList[i] = ev
-	}
+	msg := requireEnvelope(t, rts, peerID)
+	require.Equal(t, &evproto.EvidenceAck{Hashes: [][]byte{ev.Hash()}}, msg)
+	require.EqualValues(t, 1, rts.pool.Size())
 
-	// wait to see that only one evidence is sent
-	time.Sleep(300 * time.Millisecond)
+	requireNoEnvelope(t, rts)
+	require.Empty(t, rts.evidencePeerErrCh)
+}
 
-	// the second pool should only have received the first evidence because it is behind
-	peerEv, _ := pools[1].PendingEvidence(10000)
-	assert.EqualValues(t, []types.Evidence{evList[0]}, peerEv)
-
-	// the last evidence is committed and the second reactor catches up in state to the first
-	// reactor. We therefore expect that the second reactor only receives one more evidence, the
-	// one that is still pending and not the evidence that has already been committed.
-	state.LastBlockHeight++
-	pools[0].Update(state, []types.Evidence{evList[2]})
-	// the first reactor should have the two remaining pending evidence
-	require.EqualValues(t, uint32(2), pools[0].Size())
-
-	// now update the state of the second reactor
-	pools[1].Update(state, types.EvidenceList{})
-	peer = reactors[0].Switch.Peers().List()[0]
-	ps = peerState{height}
-	peer.Set(types.PeerStateKey, ps)
-
-	// wait to see that only two evidence is sent
-	time.Sleep(300 * time.Millisecond)
+// Evidence for a height the node hasn't reached yet is not added to the pool
+// nor acknowledged, such that the peer sends it again later, and the peer is
+// not punished for it.
+func TestReactorEvidenceAheadOfHeight(t *testing.T) {
+	val := types.NewMockPV()
+	height := int64(numEvidence)
+	stateDB := initializeValidatorState(val, height)
+	rts := createTestSuites(t, []sm.Store{stateDB}, 10)[0]
+	peerID := p2p.PeerID{0xAA}
+
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(height+1, defaultEvidenceTime, val, evidenceChainID)
+	rts.evidenceInCh <- p2p.Envelope{From: peerID, Message: evidenceListMsg(t, ev)}
+
+	requireNoEnvelope(t, rts)
+	require.EqualValues(t, 0, rts.pool.Size())
+	require.Empty(t, rts.evidencePeerErrCh)
+}
+
+// A peer sending invalid evidence is reported, and the evidence is not
+// acknowledged nor added to the pool.
+func TestReactorInvalidEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	stateDB := initializeValidatorState(val, int64(numEvidence))
+	rts := createTestSuites(t, []sm.Store{stateDB}, 10)[0]
+	peerID := p2p.PeerID{0xAA}
 
-	peerEv, _ = pools[1].PendingEvidence(1000)
-	assert.EqualValues(t, []types.Evidence{evList[0], evList[1]}, peerEv)
+	// evidence from a validator that is not in the validator set
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, defaultEvidenceTime, types.NewMockPV(), evidenceChainID)
+	rts.evidenceInCh <- p2p.Envelope{From: peerID, Message: evidenceListMsg(t, ev)}
+
+	select {
+	case peerErr := <-rts.evidencePeerErrCh:
+		require.Equal(t, peerID, peerErr.PeerID)
+		require.Error(t, peerErr.Err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for peer error")
+	}
+
+	requireNoEnvelope(t, rts)
+	require.EqualValues(t, 0, rts.pool.Size())
 }
 
 // evidenceLogger is a TestingLogger which uses a different
@@ -198,40 +418,6 @@ func evidenceLogger() log.Logger {
 	})
 }
 
-// connect N evidence reactors through N switches
-func makeAndConnectReactorsAndPools(config *cfg.Config, stateStores []sm.Store) ([]*evidence.Reactor,
-	[]*evidence.Pool) {
-	N := len(stateStores)
-
-	reactors := make([]*evidence.Reactor, N)
-	pools := make([]*evidence.Pool, N)
-	logger := evidenceLogger()
-	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
-
-	for i := 0; i < N; i++ {
-		evidenceDB := dbm.NewMemDB()
-		blockStore := &mocks.BlockStore{}
-		blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
-			&types.BlockMeta{Header: types.Header{Time: evidenceTime}},
-		)
-		pool, err := evidence.NewPool(evidenceDB, stateStores[i], blockStore)
-		if err != nil {
-			panic(err)
-		}
-		pools[i] = pool
-		reactors[i] = evidence.NewReactor(pool)
-		reactors[i].SetLogger(logger.With("validator", i))
-	}
-
-	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("EVIDENCE", reactors[i])
-		return s
-
-	}, p2p.Connect2Switches)
-
-	return reactors, pools
-}
-
 // wait for all evidence on all reactors
 func waitForEvidence(t *testing.T, evs types.EvidenceList, pools []*evidence.Pool) {
 	// wait for the evidence in all evpools
@@ -299,14 +485,6 @@ func sendEvidence(t *testing.T, evpool *evidence.Pool, val types.PrivValidator,
 	return evList
 }
 
-type peerState struct {
-	height int64
-}
-
-func (ps peerState) GetHeight() int64 {
-	return ps.height
-}
-
 func exampleVote(t byte) *types.Vote {
 	var stamp, err = time.Parse(types.TimeFormat, "2017-12-25T03:00:01.234Z")
 	if err != nil {
@@ -57,6 +57,13 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 		return VerifyDuplicateVote(ev, state.ChainID, valSet)
 
+	case *types.AmnesiaEvidence:
+		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		return VerifyAmnesia(ev, state.ChainID, valSet)
+
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
 		if err != nil {
@@ -224,6 +231,98 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	return nil
 }
 
+// VerifyAmnesia verifies AmnesiaEvidence against the state of full node. This involves the
+// following checks:
+//      - the votes are a precommit and a prevote for different blocks at the same height by the
+//        same validator, with the prevote in a later round
+//      - the validator is in the validator set at the height of the evidence
+//      - The signatures must both be valid
+//      - the lock proof holds valid prevotes for the precommitted block from validators with
+//        more than 1/3 of the voting power in every round after the precommit up to and
+//        including the round of the prevote, which rules out a proof of lock change the
+//        validator could have prevoted on
+func VerifyAmnesia(e *types.AmnesiaEvidence, chainID string, valSet *types.ValidatorSet) error {
+	if err := e.ValidateBasic(); err != nil {
+		return err
+	}
+
+	_, val := valSet.GetByAddress(e.Precommit.ValidatorAddress)
+	if val == nil {
+		return fmt.Errorf("address %X was not a validator at height %d", e.Precommit.ValidatorAddress, e.Height())
+	}
+	pubKey := val.PubKey
+
+	// pubkey must match address (this should already be true, sanity check)
+	addr := e.Precommit.ValidatorAddress
+	if !bytes.Equal(pubKey.Address(), addr) {
+		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
+			addr, pubKey, pubKey.Address())
+	}
+
+	// validator voting power and total voting power must match
+	if val.VotingPower != e.ValidatorPower {
+		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
+			e.ValidatorPower, val.VotingPower)
+	}
+	if valSet.TotalVotingPower() != e.TotalVotingPower {
+		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+			e.TotalVotingPower, valSet.TotalVotingPower())
+	}
+
+	precommit := e.Precommit.ToProto()
+	prevote := e.Prevote.ToProto()
+	// Signatures must be valid
+	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, precommit), e.Precommit.Signature) {
+		return fmt.Errorf("verifying precommit: %w", types.ErrVoteInvalidSignature)
+	}
+	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, prevote), e.Prevote.Signature) {
+		return fmt.Errorf("verifying prevote: %w", types.ErrVoteInvalidSignature)
+	}
+
+	return verifyAmnesiaLockProof(e, chainID, valSet)
+}
+
+// verifyAmnesiaLockProof checks that the lock proof of the evidence holds valid
+// prevotes from validators with more than 1/3 of the voting power in every
+// round after the precommit up to and including the round of the prevote.
+// ValidateBasic must have been called on the evidence.
+func verifyAmnesiaLockProof(e *types.AmnesiaEvidence, chainID string, valSet *types.ValidatorSet) error {
+	type roundVal struct {
+		round int32
+		addr  string
+	}
+	var (
+		seen  = make(map[roundVal]bool, len(e.LockProof))
+		power = make(map[int32]int64)
+	)
+	for i, vote := range e.LockProof {
+		key := roundVal{vote.Round, string(vote.ValidatorAddress)}
+		if seen[key] {
+			return fmt.Errorf("lock proof has more than one vote from %X in round %d", vote.ValidatorAddress, vote.Round)
+		}
+		seen[key] = true
+
+		_, val := valSet.GetByAddress(vote.ValidatorAddress)
+		if val == nil {
+			return fmt.Errorf("lock proof vote #%d: address %X was not a validator at height %d",
+				i, vote.ValidatorAddress, e.Height())
+		}
+		if err := vote.Verify(chainID, val.PubKey); err != nil {
+			return fmt.Errorf("verifying lock proof vote #%d: %w", i, err)
+		}
+		power[vote.Round] += val.VotingPower
+	}
+
+	total := valSet.TotalVotingPower()
+	for round := e.Precommit.Round + 1; round <= e.Prevote.Round; round++ {
+		if power[round] <= total/3 {
+			return fmt.Errorf("lock proof for round %d has %d voting power, need more than 1/3 of %d",
+				round, power[round], total)
+		}
+	}
+	return nil
+}
+
 func getSignedHeader(blockStore BlockStore, height int64) (*types.SignedHeader, error) {
 	blockMeta := blockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
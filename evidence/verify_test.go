@@ -387,6 +387,165 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyAmnesiaEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+
+	const chainID = "mychain"
+
+	precommit := makeVote(t, val, chainID, 0, 10, 1, 2, blockID, defaultEvidenceTime)
+	lockProof := []*types.Vote{makeVote(t, val2, chainID, 1, 10, 2, 1, blockID, defaultEvidenceTime)}
+	badVote := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime)
+	bv := badVote.ToProto()
+	err := val2.SignVote(chainID, bv)
+	require.NoError(t, err)
+	badVote.Signature = bv.Signature
+
+	cases := []voteData{
+		{precommit, makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime), true},
+		{precommit, makeVote(t, val, chainID, 0, 10, 5, 1, blockID2, defaultEvidenceTime), false},    // no lock proof
+		{precommit, makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime), false},     // same block id
+		{precommit, makeVote(t, val, "mychain2", 0, 10, 2, 1, blockID2, defaultEvidenceTime), false}, // wrong chain id
+		{precommit, makeVote(t, val, chainID, 0, 11, 2, 1, blockID2, defaultEvidenceTime), false},    // wrong height
+		{precommit, makeVote(t, val, chainID, 0, 10, 1, 1, blockID2, defaultEvidenceTime), false},    // same round
+		{precommit, makeVote(t, val, chainID, 0, 10, 2, 2, blockID2, defaultEvidenceTime), false},    // wrong step
+		{precommit, makeVote(t, val2, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime), false},   // wrong validator
+		{precommit, badVote, false}, // signed by wrong key
+	}
+
+	for _, c := range cases {
+		ev := &types.AmnesiaEvidence{
+			Precommit:        c.vote1,
+			Prevote:          c.vote2,
+			LockProof:        lockProof,
+			ValidatorPower:   1,
+			TotalVotingPower: 2,
+			Timestamp:        defaultEvidenceTime,
+		}
+		if c.valid {
+			assert.Nil(t, evidence.VerifyAmnesia(ev, chainID, valSet), "evidence should be valid")
+		} else {
+			assert.NotNil(t, evidence.VerifyAmnesia(ev, chainID, valSet), "evidence should be invalid")
+		}
+	}
+
+	// create good evidence and correct validator power
+	goodEv := types.NewMockAmnesiaEvidenceWithValidators(10, defaultEvidenceTime, val, val2, chainID)
+	goodEv.ValidatorPower = 1
+	goodEv.TotalVotingPower = 2
+	badEv := types.NewMockAmnesiaEvidenceWithValidators(10, defaultEvidenceTime, val, val2, chainID)
+	state := sm.State{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	err = pool.CheckEvidence(types.EvidenceList{goodEv})
+	assert.NoError(t, err)
+
+	// evidence with a different validator power should fail
+	err = pool.CheckEvidence(types.EvidenceList{badEv})
+	assert.Error(t, err)
+}
+
+func TestVerifyAmnesiaEvidenceLockProof(t *testing.T) {
+	const chainID = "mychain"
+	vals := []types.MockPV{types.NewMockPV(), types.NewMockPV(), types.NewMockPV()}
+	validators := make([]*types.Validator, len(vals))
+	for i, val := range vals {
+		validators[i] = val.ExtractIntoValidator(1)
+	}
+	valSet := types.NewValidatorSet(validators)
+	index := func(val types.MockPV) int32 {
+		pubKey, err := val.GetPubKey()
+		require.NoError(t, err)
+		idx, _ := valSet.GetByAddress(pubKey.Address())
+		return idx
+	}
+	prevote := func(val types.MockPV, round int32, blockID types.BlockID) *types.Vote {
+		return makeVote(t, val, chainID, index(val), 10, round, 1, blockID, defaultEvidenceTime)
+	}
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+	precommit := makeVote(t, vals[0], chainID, index(vals[0]), 10, 1, 2, blockID, defaultEvidenceTime)
+
+	badSig := prevote(vals[1], 2, blockID)
+	badSig.Signature = prevote(vals[2], 2, blockID).Signature
+
+	testCases := []struct {
+		name      string
+		lockProof []*types.Vote
+		valid     bool
+	}{
+		{"+1/3 in every round", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[2], 2, blockID),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, true},
+		{"1/3 in a round", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[2], 2, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, false},
+		{"round missing", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[2], 2, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, false},
+		// +2/3 prevotes for another block in the round of the prevote unlock an
+		// honest validator
+		{"polka in the prevote round", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[2], 2, blockID),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID),
+		}, false},
+		{"prevote round missing", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[2], 2, blockID),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+		}, false},
+		{"duplicate votes", []*types.Vote{
+			prevote(vals[1], 2, blockID), prevote(vals[1], 2, blockID),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, false},
+		{"invalid signature", []*types.Vote{
+			badSig, prevote(vals[2], 2, blockID),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, false},
+		{"not a validator", []*types.Vote{
+			prevote(vals[1], 2, blockID), makeVote(t, types.NewMockPV(), chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime),
+			prevote(vals[0], 3, blockID), prevote(vals[1], 3, blockID),
+			prevote(vals[1], 4, blockID), prevote(vals[2], 4, blockID),
+		}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ev := types.NewAmnesiaEvidence(precommit, prevote(vals[0], 4, blockID2), tc.lockProof,
+				defaultEvidenceTime, valSet)
+			err := evidence.VerifyAmnesia(ev, chainID, valSet)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func makeVote(
 	t *testing.T, val types.PrivValidator, chainID string, valIndex int32, height int64,
 	round int32, step int, blockID types.BlockID, time time.Time) *types.Vote {
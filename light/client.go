@@ -64,6 +64,19 @@ func SkippingVerification(trustLevel tmmath.Fraction) Option {
 	}
 }
 
+// HeaderOnlyBisection option configures the light client to fetch header-only
+// light blocks, i.e. without the validators which didn't sign them, for the
+// intermediate headers of skipping verification, from the providers which
+// support them (see provider.HeaderProvider). The validator set of an
+// intermediate header is only fetched once {trustLevel} of the old validator
+// set signed it, which saves most of the bandwidth for chains with large
+// validator sets.
+func HeaderOnlyBisection() Option {
+	return func(c *Client) {
+		c.headerOnlyBisection = true
+	}
+}
+
 // PruningSize option sets the maximum amount of light blocks that the light
 // client stores. When Prune() is run, all light blocks that are earlier than
 // the h amount of light blocks will be removed from the store.
@@ -118,6 +131,8 @@ type Client struct {
 	trustLevel       tmmath.Fraction
 	maxRetryAttempts uint16 // see MaxRetryAttempts option
 	maxClockDrift    time.Duration
+	// See HeaderOnlyBisection option
+	headerOnlyBisection bool
 
 	// Mutex for locking during changes of the light clients providers
 	providerMutex tmsync.Mutex
@@ -693,6 +708,10 @@ func (c *Client) verifySkipping(
 
 		verifiedBlock = trustedBlock
 		trace         = []*types.LightBlock{trustedBlock}
+
+		// header-only light blocks of the intermediate headers in blockCache
+		// (without validator set), by height
+		headerOnlyBlocks = make(map[int64]*types.HeaderOnlyLightBlock)
 	)
 
 	for {
@@ -702,8 +721,27 @@ func (c *Client) verifySkipping(
 			"newHeight", blockCache[depth].Height,
 			"newHash", hash2str(blockCache[depth].Hash()))
 
-		err := Verify(verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, blockCache[depth].SignedHeader,
-			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		var err error
+		if hlb, ok := headerOnlyBlocks[blockCache[depth].Height]; ok {
+			// Fetch the validator set only once the header can be trusted, or
+			// right away for adjacent headers, which are verified with it.
+			if hlb.Height != verifiedBlock.Height+1 {
+				err = VerifyHeaderOnly(verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, hlb,
+					c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+			}
+			if err == nil {
+				var lb *types.LightBlock
+				lb, err = lightBlockOfHeader(ctx, source, hlb)
+				if err == nil {
+					blockCache[depth] = lb
+					delete(headerOnlyBlocks, lb.Height)
+				}
+			}
+		}
+		if err == nil {
+			err = Verify(verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, blockCache[depth].SignedHeader,
+				blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		}
 		switch err.(type) {
 		case nil:
 			// Have we verified the last header
@@ -725,7 +763,7 @@ func (c *Client) verifySkipping(
 			if depth == len(blockCache)-1 {
 				pivotHeight := verifiedBlock.Height + (blockCache[depth].Height-verifiedBlock.
 					Height)*verifySkippingNumerator/verifySkippingDenominator
-				interimBlock, providerErr := source.LightBlock(ctx, pivotHeight)
+				interimBlock, providerErr := c.interimLightBlock(ctx, source, pivotHeight, headerOnlyBlocks)
 				if providerErr != nil {
 					return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: pivotHeight, Reason: providerErr}
 				}
@@ -739,6 +777,48 @@ func (c *Client) verifySkipping(
 	}
 }
 
+// interimLightBlock fetches the light block at the given height from source.
+// With HeaderOnlyBisection, if source is a provider.HeaderProvider, the
+// header-only light block is fetched instead: it is added to headerOnlyBlocks
+// and a light block without validator set is returned.
+func (c *Client) interimLightBlock(
+	ctx context.Context,
+	source provider.Provider,
+	height int64,
+	headerOnlyBlocks map[int64]*types.HeaderOnlyLightBlock) (*types.LightBlock, error) {
+
+	hp, ok := source.(provider.HeaderProvider)
+	if !c.headerOnlyBisection || !ok {
+		return source.LightBlock(ctx, height)
+	}
+
+	hlb, err := hp.HeaderOnlyLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	headerOnlyBlocks[height] = hlb
+	return &types.LightBlock{SignedHeader: hlb.SignedHeader}, nil
+}
+
+// lightBlockOfHeader fetches the light block of the given header-only light
+// block from source. ErrInvalidHeader is returned if source returns another
+// header.
+func lightBlockOfHeader(
+	ctx context.Context,
+	source provider.Provider,
+	hlb *types.HeaderOnlyLightBlock) (*types.LightBlock, error) {
+
+	lb, err := source.LightBlock(ctx, hlb.Height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(lb.Hash(), hlb.Hash()) {
+		return nil, ErrInvalidHeader{fmt.Errorf("light block %X doesn't match header-only light block %X at height %d",
+			lb.Hash(), hlb.Hash(), hlb.Height)}
+	}
+	return lb, nil
+}
+
 // verifySkippingAgainstPrimary does verifySkipping plus it compares new header with
 // witnesses and replaces primary if it sends the light client an invalid header
 func (c *Client) verifySkippingAgainstPrimary(
@@ -842,6 +922,41 @@ func (c *Client) Witnesses() []provider.Provider {
 	return c.witnesses
 }
 
+// DeliverAttacks reports the evidence of the attacks saved in the trusted store
+// to the providers (primary and witnesses) which haven't acknowledged it yet.
+// It returns the number of attacks whose evidence hasn't been acknowledged by
+// all the providers.
+//
+// The evidence of an attack is reported to all the providers when it is
+// detected, DeliverAttacks is meant to be called periodically to retry the
+// failed deliveries.
+func (c *Client) DeliverAttacks(ctx context.Context) (int, error) {
+	attacks, err := c.trustedStore.Attacks()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load attacks: %w", err)
+	}
+
+	c.providerMutex.Lock()
+	providers := append([]provider.Provider{c.primary}, c.witnesses...)
+	c.providerMutex.Unlock()
+
+	pending := 0
+	for _, attack := range attacks {
+		if c.deliverAttack(ctx, attack, providers, false) {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// Attacks returns the attacks detected by the light client, in the order they
+// were detected.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) Attacks() ([]*store.Attack, error) {
+	return c.trustedStore.Attacks()
+}
+
 // Cleanup removes all the data (headers and validator sets) stored. Note: the
 // client must be stopped at this point.
 func (c *Client) Cleanup() error {
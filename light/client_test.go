@@ -1099,3 +1099,80 @@ func TestClientEnsureValidHeadersAndValSets(t *testing.T) {
 	}
 
 }
+
+func TestClientHeaderOnlyBisection(t *testing.T) {
+	// the validator set changes completely at height 41
+	var (
+		headers = make(map[int64]*types.SignedHeader)
+		vals    = make(map[int64]*types.ValidatorSet)
+		keys1   = genPrivKeys(4)
+		keys2   = genPrivKeys(4)
+	)
+	keysAt := func(height int64) privKeys {
+		if height <= 40 {
+			return keys1
+		}
+		return keys2
+	}
+	for height := int64(1); height <= 100; height++ {
+		keys, nextKeys := keysAt(height), keysAt(height+1)
+		lastBlockID := types.BlockID{}
+		if height > 1 {
+			lastBlockID.Hash = headers[height-1].Hash()
+		}
+		headers[height] = keys.GenSignedHeaderLastBlockID(chainID, height,
+			bTime.Add(time.Duration(height)*time.Minute), nil, keys.ToValidators(2, 0), nextKeys.ToValidators(2, 0),
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), lastBlockID)
+		vals[height] = keys.ToValidators(2, 0)
+	}
+
+	verify := func(opts ...light.Option) *countingProvider {
+		primary := &countingProvider{Mock: mockp.New(chainID, headers, vals)}
+		c, err := light.NewClient(
+			ctx,
+			chainID,
+			light.TrustOptions{
+				Period: 4 * time.Hour,
+				Height: 1,
+				Hash:   headers[1].Hash(),
+			},
+			primary,
+			[]provider.Provider{mockp.New(chainID, headers, vals)},
+			dbs.New(dbm.NewMemDB(), chainID),
+			append(opts, light.Logger(log.TestingLogger()))...,
+		)
+		require.NoError(t, err)
+
+		lb, err := c.VerifyLightBlockAtHeight(ctx, 100, bTime.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, headers[100].Hash(), lb.Hash())
+		return primary
+	}
+
+	full := verify()
+	assert.Zero(t, full.headerOnlyBlocks)
+
+	// the validator sets of the headers which can't be trusted aren't fetched
+	headerOnly := verify(light.HeaderOnlyBisection())
+	assert.NotZero(t, headerOnly.headerOnlyBlocks)
+	assert.Less(t, headerOnly.lightBlocks, full.lightBlocks)
+}
+
+// countingProvider counts the light blocks and header-only light blocks it
+// provides.
+type countingProvider struct {
+	*mockp.Mock
+	lightBlocks      int
+	headerOnlyBlocks int
+}
+
+func (p *countingProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	p.lightBlocks++
+	return p.Mock.LightBlock(ctx, height)
+}
+
+func (p *countingProvider) HeaderOnlyLightBlock(ctx context.Context,
+	height int64) (*types.HeaderOnlyLightBlock, error) {
+	p.headerOnlyBlocks++
+	return p.Mock.HeaderOnlyLightBlock(ctx, height)
+}
@@ -0,0 +1,272 @@
+// Package daemon keeps a light client in sync with its primary in the
+// background and reports the sync status of the client.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// DefaultSyncInterval is the default interval between two updates of the
+	// light client.
+	DefaultSyncInterval = 10 * time.Second
+
+	// maxAttacks is the number of most recent attacks kept in the status.
+	maxAttacks = 100
+)
+
+// LightClient is an interface that contains functionality needed by Daemon
+// from the light client.
+type LightClient interface {
+	ChainID() string
+	Update(ctx context.Context, now time.Time) (*types.LightBlock, error)
+	TrustedLightBlock(height int64) (*types.LightBlock, error)
+	Primary() provider.Provider
+	Witnesses() []provider.Provider
+	DeliverAttacks(ctx context.Context) (int, error)
+}
+
+// Status describes how far the light client has synced and the health of the
+// providers it is connected to.
+type Status struct {
+	ChainID             string           `json:"chain_id"`
+	LatestTrustedHeight int64            `json:"latest_trusted_height"`
+	LatestTrustedHash   tmbytes.HexBytes `json:"latest_trusted_hash"`
+	LatestTrustedTime   time.Time        `json:"latest_trusted_time"`
+	LastSyncTime        time.Time        `json:"last_sync_time"`
+	LastSyncError       string           `json:"last_sync_error,omitempty"`
+	Primary             string           `json:"primary"`
+	Witnesses           []WitnessStatus  `json:"witnesses"`
+	Attacks             []Attack         `json:"attacks"`
+	// PendingAttacks is the number of attacks whose evidence hasn't been
+	// acknowledged by all the providers yet.
+	PendingAttacks int `json:"pending_attacks"`
+}
+
+// WitnessStatus describes whether a witness agrees with the latest trusted
+// light block.
+type WitnessStatus struct {
+	Address string `json:"address"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Attack is an attack on the light client, detected while syncing.
+type Attack struct {
+	Time          time.Time `json:"time"`
+	TrustedHeight int64     `json:"trusted_height"`
+	Error         string    `json:"error"`
+}
+
+// Daemon updates a light client every interval, which verifies the latest
+// light block of the primary and cross-checks it with the witnesses, and keeps
+// track of the resulting Status.
+type Daemon struct {
+	service.BaseService
+
+	client   LightClient
+	interval time.Duration
+	metrics  *Metrics
+
+	mtx    tmsync.RWMutex
+	status Status
+}
+
+// Option sets an optional parameter on the Daemon.
+type Option func(*Daemon)
+
+// SyncInterval sets the interval between two updates of the light client.
+func SyncInterval(d time.Duration) Option {
+	return func(dm *Daemon) {
+		dm.interval = d
+	}
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(dm *Daemon) {
+		dm.metrics = metrics
+	}
+}
+
+// NewDaemon returns a new Daemon syncing the given light client.
+func NewDaemon(client LightClient, logger log.Logger, opts ...Option) *Daemon {
+	d := &Daemon{
+		client:   client,
+		interval: DefaultSyncInterval,
+		metrics:  NopMetrics(),
+		status: Status{
+			ChainID:   client.ChainID(),
+			Witnesses: []WitnessStatus{},
+			Attacks:   []Attack{},
+		},
+	}
+	d.BaseService = *service.NewBaseService(logger, "LightDaemon", d)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// OnStart implements service.Service. It starts the sync routine.
+func (d *Daemon) OnStart() error {
+	go d.syncRoutine()
+	return nil
+}
+
+// Status returns the current sync status.
+func (d *Daemon) Status() Status {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	status := d.status
+	status.Witnesses = append([]WitnessStatus{}, d.status.Witnesses...)
+	status.Attacks = append([]Attack{}, d.status.Attacks...)
+	return status
+}
+
+// StatusHandler serves the current sync status as JSON.
+func (d *Daemon) StatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d.Status()); err != nil {
+		d.Logger.Error("Failed to write status", "err", err)
+	}
+}
+
+// syncRoutine updates the light client every interval until the daemon is
+// stopped.
+func (d *Daemon) syncRoutine() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-d.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.sync(ctx, time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-d.Quit():
+			return
+		}
+	}
+}
+
+// sync updates the light client once, checks the witnesses against the latest
+// trusted light block and records the outcome in the status and metrics.
+func (d *Daemon) sync(ctx context.Context, now time.Time) {
+	var attack *Attack
+
+	_, syncErr := d.client.Update(ctx, now)
+	switch {
+	case errors.Is(syncErr, light.ErrLightClientAttack):
+		d.Logger.Error("Detected an attack on the light client", "err", syncErr)
+		d.metrics.Attacks.Add(1)
+		d.metrics.SyncErrors.Add(1)
+		attack = &Attack{Time: now, Error: syncErr.Error()}
+	case syncErr != nil:
+		d.Logger.Error("Failed to update light client", "err", syncErr)
+		d.metrics.SyncErrors.Add(1)
+	}
+
+	trusted, err := d.client.TrustedLightBlock(0)
+	if err != nil {
+		d.Logger.Error("Failed to load latest trusted light block", "err", err)
+	}
+
+	// retry delivering the evidence of the attacks not acknowledged yet
+	pendingAttacks, err := d.client.DeliverAttacks(ctx)
+	if err != nil {
+		d.Logger.Error("Failed to deliver attacks", "err", err)
+	}
+
+	var witnesses []WitnessStatus
+	if trusted != nil {
+		witnesses = d.checkWitnesses(ctx, trusted)
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.status.LastSyncTime = now
+	d.status.LastSyncError = ""
+	if syncErr != nil {
+		d.status.LastSyncError = syncErr.Error()
+	}
+	d.status.Primary = fmt.Sprint(d.client.Primary())
+	d.status.PendingAttacks = pendingAttacks
+	if trusted != nil {
+		d.status.LatestTrustedHeight = trusted.Height
+		d.status.LatestTrustedHash = trusted.Hash()
+		d.status.LatestTrustedTime = trusted.Time
+		d.status.Witnesses = witnesses
+
+		d.metrics.LatestTrustedHeight.Set(float64(trusted.Height))
+		d.metrics.LatestTrustedTime.Set(float64(trusted.Time.Unix()))
+	}
+	if attack != nil {
+		attack.TrustedHeight = d.status.LatestTrustedHeight
+		d.status.Attacks = append(d.status.Attacks, *attack)
+		if len(d.status.Attacks) > maxAttacks {
+			d.status.Attacks = d.status.Attacks[len(d.status.Attacks)-maxAttacks:]
+		}
+	}
+
+	healthy := 0
+	for _, w := range d.status.Witnesses {
+		if w.Healthy {
+			healthy++
+		}
+	}
+	d.metrics.Witnesses.Set(float64(len(d.status.Witnesses)))
+	d.metrics.HealthyWitnesses.Set(float64(healthy))
+}
+
+// checkWitnesses asks every witness for the light block at the height of the
+// trusted light block and compares their hashes.
+func (d *Daemon) checkWitnesses(ctx context.Context, trusted *types.LightBlock) []WitnessStatus {
+	witnesses := d.client.Witnesses()
+	statuses := make([]WitnessStatus, len(witnesses))
+	for i, witness := range witnesses {
+		statuses[i] = WitnessStatus{Address: fmt.Sprint(witness)}
+
+		lb, err := witness.LightBlock(ctx, trusted.Height)
+		switch {
+		case err != nil:
+			statuses[i].Error = err.Error()
+		case !bytes.Equal(lb.Hash(), trusted.Hash()):
+			statuses[i].Error = fmt.Sprintf("conflicting header %X at height %d, expected %X",
+				lb.Hash(), trusted.Height, trusted.Hash())
+		default:
+			statuses[i].Healthy = true
+		}
+		if !statuses[i].Healthy {
+			d.Logger.Info("Witness is unhealthy", "witness", witness, "err", statuses[i].Error)
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,148 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light"
+	lcmock "github.com/tendermint/tendermint/light/daemon/mocks"
+	"github.com/tendermint/tendermint/light/provider"
+	pmock "github.com/tendermint/tendermint/light/provider/mock"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "test-chain"
+
+func TestDaemonSync(t *testing.T) {
+	trusted := makeLightBlock(10)
+	conflicting := makeLightBlock(10)
+
+	lc := &lcmock.LightClient{}
+	lc.On("ChainID").Return(chainID)
+	lc.On("DeliverAttacks", mock.Anything).Return(0, nil)
+	lc.On("Update", mock.Anything, mock.Anything).Return(trusted, nil)
+	lc.On("TrustedLightBlock", int64(0)).Return(trusted, nil)
+	lc.On("Primary").Return(staticProvider{"primary", trusted})
+	lc.On("Witnesses").Return([]provider.Provider{
+		staticProvider{"good", trusted},
+		staticProvider{"bad", conflicting},
+		pmock.NewDeadMock("dead"),
+	})
+
+	d := NewDaemon(lc, log.TestingLogger())
+	now := time.Now()
+	d.sync(context.Background(), now)
+
+	status := d.Status()
+	assert.Equal(t, chainID, status.ChainID)
+	assert.EqualValues(t, 10, status.LatestTrustedHeight)
+	assert.EqualValues(t, trusted.Hash(), status.LatestTrustedHash)
+	assert.Equal(t, trusted.Time, status.LatestTrustedTime)
+	assert.Equal(t, now, status.LastSyncTime)
+	assert.Empty(t, status.LastSyncError)
+	assert.Equal(t, "primary", status.Primary)
+	require.Len(t, status.Witnesses, 3)
+	assert.Equal(t, WitnessStatus{Address: "good", Healthy: true}, status.Witnesses[0])
+	assert.False(t, status.Witnesses[1].Healthy)
+	assert.Contains(t, status.Witnesses[1].Error, "conflicting header")
+	assert.False(t, status.Witnesses[2].Healthy)
+	assert.NotEmpty(t, status.Witnesses[2].Error)
+	assert.Empty(t, status.Attacks)
+}
+
+func TestDaemonSyncAttack(t *testing.T) {
+	trusted := makeLightBlock(10)
+
+	lc := &lcmock.LightClient{}
+	lc.On("ChainID").Return(chainID)
+	lc.On("DeliverAttacks", mock.Anything).Return(1, nil)
+	lc.On("Update", mock.Anything, mock.Anything).Return(nil, light.ErrLightClientAttack)
+	lc.On("TrustedLightBlock", int64(0)).Return(trusted, nil)
+	lc.On("Primary").Return(staticProvider{"primary", trusted})
+	lc.On("Witnesses").Return([]provider.Provider{})
+
+	d := NewDaemon(lc, log.TestingLogger())
+	now := time.Now()
+	d.sync(context.Background(), now)
+
+	status := d.Status()
+	assert.Equal(t, light.ErrLightClientAttack.Error(), status.LastSyncError)
+	assert.Equal(t, []Attack{{
+		Time:          now,
+		TrustedHeight: 10,
+		Error:         light.ErrLightClientAttack.Error(),
+	}}, status.Attacks)
+	assert.Equal(t, 1, status.PendingAttacks)
+}
+
+func TestDaemonStatusHandler(t *testing.T) {
+	trusted := makeLightBlock(5)
+
+	lc := &lcmock.LightClient{}
+	lc.On("ChainID").Return(chainID)
+	lc.On("DeliverAttacks", mock.Anything).Return(0, nil)
+	lc.On("Update", mock.Anything, mock.Anything).Return(nil, nil)
+	lc.On("TrustedLightBlock", int64(0)).Return(trusted, nil)
+	lc.On("Primary").Return(staticProvider{"primary", trusted})
+	lc.On("Witnesses").Return([]provider.Provider{staticProvider{"witness", trusted}})
+
+	d := NewDaemon(lc, log.TestingLogger(), SyncInterval(10*time.Millisecond))
+	require.NoError(t, d.Start())
+	t.Cleanup(func() {
+		require.NoError(t, d.Stop())
+	})
+	require.Eventually(t, func() bool {
+		return d.Status().LatestTrustedHeight == 5
+	}, time.Second, 10*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	d.StatusHandler(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.EqualValues(t, 5, status.LatestTrustedHeight)
+	assert.EqualValues(t, trusted.Hash(), status.LatestTrustedHash)
+	assert.Equal(t, []WitnessStatus{{Address: "witness", Healthy: true}}, status.Witnesses)
+}
+
+func makeLightBlock(height int64) *types.LightBlock {
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{
+				ChainID:        chainID,
+				Height:         height,
+				Time:           time.Now().UTC(),
+				ValidatorsHash: tmrand.Bytes(tmhash.Size),
+			},
+			Commit: &types.Commit{Height: height},
+		},
+	}
+}
+
+// staticProvider returns the same light block at any height.
+type staticProvider struct {
+	id string
+	lb *types.LightBlock
+}
+
+func (p staticProvider) String() string { return p.id }
+
+func (p staticProvider) LightBlock(context.Context, int64) (*types.LightBlock, error) {
+	return p.lb, nil
+}
+
+func (p staticProvider) ReportEvidence(context.Context, types.Evidence) error {
+	return nil
+}
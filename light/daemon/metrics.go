@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the latest trusted light block.
+	LatestTrustedHeight metrics.Gauge
+	// Time of the latest trusted light block, in seconds since the epoch.
+	LatestTrustedTime metrics.Gauge
+	// Number of failed updates of the light client.
+	SyncErrors metrics.Counter
+	// Number of attacks on the light client detected while syncing.
+	Attacks metrics.Counter
+	// Number of witnesses.
+	Witnesses metrics.Gauge
+	// Number of witnesses agreeing with the latest trusted light block.
+	HealthyWitnesses metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		LatestTrustedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "latest_trusted_height",
+			Help:      "Height of the latest trusted light block.",
+		}, labels).With(labelsAndValues...),
+		LatestTrustedTime: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "latest_trusted_time",
+			Help:      "Time of the latest trusted light block, in seconds since the epoch.",
+		}, labels).With(labelsAndValues...),
+		SyncErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_errors",
+			Help:      "Number of failed updates of the light client.",
+		}, labels).With(labelsAndValues...),
+		Attacks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "attacks",
+			Help:      "Number of attacks on the light client detected while syncing.",
+		}, labels).With(labelsAndValues...),
+		Witnesses: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "witnesses",
+			Help:      "Number of witnesses.",
+		}, labels).With(labelsAndValues...),
+		HealthyWitnesses: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "healthy_witnesses",
+			Help:      "Number of witnesses agreeing with the latest trusted light block.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		LatestTrustedHeight: discard.NewGauge(),
+		LatestTrustedTime:   discard.NewGauge(),
+		SyncErrors:          discard.NewCounter(),
+		Attacks:             discard.NewCounter(),
+		Witnesses:           discard.NewGauge(),
+		HealthyWitnesses:    discard.NewGauge(),
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/store"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -84,7 +85,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 			primaryEv := newLightClientAttackEvidence(primaryBlock, witnessTrace[len(witnessTrace)-1], witnessTrace[0])
 			c.logger.Error("Attempted attack detected. Sending evidence againt primary by witness", "ev", primaryEv,
 				"primary", c.primary, "witness", supportingWitness)
-			c.sendEvidence(ctx, primaryEv, supportingWitness)
+			c.reportAttack(ctx, primaryEv, now)
 
 			if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 				c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
@@ -111,7 +112,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 			witnessEv := newLightClientAttackEvidence(witnessBlock, primaryTrace[len(primaryTrace)-1], primaryTrace[0])
 			c.logger.Error("Sending evidence against witness by primary", "ev", witnessEv,
 				"primary", c.primary, "witness", supportingWitness)
-			c.sendEvidence(ctx, witnessEv, c.primary)
+			c.reportAttack(ctx, witnessEv, now)
 			// We return the error and don't process anymore witnesses
 			return ErrLightClientAttack
 
@@ -165,12 +166,48 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	errc <- nil
 }
 
-// sendEvidence sends evidence to a provider on a best effort basis.
-func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
-	err := receiver.ReportEvidence(ctx, ev)
-	if err != nil {
-		c.logger.Error("Failed to report evidence to provider", "ev", ev, "provider", receiver)
+// reportAttack saves the attack in the trusted store and reports its evidence
+// to all the providers. Providers which fail to acknowledge it are retried by
+// DeliverAttacks.
+//
+// NOTE: providerMutex must be locked.
+func (c *Client) reportAttack(ctx context.Context, ev *types.LightClientAttackEvidence, now time.Time) {
+	attack := &store.Attack{Evidence: ev, DetectedAt: now}
+	c.deliverAttack(ctx, attack, append([]provider.Provider{c.primary}, c.witnesses...), true)
+}
+
+// deliverAttack reports the evidence of the attack to the given providers,
+// which haven't acknowledged it yet, and saves the attack if it changed (or
+// save is true). It returns true if some providers haven't acknowledged the
+// evidence yet.
+func (c *Client) deliverAttack(
+	ctx context.Context,
+	attack *store.Attack,
+	providers []provider.Provider,
+	save bool) bool {
+
+	pending := false
+	for _, receiver := range providers {
+		name := fmt.Sprint(receiver)
+		if attack.Acknowledged(name) {
+			continue
+		}
+		if err := receiver.ReportEvidence(ctx, attack.Evidence); err != nil {
+			c.logger.Error("Failed to report evidence to provider", "ev", attack.Evidence, "provider", receiver,
+				"err", err)
+			pending = true
+			continue
+		}
+		attack.AcknowledgedBy = append(attack.AcknowledgedBy, name)
+		save = true
+	}
+
+	if save {
+		if err := c.trustedStore.SaveAttack(attack); err != nil {
+			c.logger.Error("Failed to save attack", "ev", attack.Evidence, "err", err)
+		}
 	}
+	return pending
 }
 
 // examineConflictingHeaderAgainstTrace takes a trace from one provider and a divergent header that
@@ -1,6 +1,9 @@
 package light_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -87,6 +90,92 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		CommonHeight: 4,
 	}
 	assert.True(t, primary.HasEvidence(evAgainstWitness))
+
+	// Check the attacks were saved, acknowledged by both full nodes.
+	attacks, err := c.Attacks()
+	require.NoError(t, err)
+	require.Len(t, attacks, 2)
+	for _, attack := range attacks {
+		assert.ElementsMatch(t, []string{fmt.Sprint(primary), fmt.Sprint(witness)}, attack.AcknowledgedBy)
+	}
+	assert.Equal(t, evAgainstPrimary.ConflictingBlock.Hash(), attacks[0].Evidence.ConflictingBlock.Hash())
+	assert.Equal(t, evAgainstWitness.ConflictingBlock.Hash(), attacks[1].Evidence.ConflictingBlock.Hash())
+}
+
+func TestLightClientAttackEvidence_Delivery(t *testing.T) {
+	// primary performs a lunatic attack
+	var (
+		latestHeight      = int64(10)
+		valSize           = 5
+		divergenceHeight  = int64(6)
+		primaryHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		primaryValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+
+	witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, bTime)
+	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3)
+	forgedVals := forgedKeys.ToValidators(2, 0)
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			primaryHeaders[height] = witnessHeaders[height]
+			primaryValidators[height] = witnessValidators[height]
+			continue
+		}
+		primaryHeaders[height] = forgedKeys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
+		primaryValidators[height] = forgedVals
+	}
+	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+	// the witness is unavailable when the attack is detected
+	witness := &unreliableProvider{Mock: mockp.New(chainID, witnessHeaders, witnessValidators), fail: true}
+
+	db := dbm.NewMemDB()
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   primaryHeaders[1].Hash(),
+		},
+		primary,
+		[]provider.Provider{witness},
+		dbs.New(db, chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(ctx, 10, bTime.Add(1*time.Hour))
+	assert.Equal(t, light.ErrLightClientAttack, err)
+
+	attacks, err := c.Attacks()
+	require.NoError(t, err)
+	require.Len(t, attacks, 2)
+	for _, attack := range attacks {
+		assert.Equal(t, []string{fmt.Sprint(primary)}, attack.AcknowledgedBy)
+		assert.False(t, witness.HasEvidence(attack.Evidence))
+	}
+
+	pending, err := c.DeliverAttacks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	// the evidence is delivered once the witness is back, and the delivery
+	// status is persisted
+	witness.fail = false
+	pending, err = c.DeliverAttacks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+
+	attacks, err = dbs.New(db, chainID).Attacks()
+	require.NoError(t, err)
+	require.Len(t, attacks, 2)
+	for _, attack := range attacks {
+		assert.ElementsMatch(t, []string{fmt.Sprint(primary), fmt.Sprint(witness)}, attack.AcknowledgedBy)
+		assert.True(t, witness.HasEvidence(attack.Evidence))
+	}
 }
 
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
@@ -260,3 +349,16 @@ func TestClientDivergentTraces3(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 0, len(c.Witnesses()))
 }
+
+// unreliableProvider fails to report evidence while fail is true.
+type unreliableProvider struct {
+	*mockp.Mock
+	fail bool
+}
+
+func (p *unreliableProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	if p.fail {
+		return errors.New("unavailable")
+	}
+	return p.Mock.ReportEvidence(ctx, ev)
+}
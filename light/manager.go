@@ -0,0 +1,354 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/light/provider"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultUpdateInterval is the default interval between two updates of the
+// chains hosted by a Manager.
+const DefaultUpdateInterval = 30 * time.Second
+
+// ErrUnknownChain is returned when a Manager doesn't host the requested chain.
+var ErrUnknownChain = errors.New("unknown chain")
+
+// ManagerOption sets an optional parameter on the Manager.
+type ManagerOption func(*Manager)
+
+// UpdateInterval sets the interval between two updates of the chains. Updates
+// are disabled if d is 0.
+func UpdateInterval(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.updateInterval = d
+	}
+}
+
+// ClientOptions sets options applied to the light clients of all the chains,
+// before the options given for a particular chain.
+func ClientOptions(options ...Option) ManagerOption {
+	return func(m *Manager) {
+		m.clientOptions = append(m.clientOptions, options...)
+	}
+}
+
+// HTTPClient sets the http.Client, whose connection pool is shared by all the
+// HTTP providers created by the Manager.
+func HTTPClient(client *http.Client) ManagerOption {
+	return func(m *Manager) {
+		m.httpClient = client
+	}
+}
+
+// managedChain is a light client along with the lock serializing its use.
+type managedChain struct {
+	mtx    tmsync.Mutex
+	client *Client
+}
+
+// Manager hosts light clients for many chains, storing their trusted light
+// blocks in a single DB (with one prefix per chain), and updates them all
+// every UpdateInterval once started.
+//
+// HTTP providers created by the Manager share the connection pool of one
+// http.Client, and a single RPC client is used per remote address.
+type Manager struct {
+	service.BaseService
+
+	db             dbm.DB
+	updateInterval time.Duration
+	clientOptions  []Option
+	httpClient     *http.Client
+
+	mtx        tmsync.RWMutex
+	chains     map[string]*managedChain
+	adding     map[string]bool          // chains whose light client is being created
+	rpcClients map[string]*rpchttp.HTTP // keyed by remote address
+}
+
+// NewManager returns a new Manager storing the trusted light blocks of its
+// chains in db.
+func NewManager(db dbm.DB, options ...ManagerOption) *Manager {
+	m := &Manager{
+		db:             db,
+		updateInterval: DefaultUpdateInterval,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				// Set to true to prevent GZIP-bomb DoS attacks
+				DisableCompression: true,
+				Proxy:              http.ProxyFromEnvironment,
+			},
+		},
+		chains:     make(map[string]*managedChain),
+		adding:     make(map[string]bool),
+		rpcClients: make(map[string]*rpchttp.HTTP),
+	}
+	m.BaseService = *service.NewBaseService(nil, "LightManager", m)
+	for _, o := range options {
+		o(m)
+	}
+	return m
+}
+
+// OnStart implements service.Service. It starts updating the chains
+// periodically, unless updates are disabled.
+func (m *Manager) OnStart() error {
+	if m.updateInterval > 0 {
+		go m.updateRoutine()
+	}
+	return nil
+}
+
+// AddChain starts hosting the given chain, using HTTP providers for the
+// primary and witnesses addresses. If trustOptions.Height is 0, the light
+// client is initialized from the light blocks already stored for the chain.
+//
+// See NewClient and NewClientFromTrustedStore.
+func (m *Manager) AddChain(
+	ctx context.Context,
+	chainID string,
+	trustOptions TrustOptions,
+	primaryAddress string,
+	witnessesAddresses []string,
+	options ...Option) error {
+
+	providers := make([]provider.Provider, 0, len(witnessesAddresses)+1)
+	for _, address := range append([]string{primaryAddress}, witnessesAddresses...) {
+		p, err := m.httpProvider(chainID, address)
+		if err != nil {
+			return fmt.Errorf("provider %s: %w", address, err)
+		}
+		providers = append(providers, p)
+	}
+
+	return m.AddChainWithProviders(ctx, chainID, trustOptions, providers[0], providers[1:], options...)
+}
+
+// AddChainWithProviders starts hosting the given chain, using the given
+// providers. If trustOptions.Height is 0, the light client is initialized from
+// the light blocks already stored for the chain. The other chains are served
+// while the light client is being created.
+//
+// See NewClient and NewClientFromTrustedStore.
+func (m *Manager) AddChainWithProviders(
+	ctx context.Context,
+	chainID string,
+	trustOptions TrustOptions,
+	primary provider.Provider,
+	witnesses []provider.Provider,
+	options ...Option) error {
+
+	// the chain is reserved while creating its light client, which fetches and
+	// verifies the trusted light block, without holding the lock
+	m.mtx.Lock()
+	if _, ok := m.chains[chainID]; ok || m.adding[chainID] {
+		m.mtx.Unlock()
+		return fmt.Errorf("chain %s is already hosted", chainID)
+	}
+	m.adding[chainID] = true
+	m.mtx.Unlock()
+	defer func() {
+		m.mtx.Lock()
+		delete(m.adding, chainID)
+		m.mtx.Unlock()
+	}()
+
+	var (
+		c   *Client
+		err error
+
+		trustedStore = dbs.New(m.db, chainID)
+		opts         = append(append([]Option{}, m.clientOptions...), options...)
+	)
+	if trustOptions.Height == 0 {
+		c, err = NewClientFromTrustedStore(chainID, trustOptions.Period, primary, witnesses, trustedStore, opts...)
+	} else {
+		c, err = NewClient(ctx, chainID, trustOptions, primary, witnesses, trustedStore, opts...)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	m.chains[chainID] = &managedChain{client: c}
+	m.mtx.Unlock()
+	return nil
+}
+
+// RemoveChain stops hosting the given chain. Its trusted light blocks are kept
+// in the DB, so the chain can be added again later.
+func (m *Manager) RemoveChain(chainID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.chains[chainID]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownChain, chainID)
+	}
+	delete(m.chains, chainID)
+	return nil
+}
+
+// Chains returns the IDs of the hosted chains, sorted.
+func (m *Manager) Chains() []string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	chainIDs := make([]string, 0, len(m.chains))
+	for chainID := range m.chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+	return chainIDs
+}
+
+// Client returns the light client of the given chain.
+//
+// NOTE: the client isn't safe for concurrent use with the Manager.
+func (m *Manager) Client(chainID string) (*Client, error) {
+	chain, err := m.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return chain.client, nil
+}
+
+// VerifyLightBlockAtHeight fetches the light block of the given chain at the
+// given height and verifies it.
+//
+// See Client.VerifyLightBlockAtHeight.
+func (m *Manager) VerifyLightBlockAtHeight(
+	ctx context.Context,
+	chainID string,
+	height int64,
+	now time.Time) (*types.LightBlock, error) {
+
+	chain, err := m.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	chain.mtx.Lock()
+	defer chain.mtx.Unlock()
+	return chain.client.VerifyLightBlockAtHeight(ctx, height, now)
+}
+
+// TrustedLightBlock returns a trusted light block of the given chain at the
+// given height (0 - the latest).
+//
+// See Client.TrustedLightBlock.
+func (m *Manager) TrustedLightBlock(chainID string, height int64) (*types.LightBlock, error) {
+	chain, err := m.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return chain.client.TrustedLightBlock(height)
+}
+
+// Update updates the light client of the given chain to the latest light
+// block of its primary.
+//
+// See Client.Update.
+func (m *Manager) Update(ctx context.Context, chainID string, now time.Time) (*types.LightBlock, error) {
+	chain, err := m.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	chain.mtx.Lock()
+	defer chain.mtx.Unlock()
+	return chain.client.Update(ctx, now)
+}
+
+func (m *Manager) chain(chainID string) (*managedChain, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	chain, ok := m.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChain, chainID)
+	}
+	return chain, nil
+}
+
+// updateRoutine updates all the chains every updateInterval until the manager
+// is stopped.
+func (m *Manager) updateRoutine() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(m.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.updateAll(ctx)
+		case <-m.Quit():
+			return
+		}
+	}
+}
+
+// updateAll updates all the chains concurrently and waits for them to finish.
+func (m *Manager) updateAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, chainID := range m.Chains() {
+		wg.Add(1)
+		go func(chainID string) {
+			defer wg.Done()
+			if _, err := m.Update(ctx, chainID, time.Now()); err != nil {
+				m.Logger.Error("Failed to update light client", "chainID", chainID, "err", err)
+			}
+		}(chainID)
+	}
+	wg.Wait()
+}
+
+// httpProvider returns an HTTP provider for the given chain and address,
+// reusing the RPC client of the address if there's one already.
+func (m *Manager) httpProvider(chainID, address string) (provider.Provider, error) {
+	// Ensure URL scheme is set (default HTTP) when not provided.
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	// UNIX sockets need a dedicated dialer, so they can't use the shared pool.
+	if strings.HasPrefix(address, "unix://") {
+		return lighthttp.New(chainID, address)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	client, ok := m.rpcClients[address]
+	if !ok {
+		var err error
+		client, err = rpchttp.NewWithClient(address, "/websocket", m.httpClient)
+		if err != nil {
+			return nil, err
+		}
+		m.rpcClients[address] = client
+	}
+	return lighthttp.NewWithClient(chainID, client), nil
+}
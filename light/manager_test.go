@@ -0,0 +1,139 @@
+package light_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	mockp "github.com/tendermint/tendermint/light/provider/mock"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestManager(t *testing.T) {
+	var (
+		db        = dbm.NewMemDB()
+		m         = light.NewManager(db, light.ClientOptions(light.Logger(log.TestingLogger())))
+		otherNode = mockp.New(genMockNode("other", 10, 3, 0, bTime))
+	)
+	other, err := otherNode.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	otherTrustOptions := light.TrustOptions{Period: trustPeriod, Height: 1, Hash: other.Hash()}
+
+	err = m.AddChainWithProviders(ctx, chainID, trustOptions, fullNode, []provider.Provider{fullNode})
+	require.NoError(t, err)
+	err = m.AddChainWithProviders(ctx, "other", otherTrustOptions, otherNode, []provider.Provider{otherNode})
+	require.NoError(t, err)
+	err = m.AddChainWithProviders(ctx, chainID, trustOptions, fullNode, []provider.Provider{fullNode})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"other", chainID}, m.Chains())
+
+	// each chain is verified with its own trusted store
+	lb, err := m.VerifyLightBlockAtHeight(ctx, chainID, 3, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, h3.Hash(), lb.Hash())
+	lb, err = m.VerifyLightBlockAtHeight(ctx, "other", 10, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, lb.Height)
+	assert.Equal(t, "other", lb.ChainID)
+
+	_, err = m.VerifyLightBlockAtHeight(ctx, "unknown", 1, bTime.Add(2*time.Hour))
+	assert.True(t, errors.Is(err, light.ErrUnknownChain))
+
+	// the chain can be restored from the DB once removed
+	require.NoError(t, m.RemoveChain(chainID))
+	assert.Equal(t, []string{"other"}, m.Chains())
+	assert.True(t, errors.Is(m.RemoveChain(chainID), light.ErrUnknownChain))
+
+	err = m.AddChainWithProviders(ctx, chainID, light.TrustOptions{Period: trustPeriod}, fullNode,
+		[]provider.Provider{fullNode})
+	require.NoError(t, err)
+	lb, err = m.TrustedLightBlock(chainID, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+}
+
+// blockingProvider doesn't respond until the context is done, and signals
+// when it's called.
+type blockingProvider struct {
+	provider.Provider
+	called chan struct{}
+}
+
+func (p blockingProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	select {
+	case p.called <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestManagerAddChainUnresponsive(t *testing.T) {
+	m := light.NewManager(dbm.NewMemDB(), light.ClientOptions(light.Logger(log.TestingLogger())))
+	err := m.AddChainWithProviders(ctx, chainID, trustOptions, fullNode, []provider.Provider{fullNode})
+	require.NoError(t, err)
+
+	addCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	slow := blockingProvider{Provider: fullNode, called: make(chan struct{}, 1)}
+	added := make(chan error, 1)
+	go func() {
+		added <- m.AddChainWithProviders(addCtx, "slow", light.TrustOptions{Period: trustPeriod, Height: 1,
+			Hash: h1.Hash()}, slow, []provider.Provider{slow})
+	}()
+	<-slow.called
+
+	// the other chains are served while the client of the new one is created
+	verified := make(chan error, 1)
+	go func() {
+		_, err := m.VerifyLightBlockAtHeight(ctx, chainID, 3, bTime.Add(2*time.Hour))
+		verified <- err
+	}()
+	select {
+	case err := <-verified:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("verification blocked by the chain being added")
+	}
+	assert.Equal(t, []string{chainID}, m.Chains())
+
+	cancel()
+	assert.Error(t, <-added)
+	assert.Equal(t, []string{chainID}, m.Chains())
+}
+
+func TestManagerUpdate(t *testing.T) {
+	var (
+		node = mockp.New(genMockNode(chainID, 10, 3, 0, time.Now().Add(-time.Hour)))
+		m    = light.NewManager(dbm.NewMemDB(),
+			light.UpdateInterval(10*time.Millisecond),
+			light.ClientOptions(light.Logger(log.TestingLogger())))
+	)
+	m.SetLogger(log.TestingLogger())
+
+	first, err := node.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	err = m.AddChainWithProviders(ctx, chainID,
+		light.TrustOptions{Period: trustPeriod, Height: 1, Hash: first.Hash()},
+		node, []provider.Provider{node})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Start())
+	t.Cleanup(func() {
+		require.NoError(t, m.Stop())
+	})
+
+	require.Eventually(t, func() bool {
+		lb, err := m.TrustedLightBlock(chainID, 0)
+		return err == nil && lb.Height == 10
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/light/provider"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+// grpc provider uses a LightAPI gRPC client to obtain the necessary
+// information.
+type grpc struct {
+	chainID string
+	remote  string
+	client  coregrpc.LightAPIClient
+}
+
+// New creates a gRPC provider, which connects to the LightAPI of the node at
+// the given remote address. If no scheme is provided in the remote address,
+// tcp will be used by default.
+func New(chainID, remote string) (provider.Provider, error) {
+	if !strings.Contains(remote, "://") {
+		remote = "tcp://" + remote
+	}
+
+	client, err := coregrpc.StartGRPCLightClient(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithClient(chainID, remote, client), nil
+}
+
+// NewWithClient allows you to provide a custom client. remote is only used to
+// describe the provider.
+func NewWithClient(chainID, remote string, client coregrpc.LightAPIClient) provider.Provider {
+	return &grpc{
+		chainID: chainID,
+		remote:  remote,
+		client:  client,
+	}
+}
+
+func (p *grpc) String() string {
+	return fmt.Sprintf("grpc{%s}", p.remote)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *grpc) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("expected height >= 0, got height %d", height)}
+	}
+
+	res, err := p.client.LightBlock(ctx, &coregrpc.RequestLightBlock{Height: height})
+	if err != nil {
+		return nil, providerError(err)
+	}
+	if res.LightBlock == nil {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("nil light block")}
+	}
+
+	lb, err := types.LightBlockFromProto(res.LightBlock)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("light block at height %d, requested %d", lb.Height, height),
+		}
+	}
+
+	err = lb.ValidateBasic(p.chainID)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+
+	return lb, nil
+}
+
+// ReportEvidence calls the BroadcastEvidence method of the LightAPI.
+func (p *grpc) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	pb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return err
+	}
+	_, err = p.client.BroadcastEvidence(ctx, &coregrpc.RequestBroadcastEvidence{Evidence: pb})
+	return err
+}
+
+// ConsensusParams fetches the consensus params at the given height, or the
+// latest ones if height is 0. They aren't verified.
+func (p *grpc) ConsensusParams(ctx context.Context, height int64) (*tmproto.ConsensusParams, error) {
+	res, err := p.client.ConsensusParams(ctx, &coregrpc.RequestConsensusParams{Height: height})
+	if err != nil {
+		return nil, providerError(err)
+	}
+	return &res.ConsensusParams, nil
+}
+
+// providerError turns gRPC status errors into provider errors.
+func providerError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return provider.ErrLightBlockNotFound
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return provider.ErrNoResponse
+	default:
+		return err
+	}
+}
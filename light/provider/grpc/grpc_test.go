@@ -0,0 +1,88 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/light/provider"
+	lightgrpc "github.com/tendermint/tendermint/light/provider/grpc"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewProvider(t *testing.T) {
+	c, err := lightgrpc.New("chain-test", "192.168.0.1:26658")
+	require.NoError(t, err)
+	require.Equal(t, "grpc{tcp://192.168.0.1:26658}", fmt.Sprintf("%s", c))
+
+	c, err = lightgrpc.New("chain-test", "unix:///tmp/grpc.sock")
+	require.NoError(t, err)
+	require.Equal(t, "grpc{unix:///tmp/grpc.sock}", fmt.Sprintf("%s", c))
+}
+
+func TestMain(m *testing.M) {
+	app := kvstore.NewApplication()
+	node := rpctest.StartTendermint(app)
+
+	code := m.Run()
+
+	rpctest.StopTendermint(node)
+	os.Exit(code)
+}
+
+func TestProvider(t *testing.T) {
+	cfg := rpctest.GetConfig()
+	defer os.RemoveAll(cfg.RootDir)
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	chainID := genDoc.ChainID
+
+	p, err := lightgrpc.New(chainID, cfg.RPC.GRPCListenAddress)
+	require.NoError(t, err)
+
+	// let it produce some blocks
+	c, err := rpchttp.New(cfg.RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	err = rpcclient.WaitForHeight(c, 5, nil)
+	require.NoError(t, err)
+
+	// let's get the highest block
+	lb, err := p.LightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	assert.NoError(t, lb.ValidateBasic(chainID))
+
+	// historical queries
+	lower := lb.Height - 3
+	lb, err = p.LightBlock(context.Background(), lower)
+	require.NoError(t, err)
+	assert.Equal(t, lower, lb.Height)
+
+	// the light block matches the block served over JSON-RPC
+	block, err := c.Block(context.Background(), &lower)
+	require.NoError(t, err)
+	assert.Equal(t, block.Block.Hash(), lb.Hash())
+	assert.EqualValues(t, block.Block.ValidatorsHash, lb.ValidatorSet.Hash())
+
+	// fetching missing heights should return the appropriate error
+	_, err = p.LightBlock(context.Background(), lb.Height+1000)
+	require.Error(t, err)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+
+	// consensus params
+	paramsProvider, ok := p.(interface {
+		ConsensusParams(ctx context.Context, height int64) (*tmproto.ConsensusParams, error)
+	})
+	require.True(t, ok)
+	params, err := paramsProvider.ConsensusParams(context.Background(), lower)
+	require.NoError(t, err)
+	assert.EqualValues(t, lb.ConsensusHash, types.HashConsensusParams(*params))
+}
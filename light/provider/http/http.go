@@ -86,6 +86,35 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 	return lb, nil
 }
 
+// HeaderOnlyLightBlock fetches a HeaderOnlyLightBlock at the given height
+// from the `/commit_signers` endpoint and checks the chainID matches.
+func (p *http) HeaderOnlyLightBlock(ctx context.Context, height int64) (*types.HeaderOnlyLightBlock, error) {
+	h, err := validateHeight(height)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+
+	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+		res, err := p.client.CommitSigners(ctx, h)
+		if err != nil {
+			// TODO: standardize errors on the RPC side
+			if regexpMissingHeight.MatchString(err.Error()) {
+				return nil, provider.ErrLightBlockNotFound
+			}
+			// we wait and try again with exponential backoff
+			time.Sleep(backoffTimeout(uint16(attempt)))
+			continue
+		}
+
+		hlb := &res.HeaderOnlyLightBlock
+		if err := hlb.ValidateBasic(p.chainID); err != nil {
+			return nil, provider.ErrBadLightBlock{Reason: err}
+		}
+		return hlb, nil
+	}
+	return nil, provider.ErrNoResponse
+}
+
 // ReportEvidence calls `/broadcast_evidence` endpoint.
 func (p *http) ReportEvidence(ctx context.Context, ev types.Evidence) error {
 	_, err := p.client.BroadcastEvidence(ctx, ev)
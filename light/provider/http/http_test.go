@@ -88,3 +88,28 @@ func TestProvider(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, provider.ErrLightBlockNotFound, err)
 }
+
+func TestProviderHeaderOnlyLightBlock(t *testing.T) {
+	cfg := rpctest.GetConfig()
+	chainID := cfg.ChainID()
+
+	c, err := rpchttp.New(cfg.RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	status, err := c.Status(context.Background())
+	require.NoError(t, err)
+	p, ok := lighthttp.NewWithClient(chainID, c).(provider.HeaderProvider)
+	require.True(t, ok)
+
+	err = rpcclient.WaitForHeight(c, 10, nil)
+	require.NoError(t, err)
+
+	hlb, err := p.HeaderOnlyLightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, hlb.Signers, 1)
+	assert.Equal(t, status.ValidatorInfo.Address, hlb.Signers[0].Validator.Address)
+	assert.Equal(t, status.ValidatorInfo.VotingPower, hlb.TotalVotingPower)
+	assert.NoError(t, hlb.VerifyCommitLight(chainID))
+
+	_, err = p.HeaderOnlyLightBlock(context.Background(), hlb.Height+1000)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/tendermint/tendermint/light/provider"
@@ -17,7 +18,7 @@ type Mock struct {
 	evidenceToReport map[string]types.Evidence // hash => evidence
 }
 
-var _ provider.Provider = (*Mock)(nil)
+var _ provider.HeaderProvider = (*Mock)(nil)
 
 // New creates a mock provider with the given set of headers and validator
 // sets.
@@ -31,14 +32,19 @@ func New(id string, headers map[int64]*types.SignedHeader, vals map[int64]*types
 }
 
 func (p *Mock) String() string {
-	var headers strings.Builder
-	for _, h := range p.headers {
-		fmt.Fprintf(&headers, " %d:%X", h.Height, h.Hash())
+	// iterate in height order, so the description of the provider is stable
+	heights := make([]int64, 0, len(p.headers))
+	for height := range p.headers {
+		heights = append(heights, height)
 	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
 
-	var vals strings.Builder
-	for _, v := range p.vals {
-		fmt.Fprintf(&vals, " %X", v.Hash())
+	var headers, vals strings.Builder
+	for _, height := range heights {
+		fmt.Fprintf(&headers, " %d:%X", height, p.headers[height].Hash())
+		if v, ok := p.vals[height]; ok {
+			fmt.Fprintf(&vals, " %X", v.Hash())
+		}
 	}
 
 	return fmt.Sprintf("Mock{id: %s, headers: %s, vals: %v}", p.id, headers.String(), vals.String())
@@ -75,6 +81,18 @@ func (p *Mock) LightBlock(_ context.Context, height int64) (*types.LightBlock, e
 	return lb, nil
 }
 
+func (p *Mock) HeaderOnlyLightBlock(ctx context.Context, height int64) (*types.HeaderOnlyLightBlock, error) {
+	lb, err := p.LightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	hlb, err := types.NewHeaderOnlyLightBlock(lb.SignedHeader, lb.ValidatorSet)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return hlb, nil
+}
+
 func (p *Mock) ReportEvidence(_ context.Context, ev types.Evidence) error {
 	p.evidenceToReport[string(ev.Hash())] = ev
 	return nil
@@ -24,3 +24,20 @@ type Provider interface {
 	// ReportEvidence reports an evidence of misbehavior.
 	ReportEvidence(context.Context, types.Evidence) error
 }
+
+// HeaderProvider is a Provider, which also provides header-only light blocks:
+// signed headers along with the validators which signed them only, so the
+// light client can tell whether a header can be trusted without fetching its
+// whole validator set.
+type HeaderProvider interface {
+	Provider
+
+	// HeaderOnlyLightBlock returns the HeaderOnlyLightBlock that corresponds
+	// to the given height.
+	//
+	// 0 - the latest.
+	// height must be >= 0.
+	//
+	// The errors are the same as the LightBlock ones.
+	HeaderOnlyLightBlock(ctx context.Context, height int64) (*types.HeaderOnlyLightBlock, error)
+}
@@ -0,0 +1,145 @@
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ics23 "github.com/confio/ics23/go"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	// ProofOpICS23IAVL is the type of the ICS23 proof operations for IAVL
+	// trees, e.g. the stores of Cosmos SDK applications.
+	ProofOpICS23IAVL = "ics23:iavl"
+	// ProofOpICS23Simple is the type of the ICS23 proof operations for simple
+	// merkle trees, e.g. the multistore of Cosmos SDK applications.
+	ProofOpICS23Simple = "ics23:simple"
+)
+
+// A ProofVerifier registers the decoders of the proof operations it is able to
+// verify on the given merkle.ProofRuntime.
+type ProofVerifier func(prt *merkle.ProofRuntime)
+
+var proofVerifiers = map[string]ProofVerifier{
+	// simple verifies values proven by simple merkle proofs.
+	"simple": func(prt *merkle.ProofRuntime) {
+		prt.RegisterOpDecoder(merkle.ProofOpValue, merkle.ValueOpDecoder)
+	},
+	// ics23 verifies values proven by ICS23 commitment proofs.
+	"ics23": func(prt *merkle.ProofRuntime) {
+		prt.RegisterOpDecoder(ProofOpICS23IAVL, CommitmentOpDecoder(ics23.IavlSpec))
+		prt.RegisterOpDecoder(ProofOpICS23Simple, CommitmentOpDecoder(ics23.TendermintSpec))
+	},
+}
+
+// RegisterProofVerifier makes the given ProofVerifier available to
+// NewProofRuntime under name. It is not safe for concurrent use and should be
+// called on initialization, e.g. from an init function.
+//
+// It panics if a verifier is already registered under name.
+func RegisterProofVerifier(name string, verifier ProofVerifier) {
+	if _, ok := proofVerifiers[name]; ok {
+		panic(fmt.Sprintf("proof verifier %q is already registered", name))
+	}
+	proofVerifiers[name] = verifier
+}
+
+// ProofVerifiers returns the names of the registered proof verifiers.
+func ProofVerifiers() []string {
+	names := make([]string, 0, len(proofVerifiers))
+	for name := range proofVerifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProofRuntime returns a merkle.ProofRuntime, which verifies the proof
+// operations of the named verifiers.
+func NewProofRuntime(names ...string) (*merkle.ProofRuntime, error) {
+	prt := merkle.NewProofRuntime()
+	for _, name := range names {
+		verifier, ok := proofVerifiers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown proof verifier %q, must be one of %s",
+				name, strings.Join(ProofVerifiers(), ", "))
+		}
+		verifier(prt)
+	}
+	return prt, nil
+}
+
+// CommitmentOp is a merkle.ProofOperator, which proves the existence or
+// absence of a key in a tree with an ICS23 commitment proof.
+type CommitmentOp struct {
+	Type  string
+	Spec  *ics23.ProofSpec
+	Key   []byte
+	Proof *ics23.CommitmentProof
+}
+
+var _ merkle.ProofOperator = CommitmentOp{}
+
+// CommitmentOpDecoder returns a merkle.OpDecoder decoding CommitmentOps for
+// trees with the given spec.
+func CommitmentOpDecoder(spec *ics23.ProofSpec) merkle.OpDecoder {
+	return func(pop tmcrypto.ProofOp) (merkle.ProofOperator, error) {
+		proof := &ics23.CommitmentProof{}
+		if err := proof.Unmarshal(pop.Data); err != nil {
+			return nil, fmt.Errorf("decoding ProofOp.Data into CommitmentProof: %w", err)
+		}
+		return CommitmentOp{
+			Type:  pop.Type,
+			Spec:  spec,
+			Key:   pop.Key,
+			Proof: proof,
+		}, nil
+	}
+}
+
+// GetKey implements merkle.ProofOperator.
+func (op CommitmentOp) GetKey() []byte {
+	return op.Key
+}
+
+// Run implements merkle.ProofOperator. It verifies the absence of the key if
+// args is empty and the existence of the key with the value args[0] otherwise.
+// It returns the root of the tree.
+func (op CommitmentOp) Run(args [][]byte) ([][]byte, error) {
+	root, err := op.Proof.Calculate()
+	if err != nil {
+		return nil, fmt.Errorf("can't calculate root for proof: %w", err)
+	}
+
+	switch len(args) {
+	case 0:
+		if !ics23.VerifyNonMembership(op.Spec, root, op.Proof, op.Key) {
+			return nil, fmt.Errorf("proof did not verify absence of key %X", op.Key)
+		}
+	case 1:
+		if !ics23.VerifyMembership(op.Spec, root, op.Proof, op.Key, args[0]) {
+			return nil, fmt.Errorf("proof did not verify existence of key %X with value %X", op.Key, args[0])
+		}
+	default:
+		return nil, fmt.Errorf("args must be length 0 or 1, got: %d", len(args))
+	}
+
+	return [][]byte{root}, nil
+}
+
+// ProofOp implements merkle.ProofOperator.
+func (op CommitmentOp) ProofOp() tmcrypto.ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return tmcrypto.ProofOp{
+		Type: op.Type,
+		Key:  op.Key,
+		Data: bz,
+	}
+}
@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+func TestNewProofRuntime(t *testing.T) {
+	_, err := NewProofRuntime("simple", "ics23")
+	require.NoError(t, err)
+
+	_, err = NewProofRuntime("simple", "foo")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"ics23", "simple"}, ProofVerifiers())
+	assert.Panics(t, func() { RegisterProofVerifier("simple", func(*merkle.ProofRuntime) {}) })
+}
+
+func TestICS23ProofVerifier(t *testing.T) {
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100)
+	require.NoError(t, err)
+
+	var (
+		key     = []byte("foo")
+		value   = []byte("bar")
+		missing = []byte("qux")
+	)
+	tree.Set(key, value)
+	tree.Set([]byte("baz"), []byte("bat"))
+	root, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	keyPath := func(key []byte) string {
+		return merkle.KeyPath{}.AppendKey(key, merkle.KeyEncodingURL).String()
+	}
+	proofOps := func(proof interface{ Marshal() ([]byte, error) }, key []byte) *tmcrypto.ProofOps {
+		bz, err := proof.Marshal()
+		require.NoError(t, err)
+		return &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{{Type: ProofOpICS23IAVL, Key: key, Data: bz}}}
+	}
+
+	membership, err := tree.GetMembershipProof(key)
+	require.NoError(t, err)
+	nonMembership, err := tree.GetNonMembershipProof(missing)
+	require.NoError(t, err)
+
+	prt, err := NewProofRuntime("ics23")
+	require.NoError(t, err)
+
+	assert.NoError(t, prt.VerifyValue(proofOps(membership, key), root, keyPath(key), value))
+	assert.Error(t, prt.VerifyValue(proofOps(membership, key), root, keyPath(key), []byte("baz")))
+	assert.Error(t, prt.VerifyValue(proofOps(membership, key), []byte("root"), keyPath(key), value))
+	assert.NoError(t, prt.VerifyAbsence(proofOps(nonMembership, missing), root, keyPath(missing)))
+	assert.Error(t, prt.VerifyAbsence(proofOps(membership, key), root, keyPath(key)))
+
+	// without the ics23 verifier, the proof can't be decoded
+	prt, err = NewProofRuntime("simple")
+	require.NoError(t, err)
+	assert.Error(t, prt.VerifyValue(proofOps(membership, key), root, keyPath(key), value))
+}
@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"time"
+
 	"github.com/tendermint/tendermint/libs/bytes"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -13,9 +15,11 @@ import (
 func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 	return map[string]*rpcserver.RPCFunc{
 		// Subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query"),
+		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query,epoch,since"),
 		"unsubscribe":     rpcserver.NewWSRPCFunc(c.UnsubscribeWS, "query"),
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
+		// events polls the events over HTTP.
+		"events": rpcserver.NewRPCFunc(makeEventsFunc(c), "query,epoch,after,wait_time"),
 
 		// info API
 		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
@@ -27,6 +31,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash"),
 		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height"),
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height"),
+		"commit_signers":       rpcserver.NewRPCFunc(makeCommitSignersFunc(c), "height"),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove"),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page"),
@@ -47,6 +52,22 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+		"pending_evidence":   rpcserver.NewRPCFunc(makePendingEvidenceFunc(c), "page,per_page"),
+		"committed_evidence": rpcserver.NewRPCFunc(makeCommittedEvidenceFunc(c), "height,address,page,per_page"),
+
+		// statesync API
+		"snapshots":      rpcserver.NewRPCFunc(makeSnapshotsFunc(c), ""),
+		"snapshot_chunk": rpcserver.NewRPCFunc(makeSnapshotChunkFunc(c), "height,format,index"),
+	}
+}
+
+type rpcEventsFunc func(ctx *rpctypes.Context, query, epoch string, after uint64,
+	waitTime time.Duration) (*ctypes.ResultEvents, error)
+
+func makeEventsFunc(c *lrpc.Client) rpcEventsFunc {
+	return func(ctx *rpctypes.Context, query, epoch string, after uint64,
+		waitTime time.Duration) (*ctypes.ResultEvents, error) {
+		return c.Events(ctx.Context(), query, epoch, after, waitTime)
 	}
 }
 
@@ -123,6 +144,14 @@ func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
 	}
 }
 
+type rpcCommitSignersFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommitSigners, error)
+
+func makeCommitSignersFunc(c *lrpc.Client) rpcCommitSignersFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommitSigners, error) {
+		return c.CommitSigners(ctx.Context(), height)
+	}
+}
+
 type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 func makeTxFunc(c *lrpc.Client) rpcTxFunc {
@@ -244,3 +273,38 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcPendingEvidenceFunc func(ctx *rpctypes.Context, page, perPage *int) (*ctypes.ResultPendingEvidence, error)
+
+func makePendingEvidenceFunc(c *lrpc.Client) rpcPendingEvidenceFunc {
+	return func(ctx *rpctypes.Context, page, perPage *int) (*ctypes.ResultPendingEvidence, error) {
+		return c.PendingEvidence(ctx.Context(), page, perPage)
+	}
+}
+
+type rpcCommittedEvidenceFunc func(ctx *rpctypes.Context, height *int64, address []byte,
+	page, perPage *int) (*ctypes.ResultCommittedEvidence, error)
+
+func makeCommittedEvidenceFunc(c *lrpc.Client) rpcCommittedEvidenceFunc {
+	return func(ctx *rpctypes.Context, height *int64, address []byte,
+		page, perPage *int) (*ctypes.ResultCommittedEvidence, error) {
+		return c.CommittedEvidence(ctx.Context(), height, address, page, perPage)
+	}
+}
+
+type rpcSnapshotsFunc func(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error)
+
+func makeSnapshotsFunc(c *lrpc.Client) rpcSnapshotsFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error) {
+		return c.Snapshots(ctx.Context())
+	}
+}
+
+type rpcSnapshotChunkFunc func(ctx *rpctypes.Context, height uint64, format, index uint32) (
+	*ctypes.ResultSnapshotChunk, error)
+
+func makeSnapshotChunkFunc(c *lrpc.Client) rpcSnapshotChunkFunc {
+	return func(ctx *rpctypes.Context, height uint64, format, index uint32) (*ctypes.ResultSnapshotChunk, error) {
+		return c.SnapshotChunk(ctx.Context(), height, format, index)
+	}
+}
@@ -59,6 +59,15 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// ProofRuntime option can be used to set the merkle.ProofRuntime used to
+// verify values returned by ABCIQuery. merkle.DefaultProofRuntime is used by
+// default.
+func ProofRuntime(prt *merkle.ProofRuntime) Option {
+	return func(c *Client) {
+		c.prt = prt
+	}
+}
+
 // NewClient returns a new client.
 func NewClient(next rpcclient.Client, lc LightClient, opts ...Option) *Client {
 	c := &Client{
@@ -135,25 +144,23 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 		return nil, err
 	}
 
+	// Build a Merkle key path from path and resp.Key.
+	if c.keyPathFn == nil {
+		return nil, errors.New("please configure Client with KeyPathFn option")
+	}
+	kp, err := c.keyPathFn(path, resp.Key)
+	if err != nil {
+		return nil, fmt.Errorf("can't build merkle key path: %w", err)
+	}
+
 	// Validate the value proof against the trusted header.
 	if resp.Value != nil {
-		// 1) build a Merkle key path from path and resp.Key
-		if c.keyPathFn == nil {
-			return nil, errors.New("please configure Client with KeyPathFn option")
-		}
-
-		kp, err := c.keyPathFn(path, resp.Key)
-		if err != nil {
-			return nil, fmt.Errorf("can't build merkle key path: %w", err)
-		}
-
-		// 2) verify value
 		err = c.prt.VerifyValue(resp.ProofOps, l.AppHash, kp.String(), resp.Value)
 		if err != nil {
 			return nil, fmt.Errorf("verify value proof: %w", err)
 		}
 	} else { // OR validate the absence proof against the trusted header.
-		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, string(resp.Key))
+		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, kp.String())
 		if err != nil {
 			return nil, fmt.Errorf("verify absence proof: %w", err)
 		}
@@ -409,7 +416,7 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 
 func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	// Update the light client if we're behind and retrieve the light block at the requested height
-	l, err := c.updateLightClientIfNeededTo(ctx, *height)
+	l, err := c.updateLightClientIfNeededTo(ctx, heightOrLatest(height))
 	if err != nil {
 		return nil, err
 	}
@@ -420,6 +427,25 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
+// CommitSigners returns the verified light block at the given height as a
+// header-only light block.
+func (c *Client) CommitSigners(ctx context.Context, height *int64) (*ctypes.ResultCommitSigners, error) {
+	// Update the light client if we're behind and retrieve the light block at the requested height
+	l, err := c.updateLightClientIfNeededTo(ctx, heightOrLatest(height))
+	if err != nil {
+		return nil, err
+	}
+
+	hlb, err := types.NewHeaderOnlyLightBlock(l.SignedHeader, l.ValidatorSet)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultCommitSigners{
+		HeaderOnlyLightBlock: *hlb,
+		CanonicalCommit:      true,
+	}, nil
+}
+
 // Tx calls rpcclient#Tx method and then verifies the proof if such was
 // requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
@@ -428,31 +454,67 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 		return res, err
 	}
 
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match requested hash %X", res.Hash, hash)
+	}
+	if err := c.verifyTx(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxSearch calls rpcclient#TxSearch method and then verifies the proof of
+// every tx if such was requested.
+func (c *Client) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (
+	*ctypes.ResultTxSearch, error) {
+	res, err := c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	if err != nil || !prove {
+		return res, err
+	}
+
+	for _, tx := range res.Txs {
+		if err := c.verifyTx(ctx, tx); err != nil {
+			return nil, fmt.Errorf("tx %X: %w", tx.Hash, err)
+		}
+	}
+	return res, nil
+}
+
+// verifyTx verifies that res.Tx is included in the block at res.Height by
+// validating res.Proof against the data hash of the trusted header.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx) error {
 	// Validate res.
 	if res.Height <= 0 {
-		return nil, errNegOrZeroHeight
+		return errNegOrZeroHeight
+	}
+	if tH := res.Tx.Hash(); !bytes.Equal(tH, res.Hash) {
+		return fmt.Errorf("tx hash %X does not match with hash %X", tH, res.Hash)
+	}
+	if !bytes.Equal(res.Proof.Data, res.Tx) {
+		return errors.New("proof is for a different tx")
+	}
+	if int64(res.Index) != res.Proof.Proof.Index {
+		return fmt.Errorf("proof index %d does not match with tx index %d", res.Proof.Proof.Index, res.Index)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, res.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
-}
-
-func (c *Client) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (
-	*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return fmt.Errorf("invalid tx proof: %w", err)
+	}
+	return nil
 }
 
 // Validators fetches and verifies validators.
 func (c *Client) Validators(ctx context.Context, height *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators,
 	error) {
 	// Update the light client if we're behind and retrieve the light block at the requested height.
-	l, err := c.updateLightClientIfNeededTo(ctx, *height)
+	l, err := c.updateLightClientIfNeededTo(ctx, heightOrLatest(height))
 	if err != nil {
 		return nil, err
 	}
@@ -479,11 +541,33 @@ func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*cty
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+func (c *Client) PendingEvidence(ctx context.Context, page, perPage *int) (*ctypes.ResultPendingEvidence, error) {
+	return c.next.PendingEvidence(ctx, page, perPage)
+}
+
+func (c *Client) CommittedEvidence(ctx context.Context, height *int64, address []byte,
+	page, perPage *int) (*ctypes.ResultCommittedEvidence, error) {
+	return c.next.CommittedEvidence(ctx, height, address, page, perPage)
+}
+
+func (c *Client) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return c.next.Snapshots(ctx)
+}
+
+func (c *Client) SnapshotChunk(ctx context.Context, height uint64, format, index uint32) (*ctypes.ResultSnapshotChunk, error) {
+	return c.next.SnapshotChunk(ctx, height, format, index)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+func (c *Client) SubscribeSince(ctx context.Context, subscriber, query, epoch string, since uint64,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	return c.next.SubscribeSince(ctx, subscriber, query, epoch, since, outCapacity...)
+}
+
 func (c *Client) Unsubscribe(ctx context.Context, subscriber, query string) error {
 	return c.next.Unsubscribe(ctx, subscriber, query)
 }
@@ -492,23 +576,64 @@ func (c *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return c.next.UnsubscribeAll(ctx, subscriber)
 }
 
+// Events calls rpcclient#Events, but does not verify the events (UNSAFE)!
+// TODO: verify data
+func (c *Client) Events(
+	ctx context.Context,
+	query string,
+	epoch string,
+	after uint64,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	return c.next.Events(ctx, query, epoch, after, waitTime)
+}
+
+// updateLightClientIfNeededTo verifies the light block at the given height. If
+// height is zero, the latest height reported by the primary is used.
 func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height int64) (*types.LightBlock, error) {
-	l, err := c.lc.VerifyLightBlockAtHeight(ctx, height, time.Now())
+	h := height
+	if h == 0 {
+		res, err := c.next.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't get latest height: %w", err)
+		}
+		h = res.SyncInfo.LatestBlockHeight
+	}
+
+	l, err := c.lc.VerifyLightBlockAtHeight(ctx, h, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to update light client to %d: %w", height, err)
+		return nil, fmt.Errorf("failed to update light client to %d: %w", h, err)
 	}
 	return l, nil
 }
 
+// heightOrLatest returns the given height, or zero (latest) if it's nil.
+func heightOrLatest(height *int64) int64 {
+	if height == nil {
+		return 0
+	}
+	return *height
+}
+
 func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 	c.prt.RegisterOpDecoder(typ, dec)
 }
 
 // SubscribeWS subscribes for events using the given query and remote address as
-// a subscriber, but does not verify responses (UNSAFE)!
+// a subscriber, but does not verify responses (UNSAFE)! If since is not 0, the
+// events published after the event with this epoch and sequence number are
+// replayed first.
 // TODO: verify data
-func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
-	out, err := c.next.Subscribe(context.Background(), ctx.RemoteAddr(), query)
+func (c *Client) SubscribeWS(ctx *rpctypes.Context, query, epoch string, since uint64) (*ctypes.ResultSubscribe, error) {
+	var (
+		out <-chan ctypes.ResultEvent
+		err error
+	)
+	if since > 0 {
+		out, err = c.next.SubscribeSince(context.Background(), ctx.RemoteAddr(), query, epoch, since)
+	} else {
+		out, err = c.next.Subscribe(context.Background(), ctx.RemoteAddr(), query)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -150,3 +150,77 @@ func testOpDecoder(pop tmcrypto.ProofOp) (merkle.ProofOperator, error) {
 	}
 	return op, nil
 }
+
+// TestTxSearch tests TxSearch requests and verifies the tx proofs.
+func TestTxSearch(t *testing.T) {
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	resultTx := func(i int) *ctypes.ResultTx {
+		return &ctypes.ResultTx{
+			Hash:   txs[i].Hash(),
+			Height: 5,
+			Index:  uint32(i),
+			Tx:     txs[i],
+			Proof:  txs.Proof(i),
+		}
+	}
+
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(5), mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: 5, DataHash: txs.Hash()},
+			},
+		},
+		nil,
+	)
+
+	// valid proofs
+	next := &rpcmock.Client{}
+	next.On("TxSearch", context.Background(), "tx.height=5", true, (*int)(nil), (*int)(nil), "").Return(
+		&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{resultTx(0), resultTx(2)}, TotalCount: 2}, nil)
+	c := NewClient(next, lc)
+	res, err := c.TxSearch(context.Background(), "tx.height=5", true, nil, nil, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Txs, 2)
+
+	// a tx with the proof of another tx
+	forged := resultTx(1)
+	forged.Proof = txs.Proof(2)
+	next = &rpcmock.Client{}
+	next.On("TxSearch", context.Background(), "tx.height=5", true, (*int)(nil), (*int)(nil), "").Return(
+		&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{resultTx(0), forged}, TotalCount: 2}, nil)
+	c = NewClient(next, lc)
+	_, err = c.TxSearch(context.Background(), "tx.height=5", true, nil, nil, "")
+	assert.Error(t, err)
+
+	// a tx which isn't in the trusted block
+	other := types.Txs{types.Tx("d=4")}
+	forged = &ctypes.ResultTx{Hash: other[0].Hash(), Height: 5, Tx: other[0], Proof: other.Proof(0)}
+	next = &rpcmock.Client{}
+	next.On("Tx", context.Background(), other[0].Hash(), true).Return(forged, nil)
+	c = NewClient(next, lc)
+	_, err = c.Tx(context.Background(), other[0].Hash(), true)
+	assert.Error(t, err)
+}
+
+// TestCommitLatest tests that a nil height is resolved to the latest height.
+func TestCommitLatest(t *testing.T) {
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(7), mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: 7},
+			},
+		},
+		nil,
+	)
+
+	next := &rpcmock.Client{}
+	next.On("Status", context.Background()).Return(
+		&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 7}}, nil)
+
+	c := NewClient(next, lc)
+	res, err := c.Commit(context.Background(), nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, res.Height)
+}
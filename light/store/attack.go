@@ -0,0 +1,66 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	lightproto "github.com/tendermint/tendermint/proto/tendermint/light"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Attack is an attack detected by the light client, along with the providers
+// which acknowledged the evidence of it.
+type Attack struct {
+	Evidence   *types.LightClientAttackEvidence
+	DetectedAt time.Time
+	// AcknowledgedBy contains the providers (see fmt.Sprint) to which the
+	// evidence has been reported successfully.
+	AcknowledgedBy []string
+	// Seq is assigned by the store when the attack is first saved and orders
+	// the attacks detected at the same time. It is not part of the protobuf
+	// encoding.
+	Seq uint64
+}
+
+// Acknowledged returns true if the given provider acknowledged the evidence.
+func (a *Attack) Acknowledged(provider string) bool {
+	for _, p := range a.AcknowledgedBy {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// ToProto converts Attack to protobuf.
+func (a *Attack) ToProto() (*lightproto.Attack, error) {
+	if a == nil {
+		return nil, errors.New("nil attack")
+	}
+	ev, err := a.Evidence.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &lightproto.Attack{
+		Evidence:       ev,
+		DetectedAt:     a.DetectedAt,
+		AcknowledgedBy: a.AcknowledgedBy,
+	}, nil
+}
+
+// AttackFromProto converts protobuf back into the Attack.
+func AttackFromProto(pb *lightproto.Attack) (*Attack, error) {
+	if pb == nil {
+		return nil, errors.New("nil attack")
+	}
+	ev, err := types.LightClientAttackEvidenceFromProto(pb.Evidence)
+	if err != nil {
+		return nil, fmt.Errorf("invalid evidence: %w", err)
+	}
+	return &Attack{
+		Evidence:       ev,
+		DetectedAt:     pb.DetectedAt,
+		AcknowledgedBy: pb.AcknowledgedBy,
+	}, nil
+}
@@ -10,14 +10,11 @@ import (
 
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/light/store"
+	lightproto "github.com/tendermint/tendermint/proto/tendermint/light"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
-var (
-	sizeKey = []byte("size")
-)
-
 type dbs struct {
 	db     dbm.DB
 	prefix string
@@ -30,13 +27,17 @@ type dbs struct {
 // want to use one DB with many light clients).
 func New(db dbm.DB, prefix string) store.Store {
 
-	size := uint16(0)
-	bz, err := db.Get(sizeKey)
+	s := &dbs{db: db, prefix: prefix}
+	bz, err := db.Get(s.sizeKey())
 	if err == nil && len(bz) > 0 {
-		size = unmarshalSize(bz)
+		s.size = unmarshalSize(bz)
+	} else if prefix != "" {
+		// stores created before the prefixed size keys stored their size under
+		// the shared "size" key, so count their light blocks instead
+		s.size = s.countLightBlocks()
 	}
 
-	return &dbs{db: db, prefix: prefix, size: size}
+	return s
 }
 
 // SaveLightBlock persists LightBlock to the db.
@@ -65,7 +66,7 @@ func (s *dbs) SaveLightBlock(lb *types.LightBlock) error {
 	if err = b.Set(s.lbKey(lb.Height), lbBz); err != nil {
 		return err
 	}
-	if err = b.Set(sizeKey, marshalSize(s.size+1)); err != nil {
+	if err = b.Set(s.sizeKey(), marshalSize(s.size+1)); err != nil {
 		return err
 	}
 	if err = b.WriteSync(); err != nil {
@@ -93,7 +94,7 @@ func (s *dbs) DeleteLightBlock(height int64) error {
 	if err := b.Delete(s.lbKey(height)); err != nil {
 		return err
 	}
-	if err := b.Set(sizeKey, marshalSize(s.size-1)); err != nil {
+	if err := b.Set(s.sizeKey(), marshalSize(s.size-1)); err != nil {
 		return err
 	}
 	if err := b.WriteSync(); err != nil {
@@ -273,7 +274,7 @@ func (s *dbs) Prune(size uint16) error {
 
 	s.size -= uint16(pruned)
 
-	if wErr := s.db.SetSync(sizeKey, marshalSize(s.size)); wErr != nil {
+	if wErr := s.db.SetSync(s.sizeKey(), marshalSize(s.size)); wErr != nil {
 		return fmt.Errorf("failed to persist size: %w", wErr)
 	}
 
@@ -289,6 +290,124 @@ func (s *dbs) Size() uint16 {
 	return s.size
 }
 
+// SaveAttack persists the attack to the db.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) SaveAttack(attack *store.Attack) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	b := s.db.NewBatch()
+	defer b.Close()
+
+	if attack.Seq == 0 {
+		seq, err := s.lastAttackSeq()
+		if err != nil {
+			return err
+		}
+		attack.Seq = seq + 1
+		if err := b.Set(s.attackSeqKey(), marshalSeq(attack.Seq)); err != nil {
+			return err
+		}
+	}
+
+	pb, err := attack.ToProto()
+	if err != nil {
+		return fmt.Errorf("unable to convert attack to protobuf: %w", err)
+	}
+
+	bz, err := pb.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling Attack: %w", err)
+	}
+
+	if err := b.Set(s.attackKey(attack), bz); err != nil {
+		return err
+	}
+
+	return b.WriteSync()
+}
+
+// Attacks returns all the attacks stored, in the order they were saved.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) Attacks() ([]*store.Attack, error) {
+	itr, err := dbm.IteratePrefix(s.db, []byte(fmt.Sprintf("attack/%s/", s.prefix)))
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	attacks := make([]*store.Attack, 0)
+	for ; itr.Valid(); itr.Next() {
+		var pb lightproto.Attack
+		if err := pb.Unmarshal(itr.Value()); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		attack, err := store.AttackFromProto(&pb)
+		if err != nil {
+			return nil, fmt.Errorf("proto conversion error: %w", err)
+		}
+		seq, ok := parseAttackKey(itr.Key())
+		if !ok {
+			return nil, fmt.Errorf("invalid attack key %q", itr.Key())
+		}
+		attack.Seq = seq
+		attacks = append(attacks, attack)
+	}
+
+	return attacks, itr.Error()
+}
+
+// countLightBlocks returns the number of light blocks stored.
+func (s *dbs) countLightBlocks() uint16 {
+	itr, err := s.db.Iterator(
+		s.lbKey(1),
+		append(s.lbKey(1<<63-1), byte(0x00)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	size := uint16(0)
+	for ; itr.Valid(); itr.Next() {
+		if _, _, ok := parseLbKey(itr.Key()); ok {
+			size++
+		}
+	}
+	return size
+}
+
+// sizeKey is the key of the size of the store. Stores with a prefix have their
+// own, so that many light clients can share the same DB.
+func (s *dbs) sizeKey() []byte {
+	if s.prefix == "" {
+		return []byte("size")
+	}
+	return []byte(fmt.Sprintf("size/%s", s.prefix))
+}
+
+// lastAttackSeq returns the sequence number of the last saved attack.
+func (s *dbs) lastAttackSeq() (uint64, error) {
+	bz, err := s.db.Get(s.attackSeqKey())
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	return unmarshalSeq(bz), nil
+}
+
+func (s *dbs) attackSeqKey() []byte {
+	return []byte(fmt.Sprintf("attackSeq/%s", s.prefix))
+}
+
+func (s *dbs) attackKey(attack *store.Attack) []byte {
+	return []byte(fmt.Sprintf("attack/%s/%020d/%X", s.prefix, attack.Seq, attack.Evidence.Hash()))
+}
+
 func (s *dbs) lbKey(height int64) []byte {
 	return []byte(fmt.Sprintf("lb/%s/%020d", s.prefix, height))
 }
@@ -319,6 +438,30 @@ func parseLbKey(key []byte) (prefix string, height int64, ok bool) {
 	return
 }
 
+var attackKeyPattern = regexp.MustCompile(`^attack/[^/]*/([0-9]+)/[0-9A-F]*$`)
+
+func parseAttackKey(key []byte) (seq uint64, ok bool) {
+	submatch := attackKeyPattern.FindSubmatch(key)
+	if submatch == nil {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(string(submatch[1]), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
+
+func marshalSeq(seq uint64) []byte {
+	bs := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bs, seq)
+	return bs
+}
+
+func unmarshalSeq(bz []byte) uint64 {
+	return binary.LittleEndian.Uint64(bz)
+}
+
 func marshalSize(size uint16) []byte {
 	bs := make([]byte, 2)
 	binary.LittleEndian.PutUint16(bs, size)
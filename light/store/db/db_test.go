@@ -13,6 +13,8 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light/store"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
@@ -73,6 +75,49 @@ func Test_SaveLightBlock(t *testing.T) {
 
 }
 
+func Test_SharedDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	store1, store2 := New(db, "chain-1"), New(db, "chain-2")
+
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, store1.SaveLightBlock(randLightBlock(height)))
+	}
+	require.NoError(t, store2.SaveLightBlock(randLightBlock(1)))
+
+	// each store has its own size, also once reopened
+	assert.EqualValues(t, 3, store1.Size())
+	assert.EqualValues(t, 1, store2.Size())
+	assert.EqualValues(t, 3, New(db, "chain-1").Size())
+	assert.EqualValues(t, 1, New(db, "chain-2").Size())
+
+	require.NoError(t, store1.Prune(1))
+	assert.EqualValues(t, 1, store1.Size())
+	assert.EqualValues(t, 1, New(db, "chain-2").Size())
+	_, err := store2.LightBlock(1)
+	require.NoError(t, err)
+}
+
+func Test_UpgradedPrefixedStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := New(db, "chain-1")
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, store.SaveLightBlock(randLightBlock(height)))
+	}
+	// the size of prefixed stores used to be stored under the shared key
+	require.NoError(t, db.Delete([]byte("size/chain-1")))
+	require.NoError(t, db.Set([]byte("size"), marshalSize(3)))
+
+	store = New(db, "chain-1")
+	assert.EqualValues(t, 3, store.Size())
+	require.NoError(t, store.DeleteLightBlock(3))
+	assert.EqualValues(t, 2, store.Size())
+	require.NoError(t, store.Prune(1))
+	assert.EqualValues(t, 1, store.Size())
+	height, err := store.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+}
+
 func Test_LightBlockBefore(t *testing.T) {
 	dbStore := New(dbm.NewMemDB(), "Test_LightBlockBefore")
 
@@ -170,6 +215,56 @@ func Test_Concurrency(t *testing.T) {
 	wg.Wait()
 }
 
+func Test_Attacks(t *testing.T) {
+	db := dbm.NewMemDB()
+	dbStore := New(db, "Test_Attacks")
+
+	// attacks detected at the same time are kept in the order they were saved
+	now := time.Now()
+	attacks := make([]*store.Attack, 3)
+	for i := range attacks {
+		attacks[i] = &store.Attack{
+			Evidence:   &types.LightClientAttackEvidence{ConflictingBlock: signedLightBlock(t, int64(i+1)), CommonHeight: 1},
+			DetectedAt: now,
+		}
+		require.NoError(t, dbStore.SaveAttack(attacks[i]))
+		assert.EqualValues(t, i+1, attacks[i].Seq)
+	}
+
+	// saving an attack again replaces it
+	attacks[0].AcknowledgedBy = []string{"provider"}
+	require.NoError(t, dbStore.SaveAttack(attacks[0]))
+
+	saved, err := New(db, "Test_Attacks").Attacks()
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+	for i, attack := range saved {
+		assert.EqualValues(t, i+1, attack.Seq)
+		assert.Equal(t, attacks[i].Evidence.Hash(), attack.Evidence.Hash())
+	}
+	assert.Equal(t, []string{"provider"}, saved[0].AcknowledgedBy)
+}
+
+// signedLightBlock returns a light block which passes ValidateBasic.
+func signedLightBlock(t *testing.T, height int64) *types.LightBlock {
+	const chainID = "test-chain"
+	vals, privVals := types.RandValidatorSet(2, 1)
+	header := randLightBlock(height).Header
+	header.ChainID = chainID
+	header.ValidatorsHash = vals.Hash()
+	blockID := types.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: crypto.CRandBytes(tmhash.Size)},
+	}
+	voteSet := types.NewVoteSet(chainID, height, 0, tmproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
 func randLightBlock(height int64) *types.LightBlock {
 	vals, _ := types.RandValidatorSet(2, 1)
 	return &types.LightBlock{
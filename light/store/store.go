@@ -45,4 +45,14 @@ type Store interface {
 
 	// Size returns a number of currently existing header & validator set pairs.
 	Size() uint16
+
+	// SaveAttack saves the given attack. A new attack (with a zero Seq) is
+	// assigned the next sequence number, while an attack which was already
+	// saved replaces the previous version.
+	SaveAttack(attack *Attack) error
+
+	// Attacks returns the saved attacks, in the order they were detected.
+	//
+	// Attacks are kept by Prune.
+	Attacks() ([]*Attack, error)
 }
@@ -146,6 +146,78 @@ func VerifyAdjacent(
 	return nil
 }
 
+// VerifyHeaderOnly verifies the non-adjacent header-only light block untrusted
+// against trustedHeader, from the validators which signed untrusted only. It
+// ensures that:
+//
+//	a) trustedHeader can still be trusted (if not, ErrOldHeaderExpired is returned)
+//	b) untrusted is valid and its signers are part of its validator set
+//    (if not, ErrInvalidHeader is returned)
+//	c) trustLevel ([1/3, 1]) of trustedVals signed correctly (if not,
+//    ErrNewValSetCantBeTrusted is returned)
+//	d) more than 2/3 of untrusted.TotalVotingPower have signed it
+//    (otherwise, ErrInvalidHeader is returned)
+//  e) headers are non-adjacent.
+//
+// As untrusted.TotalVotingPower isn't proven, d) is weaker than the one of
+// VerifyNonAdjacent, which must still be used with the whole validator set of
+// untrusted before trusting it. VerifyHeaderOnly allows to tell whether the
+// validator set is worth fetching, i.e. whether untrusted can be trusted.
+func VerifyHeaderOnly(
+	trustedHeader *types.SignedHeader, // height=X
+	trustedVals *types.ValidatorSet, // height=X or height=X+1
+	untrusted *types.HeaderOnlyLightBlock, // height=Y
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel tmmath.Fraction) error {
+
+	checkRequiredHeaderFields(trustedHeader)
+
+	if untrusted.SignedHeader == nil {
+		return ErrInvalidHeader{errors.New("missing signed header")}
+	}
+
+	if untrusted.Height == trustedHeader.Height+1 {
+		return errors.New("headers must be non adjacent in height")
+	}
+
+	if err := ValidateTrustLevel(trustLevel); err != nil {
+		return err
+	}
+
+	if HeaderExpired(trustedHeader, trustingPeriod, now) {
+		return ErrOldHeaderExpired{trustedHeader.Time.Add(trustingPeriod), now}
+	}
+
+	if err := verifyNewHeader(untrusted.SignedHeader, trustedHeader, now, maxClockDrift); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	if err := untrusted.ValidateBasic(trustedHeader.ChainID); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	// Ensure that +`trustLevel` (default 1/3) or more in voting power of the last trusted validator
+	// set signed correctly.
+	err := trustedVals.VerifyCommitLightTrusting(trustedHeader.ChainID, untrusted.Commit, trustLevel)
+	if err != nil {
+		switch e := err.(type) {
+		case types.ErrNotEnoughVotingPowerSigned:
+			return ErrNewValSetCantBeTrusted{e}
+		default:
+			return ErrInvalidHeader{e}
+		}
+	}
+
+	// Ensure that +2/3 of the reported voting power signed correctly.
+	if err := untrusted.VerifyCommitLight(trustedHeader.ChainID); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	return nil
+}
+
 // Verify combines both VerifyAdjacent and VerifyNonAdjacent functions.
 func Verify(
 	trustedHeader *types.SignedHeader, // height=X
@@ -227,6 +299,27 @@ func verifyNewHeaderAndVals(
 	now time.Time,
 	maxClockDrift time.Duration) error {
 
+	if err := verifyNewHeader(untrustedHeader, trustedHeader, now, maxClockDrift); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(untrustedHeader.ValidatorsHash, untrustedVals.Hash()) {
+		return fmt.Errorf("expected new header validators (%X) to match those that were supplied (%X) at height %d",
+			untrustedHeader.ValidatorsHash,
+			untrustedVals.Hash(),
+			untrustedHeader.Height,
+		)
+	}
+
+	return nil
+}
+
+func verifyNewHeader(
+	untrustedHeader *types.SignedHeader,
+	trustedHeader *types.SignedHeader,
+	now time.Time,
+	maxClockDrift time.Duration) error {
+
 	if err := untrustedHeader.ValidateBasic(trustedHeader.ChainID); err != nil {
 		return fmt.Errorf("untrustedHeader.ValidateBasic failed: %w", err)
 	}
@@ -250,14 +343,6 @@ func verifyNewHeaderAndVals(
 			maxClockDrift)
 	}
 
-	if !bytes.Equal(untrustedHeader.ValidatorsHash, untrustedVals.Hash()) {
-		return fmt.Errorf("expected new header validators (%X) to match those that were supplied (%X) at height %d",
-			untrustedHeader.ValidatorsHash,
-			untrustedVals.Hash(),
-			untrustedHeader.Height,
-		)
-	}
-
 	return nil
 }
 
@@ -99,10 +99,11 @@ type PeerUpdatesCh struct {
 	doneCh chan struct{}
 }
 
-// NewPeerUpdates returns a reference to a new PeerUpdatesCh.
-func NewPeerUpdates() *PeerUpdatesCh {
+// NewPeerUpdates returns a reference to a new PeerUpdatesCh, delivering peer
+// updates sent on updatesCh.
+func NewPeerUpdates(updatesCh chan PeerUpdate) *PeerUpdatesCh {
 	return &PeerUpdatesCh{
-		updatesCh: make(chan PeerUpdate),
+		updatesCh: updatesCh,
 		doneCh:    make(chan struct{}),
 	}
 }
@@ -60,7 +60,7 @@ func NewReactorShim(name string, descriptors map[ChannelID]*ChannelDescriptorShi
 
 	rs := &ReactorShim{
 		Name:        name,
-		PeerUpdates: NewPeerUpdates(),
+		PeerUpdates: NewPeerUpdates(make(chan PeerUpdate)),
 		Channels:    channels,
 	}
 
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -51,6 +52,8 @@ type AddrBook interface {
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
 	MarkGood(ID)
+	MarkBad(*NetAddress, time.Duration)
+	IsBanned(*NetAddress) bool
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
@@ -357,6 +360,23 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	}
 }
 
+// BanPeerForError disconnects from a peer due to external error and bans it
+// from the address book for banTime, so that it is neither dialed nor accepted
+// in the meantime. Unlike StopPeerForError, persistent peers are not
+// reconnected.
+func (sw *Switch) BanPeerForError(peer Peer, reason interface{}, banTime time.Duration) {
+	if !peer.IsRunning() {
+		return
+	}
+
+	sw.Logger.Error("Banning peer for error", "peer", peer, "err", reason, "ban_time", banTime)
+	sw.stopAndRemovePeer(peer, reason)
+
+	if sw.addrBook != nil {
+		sw.addrBook.MarkBad(peer.SocketAddr(), banTime)
+	}
+}
+
 // StopPeerGracefully disconnects from a peer gracefully.
 // TODO: handle graceful disconnects.
 func (sw *Switch) StopPeerGracefully(peer Peer) {
@@ -787,6 +807,10 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.addrBook != nil && sw.addrBook.IsBanned(p.SocketAddr()) {
+		return ErrRejected{id: p.ID(), err: errors.New("peer is banned"), isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
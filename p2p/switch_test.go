@@ -451,6 +451,40 @@ func TestSwitchStopPeerForError(t *testing.T) {
 	assert.EqualValues(t, 0, peersMetricValue())
 }
 
+func TestSwitchBanPeerForError(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	require.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	p := sw.Peers().Get(rp.ID())
+	sw.BanPeerForError(p, fmt.Errorf("some err"), time.Hour)
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+
+	// the banned peer must be rejected when dialed again
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if assert.Error(t, err) {
+		if err, ok := err.(ErrRejected); ok {
+			assert.True(t, err.IsFiltered())
+		} else {
+			t.Errorf("expected ErrRejected")
+		}
+	}
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+}
+
 func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
 	err := sw.Start()
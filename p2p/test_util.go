@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
@@ -249,6 +250,7 @@ type AddrBookMock struct {
 	Addrs        map[string]struct{}
 	OurAddrs     map[string]struct{}
 	PrivateAddrs map[string]struct{}
+	BannedAddrs  map[ID]struct{}
 }
 
 var _ AddrBook = (*AddrBookMock)(nil)
@@ -263,6 +265,17 @@ func (book *AddrBookMock) OurAddress(addr *NetAddress) bool {
 	return ok
 }
 func (book *AddrBookMock) MarkGood(ID) {}
+func (book *AddrBookMock) MarkBad(addr *NetAddress, banTime time.Duration) {
+	delete(book.Addrs, addr.String())
+	if book.BannedAddrs == nil {
+		book.BannedAddrs = make(map[ID]struct{})
+	}
+	book.BannedAddrs[addr.ID] = struct{}{}
+}
+func (book *AddrBookMock) IsBanned(addr *NetAddress) bool {
+	_, ok := book.BannedAddrs[addr.ID]
+	return ok
+}
 func (book *AddrBookMock) HasAddress(addr *NetAddress) bool {
 	_, ok := book.Addrs[addr.String()]
 	return ok
@@ -7,6 +7,11 @@ FilePV
 FilePV is the simplest implementation and developer default.
 It uses one file for the private key and another to store state.
 
+The state can instead be stored in any LastSignStateStore. Several signer
+processes (e.g. behind SignerServer) may share one key, as long as they share
+a KVLastSignStateStore backed by a linearizable key-value store such as etcd.
+MemKV is a local stand-in for such a store.
+
 SignerListenerEndpoint
 
 SignerListenerEndpoint establishes a connection to an external process,
@@ -22,6 +22,10 @@ var (
 	ErrWriteTimeout       = errors.New("endpoint write timed out")
 )
 
+// ErrLastSignStateConflict is returned by a LastSignStateStore if the state
+// was modified since it was loaded, e.g. by another signer sharing the store.
+var ErrLastSignStateConflict = errors.New("last sign state was modified by another signer")
+
 // RemoteSignerError allows (remote) validators to include meaningful error
 // descriptions in their reply.
 type RemoteSignerError struct {
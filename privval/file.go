@@ -148,9 +148,14 @@ func (lss *FilePVLastSignState) Save() {
 // NOTE: the directories containing pv.Key.filePath and pv.LastSignState.filePath must already exist.
 // It includes the LastSignature and LastSignBytes so we don't lose the signature
 // if the process crashes after signing but before the resulting consensus message is processed.
+//
+// The last sign state is persisted to a LastSignStateStore, by default the
+// state file. LastSignState holds the state last loaded from or saved to it.
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	store LastSignStateStore
 }
 
 // NewFilePV generates a new validator from the given key and paths.
@@ -166,6 +171,10 @@ func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FileP
 			Step:     stepNone,
 			filePath: stateFilePath,
 		},
+		store: newFileLastSignStateStore(FilePVLastSignState{
+			Step:     stepNone,
+			filePath: stateFilePath,
+		}),
 	}
 }
 
@@ -195,16 +204,36 @@ func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
 	return loadFilePV(keyFilePath, stateFilePath, false)
 }
 
-// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
-	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
+// LoadFilePVWithStore loads a FilePV key from keyFilePath and persists its
+// last sign state to the given store instead of a state file. Several signer
+// processes sharing the same key can use a store backed by the same
+// LinearizableKV without double signing.
+func LoadFilePVWithStore(keyFilePath string, store LastSignStateStore) (*FilePV, error) {
+	pvKey, err := loadFilePVKey(keyFilePath)
 	if err != nil {
-		tmos.Exit(err.Error())
+		return nil, err
+	}
+	pvState, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("error loading PrivValidator state: %w", err)
 	}
+
+	return &FilePV{
+		Key:           pvKey,
+		LastSignState: pvState,
+		store:         store,
+	}, nil
+}
+
+func loadFilePVKey(keyFilePath string) (FilePVKey, error) {
 	pvKey := FilePVKey{}
+	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
+	if err != nil {
+		return pvKey, err
+	}
 	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
 	if err != nil {
-		tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+		return pvKey, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
 	}
 
 	// overwrite pubkey and address for convenience
@@ -212,6 +241,16 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
 
+	return pvKey, nil
+}
+
+// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+	pvKey, err := loadFilePVKey(keyFilePath)
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
+
 	pvState := FilePVLastSignState{}
 
 	if loadState {
@@ -230,6 +269,7 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	return &FilePV{
 		Key:           pvKey,
 		LastSignState: pvState,
+		store:         newFileLastSignStateStore(pvState),
 	}
 }
 
@@ -279,10 +319,13 @@ func (pv *FilePV) SignProposal(chainID string, proposal *tmproto.Proposal) error
 	return nil
 }
 
-// Save persists the FilePV to disk.
+// Save persists the FilePV to disk. The last sign state is only saved if it's
+// persisted to the state file, other stores are updated upon signing.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
-	pv.LastSignState.Save()
+	if store, ok := pv.store.(*FileLastSignStateStore); ok {
+		store.overwrite(pv.LastSignState)
+	}
 }
 
 // Reset resets all fields in the FilePV.
@@ -316,7 +359,10 @@ func (pv *FilePV) String() string {
 func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
-	lss := pv.LastSignState
+	lss, err := pv.loadLastSignState()
+	if err != nil {
+		return err
+	}
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -347,7 +393,9 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(lss, height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	vote.Signature = sig
 	return nil
 }
@@ -358,7 +406,10 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
-	lss := pv.LastSignState
+	lss, err := pv.loadLastSignState()
+	if err != nil {
+		return err
+	}
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -389,21 +440,41 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(lss, height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	proposal.Signature = sig
 	return nil
 }
 
-// Persist height/round/step and signature
-func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	signBytes []byte, sig []byte) {
+// loadLastSignState loads the latest state from the store, which may have
+// been updated by another signer sharing it.
+func (pv *FilePV) loadLastSignState() (FilePVLastSignState, error) {
+	lss, err := pv.store.Load()
+	if err != nil {
+		return lss, fmt.Errorf("error loading last sign state: %w", err)
+	}
+	lss.filePath = pv.LastSignState.filePath
+	pv.LastSignState = lss
+	return lss, nil
+}
 
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	pv.LastSignState.Save()
+// Persist height/round/step and signature. The signature must not be used if
+// this fails, since another signer may have signed for the same HRS.
+func (pv *FilePV) saveSigned(prev FilePVLastSignState, height int64, round int32, step int8,
+	signBytes []byte, sig []byte) error {
+
+	lss := prev
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+	if err := pv.store.CompareAndSwap(prev, lss); err != nil {
+		return fmt.Errorf("error saving last sign state: %w", err)
+	}
+	pv.LastSignState = lss
+	return nil
 }
 
 //-----------------------------------------------------------------------------------------
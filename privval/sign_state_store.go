@@ -0,0 +1,227 @@
+package privval
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tempfile"
+)
+
+// LastSignStateStore persists the last sign state of a FilePV.
+//
+// FilePV loads the state before signing anything and swaps in the new state
+// before releasing a signature. If several signer processes share the same
+// key, they must share a store whose CompareAndSwap is linearizable, so at
+// most one of them gets to sign for a given height/round/step.
+type LastSignStateStore interface {
+	// Load returns the most recently persisted state.
+	Load() (FilePVLastSignState, error)
+	// CompareAndSwap persists next, provided the persisted state still equals
+	// prev. Otherwise, it returns ErrLastSignStateConflict.
+	CompareAndSwap(prev, next FilePVLastSignState) error
+}
+
+//-------------------------------------------------------------------------------
+
+// FileLastSignStateStore is a LastSignStateStore backed by a JSON file. It is
+// the default store of FilePV.
+//
+// NOTE: the file is only read upon creation, the store then holds the state
+// in memory. Signer processes must not share the same file.
+type FileLastSignStateStore struct {
+	mtx   tmsync.Mutex
+	state FilePVLastSignState
+}
+
+var _ LastSignStateStore = (*FileLastSignStateStore)(nil)
+
+// NewFileLastSignStateStore returns a store persisting the state to filePath.
+// The initial state is read from filePath, a missing or empty file results in
+// an empty state.
+func NewFileLastSignStateStore(filePath string) (*FileLastSignStateStore, error) {
+	lss := FilePVLastSignState{}
+
+	bz, err := ioutil.ReadFile(filePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	case len(bz) > 0:
+		if err := tmjson.Unmarshal(bz, &lss); err != nil {
+			return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", filePath, err)
+		}
+	}
+
+	lss.filePath = filePath
+	return newFileLastSignStateStore(lss), nil
+}
+
+func newFileLastSignStateStore(lss FilePVLastSignState) *FileLastSignStateStore {
+	return &FileLastSignStateStore{state: lss}
+}
+
+// Load implements LastSignStateStore.
+func (s *FileLastSignStateStore) Load() (FilePVLastSignState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.state, nil
+}
+
+// CompareAndSwap implements LastSignStateStore.
+func (s *FileLastSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.state.equal(prev) {
+		return ErrLastSignStateConflict
+	}
+
+	next.filePath = s.state.filePath
+	jsonBytes, err := tmjson.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(next.filePath, jsonBytes, 0600); err != nil {
+		return err
+	}
+	s.state = next
+	return nil
+}
+
+// overwrite unconditionally persists the given state. It panics if the state
+// can't be saved.
+func (s *FileLastSignStateStore) overwrite(lss FilePVLastSignState) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	lss.filePath = s.state.filePath
+	lss.Save()
+	s.state = lss
+}
+
+//-------------------------------------------------------------------------------
+
+// LinearizableKV is the subset of a linearizable key-value store, such as
+// etcd or any other Raft-replicated store, needed to share the last sign state
+// between several signer processes.
+type LinearizableKV interface {
+	// Get returns the value stored under key along with its revision. A
+	// missing key has a nil value and revision 0.
+	Get(key string) (value []byte, revision int64, err error)
+	// CompareAndSwap stores value under key if the key's current revision is
+	// revision. Otherwise, it returns ErrLastSignStateConflict.
+	CompareAndSwap(key string, revision int64, value []byte) error
+}
+
+// KVLastSignStateStore is a LastSignStateStore backed by a LinearizableKV. It
+// is safe to use by several signer processes sharing the same key.
+type KVLastSignStateStore struct {
+	kv  LinearizableKV
+	key string
+}
+
+var _ LastSignStateStore = (*KVLastSignStateStore)(nil)
+
+// NewKVLastSignStateStore returns a store persisting the state under key in
+// the given key-value store.
+func NewKVLastSignStateStore(kv LinearizableKV, key string) *KVLastSignStateStore {
+	return &KVLastSignStateStore{kv: kv, key: key}
+}
+
+// Load implements LastSignStateStore.
+func (s *KVLastSignStateStore) Load() (FilePVLastSignState, error) {
+	lss, _, err := s.load()
+	return lss, err
+}
+
+// CompareAndSwap implements LastSignStateStore.
+func (s *KVLastSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	current, revision, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !current.equal(prev) {
+		return ErrLastSignStateConflict
+	}
+
+	bz, err := tmjson.Marshal(next)
+	if err != nil {
+		return err
+	}
+	// another signer may have swapped the state since we loaded it, in which
+	// case the revision doesn't match anymore
+	return s.kv.CompareAndSwap(s.key, revision, bz)
+}
+
+func (s *KVLastSignStateStore) load() (FilePVLastSignState, int64, error) {
+	var lss FilePVLastSignState
+
+	bz, revision, err := s.kv.Get(s.key)
+	if err != nil {
+		return lss, 0, err
+	}
+	if bz == nil {
+		return lss, revision, nil
+	}
+
+	if err := tmjson.Unmarshal(bz, &lss); err != nil {
+		return lss, 0, fmt.Errorf("error reading PrivValidator state from key %v: %w", s.key, err)
+	}
+	return lss, revision, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// MemKV is an in-memory LinearizableKV. It is a local stand-in for a
+// replicated key-value store, e.g. for signers running in the same process
+// or for testing.
+type MemKV struct {
+	mtx       tmsync.Mutex
+	values    map[string][]byte
+	revisions map[string]int64
+}
+
+var _ LinearizableKV = (*MemKV)(nil)
+
+// NewMemKV returns an empty MemKV.
+func NewMemKV() *MemKV {
+	return &MemKV{
+		values:    make(map[string][]byte),
+		revisions: make(map[string]int64),
+	}
+}
+
+// Get implements LinearizableKV.
+func (kv *MemKV) Get(key string) ([]byte, int64, error) {
+	kv.mtx.Lock()
+	defer kv.mtx.Unlock()
+	return kv.values[key], kv.revisions[key], nil
+}
+
+// CompareAndSwap implements LinearizableKV.
+func (kv *MemKV) CompareAndSwap(key string, revision int64, value []byte) error {
+	kv.mtx.Lock()
+	defer kv.mtx.Unlock()
+
+	if kv.revisions[key] != revision {
+		return ErrLastSignStateConflict
+	}
+	kv.values[key] = value
+	kv.revisions[key]++
+	return nil
+}
+
+//-------------------------------------------------------------------------------
+
+// equal compares the persisted fields of two states.
+func (lss FilePVLastSignState) equal(other FilePVLastSignState) bool {
+	return lss.Height == other.Height &&
+		lss.Round == other.Round &&
+		lss.Step == other.Step &&
+		bytes.Equal(lss.Signature, other.Signature) &&
+		bytes.Equal(lss.SignBytes, other.SignBytes)
+}
@@ -0,0 +1,135 @@
+package privval
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestLastSignStateStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "priv_validator_state_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	stateFile := filepath.Join(dir, "state.json")
+	fileStore, err := NewFileLastSignStateStore(stateFile)
+	require.NoError(t, err)
+
+	stores := map[string]LastSignStateStore{
+		"file": fileStore,
+		"kv":   NewKVLastSignStateStore(NewMemKV(), "state"),
+	}
+
+	for name, store := range stores {
+		store := store
+		t.Run(name, func(t *testing.T) {
+			empty, err := store.Load()
+			require.NoError(t, err)
+			assert.True(t, empty.equal(FilePVLastSignState{}))
+
+			next := FilePVLastSignState{
+				Height:    1,
+				Round:     2,
+				Step:      stepPrevote,
+				Signature: tmrand.Bytes(64),
+				SignBytes: tmrand.Bytes(32),
+			}
+			require.NoError(t, store.CompareAndSwap(empty, next))
+
+			loaded, err := store.Load()
+			require.NoError(t, err)
+			assert.True(t, loaded.equal(next))
+
+			// the state was swapped, so swapping from the old state must fail
+			other := next
+			other.Height = 2
+			assert.Equal(t, ErrLastSignStateConflict, store.CompareAndSwap(empty, other))
+
+			loaded, err = store.Load()
+			require.NoError(t, err)
+			assert.True(t, loaded.equal(next))
+		})
+	}
+
+	// the file store persisted the state to disk
+	fileStore, err = NewFileLastSignStateStore(stateFile)
+	require.NoError(t, err)
+	loaded, err := fileStore.Load()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, loaded.Height)
+}
+
+func TestFilePVSharedStore(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(tempKeyFile.Name()) })
+
+	privVal, err := GenFilePV(tempKeyFile.Name(), "", "")
+	require.NoError(t, err)
+	privVal.Key.Save()
+
+	// several signers share the same key and store
+	kv := NewMemKV()
+	signers := make([]*FilePV, 10)
+	for i := range signers {
+		signers[i], err = LoadFilePVWithStore(tempKeyFile.Name(), NewKVLastSignStateStore(kv, "pv"))
+		require.NoError(t, err)
+	}
+
+	height, round := int64(10), int32(1)
+	newBlockID := func() types.BlockID {
+		hash := tmrand.Bytes(tmhash.Size)
+		return types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Total: 5, Hash: hash}}
+	}
+
+	// only one of them gets to sign for the same HRS
+	var (
+		wg     sync.WaitGroup
+		mtx    sync.Mutex
+		signed []*tmproto.Vote
+	)
+	for _, signer := range signers {
+		wg.Add(1)
+		go func(signer *FilePV) {
+			defer wg.Done()
+			vote := newVote(privVal.Key.Address, 0, height, round, tmproto.PrevoteType, newBlockID()).ToProto()
+			if err := signer.SignVote("mychainid", vote); err == nil {
+				mtx.Lock()
+				signed = append(signed, vote)
+				mtx.Unlock()
+			}
+		}(signer)
+	}
+	wg.Wait()
+	require.Len(t, signed, 1)
+
+	// the others can re-sign that same vote, but no conflicting one
+	for _, signer := range signers {
+		vote := *signed[0]
+		vote.Signature = nil
+		require.NoError(t, signer.SignVote("mychainid", &vote))
+		assert.Equal(t, signed[0].Signature, vote.Signature)
+
+		conflicting := newVote(privVal.Key.Address, 0, height, round, tmproto.PrevoteType, newBlockID()).ToProto()
+		assert.Error(t, signer.SignVote("mychainid", conflicting))
+	}
+
+	// once one of them moves on to the next step, the others can't go back
+	precommit := newVote(privVal.Key.Address, 0, height, round, tmproto.PrecommitType, newBlockID()).ToProto()
+	require.NoError(t, signers[1].SignVote("mychainid", precommit))
+
+	vote := *signed[0]
+	vote.Signature = nil
+	assert.Error(t, signers[2].SignVote("mychainid", &vote))
+	assert.Equal(t, stepPrecommit, signers[2].LastSignState.Step)
+}
@@ -0,0 +1,76 @@
+package blockchain_test
+
+import (
+	"testing"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestValidateMsg(t *testing.T) {
+	testcases := map[string]struct {
+		msg      proto.Message
+		validMsg bool
+		valid    bool
+	}{
+		"nil":       {nil, false, false},
+		"unrelated": {&tmproto.Block{}, false, false},
+
+		"BlockRequest valid":           {&bcproto.BlockRequest{Height: 1}, true, true},
+		"BlockRequest negative height": {&bcproto.BlockRequest{Height: -1}, true, false},
+
+		"BlockResponse valid":     {&bcproto.BlockResponse{Block: &tmproto.Block{}}, true, true},
+		"BlockResponse nil block": {&bcproto.BlockResponse{}, true, false},
+
+		"NoBlockResponse valid":           {&bcproto.NoBlockResponse{Height: 1}, true, true},
+		"NoBlockResponse negative height": {&bcproto.NoBlockResponse{Height: -1}, true, false},
+
+		"StatusRequest valid": {&bcproto.StatusRequest{}, true, true},
+
+		"StatusResponse valid":           {&bcproto.StatusResponse{Base: 1, Height: 2}, true, true},
+		"StatusResponse negative base":   {&bcproto.StatusResponse{Base: -1, Height: 2}, true, false},
+		"StatusResponse negative height": {&bcproto.StatusResponse{Base: 0, Height: -1}, true, false},
+		"StatusResponse base > height":   {&bcproto.StatusResponse{Base: 3, Height: 2}, true, false},
+	}
+
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			msg := new(bcproto.Message)
+
+			if tc.validMsg {
+				require.NoError(t, msg.Wrap(tc.msg))
+			} else {
+				require.Error(t, msg.Wrap(tc.msg))
+			}
+
+			if tc.valid {
+				require.NoError(t, msg.Validate())
+			} else {
+				require.Error(t, msg.Validate())
+			}
+		})
+	}
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	msgs := []proto.Message{
+		&bcproto.BlockRequest{Height: 1},
+		&bcproto.BlockResponse{Block: &tmproto.Block{}},
+		&bcproto.NoBlockResponse{Height: 1},
+		&bcproto.StatusRequest{},
+		&bcproto.StatusResponse{Base: 1, Height: 2},
+	}
+
+	for _, m := range msgs {
+		msg := new(bcproto.Message)
+		require.NoError(t, msg.Wrap(m))
+
+		unwrapped, err := msg.Unwrap()
+		require.NoError(t, err)
+		require.Equal(t, m, unwrapped)
+	}
+}
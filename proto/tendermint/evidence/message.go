@@ -0,0 +1,71 @@
+package evidence
+
+import (
+	"errors"
+	fmt "fmt"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// Wrap implements the p2p Wrapper interface and wraps an evidence message.
+func (m *Message) Wrap(msg proto.Message) error {
+	switch msg := msg.(type) {
+	case *tmproto.EvidenceList:
+		m.Sum = &Message_EvidenceList{EvidenceList: msg}
+
+	case *EvidenceAck:
+		m.Sum = &Message_EvidenceAck{EvidenceAck: msg}
+
+	default:
+		return fmt.Errorf("unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+// Unwrap implements the p2p Wrapper interface and unwraps a wrapped evidence
+// message.
+func (m *Message) Unwrap() (proto.Message, error) {
+	switch msg := m.Sum.(type) {
+	case *Message_EvidenceList:
+		return m.GetEvidenceList(), nil
+
+	case *Message_EvidenceAck:
+		return m.GetEvidenceAck(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown message: %T", msg)
+	}
+}
+
+// Validate validates the message returning an error upon failure.
+func (m *Message) Validate() error {
+	if m == nil {
+		return errors.New("message cannot be nil")
+	}
+
+	switch msg := m.Sum.(type) {
+	case *Message_EvidenceList:
+		// the evidence is validated by the receiver when converting from proto
+		if len(m.GetEvidenceList().Evidence) == 0 {
+			return errors.New("evidence list cannot be empty")
+		}
+
+	case *Message_EvidenceAck:
+		if len(m.GetEvidenceAck().Hashes) == 0 {
+			return errors.New("evidence ack cannot be empty")
+		}
+		for _, hash := range m.GetEvidenceAck().Hashes {
+			if len(hash) == 0 {
+				return errors.New("evidence hash cannot be empty")
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown message type: %T", msg)
+	}
+
+	return nil
+}
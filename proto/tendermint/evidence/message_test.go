@@ -0,0 +1,78 @@
+package evidence_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	evproto "github.com/tendermint/tendermint/proto/tendermint/evidence"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestValidateMsg(t *testing.T) {
+	testcases := map[string]struct {
+		msg      proto.Message
+		validMsg bool
+		valid    bool
+	}{
+		"nil":       {nil, false, false},
+		"unrelated": {&tmproto.Block{}, false, false},
+
+		"EvidenceList valid": {
+			&tmproto.EvidenceList{Evidence: []tmproto.Evidence{{}}},
+			true,
+			true,
+		},
+		"EvidenceList empty": {&tmproto.EvidenceList{}, true, false},
+
+		"EvidenceAck valid":      {&evproto.EvidenceAck{Hashes: [][]byte{{0x01}}}, true, true},
+		"EvidenceAck empty":      {&evproto.EvidenceAck{}, true, false},
+		"EvidenceAck empty hash": {&evproto.EvidenceAck{Hashes: [][]byte{{0x01}, {}}}, true, false},
+	}
+
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			msg := new(evproto.Message)
+
+			if tc.validMsg {
+				require.NoError(t, msg.Wrap(tc.msg))
+			} else {
+				require.Error(t, msg.Wrap(tc.msg))
+			}
+
+			if tc.valid {
+				require.NoError(t, msg.Validate())
+			} else {
+				require.Error(t, msg.Validate())
+			}
+		})
+	}
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	msgs := []proto.Message{
+		&tmproto.EvidenceList{Evidence: []tmproto.Evidence{{}}},
+		&evproto.EvidenceAck{Hashes: [][]byte{{0x01}}},
+	}
+
+	for _, m := range msgs {
+		msg := new(evproto.Message)
+		require.NoError(t, msg.Wrap(m))
+
+		unwrapped, err := msg.Unwrap()
+		require.NoError(t, err)
+		require.Equal(t, m, unwrapped)
+	}
+}
+
+func TestEvidenceAckVectors(t *testing.T) {
+	msg := new(evproto.Message)
+	require.NoError(t, msg.Wrap(&evproto.EvidenceAck{Hashes: [][]byte{{0x01, 0x02}}}))
+
+	bz, err := msg.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, "12040a020102", hex.EncodeToString(bz))
+}
@@ -22,6 +22,18 @@ func (m *Message) Wrap(msg proto.Message) error {
 	case *SnapshotsResponse:
 		m.Sum = &Message_SnapshotsResponse{SnapshotsResponse: msg}
 
+	case *LightBlockRequest:
+		m.Sum = &Message_LightBlockRequest{LightBlockRequest: msg}
+
+	case *LightBlockResponse:
+		m.Sum = &Message_LightBlockResponse{LightBlockResponse: msg}
+
+	case *ParamsRequest:
+		m.Sum = &Message_ParamsRequest{ParamsRequest: msg}
+
+	case *ParamsResponse:
+		m.Sum = &Message_ParamsResponse{ParamsResponse: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -45,6 +57,18 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_SnapshotsResponse:
 		return m.GetSnapshotsResponse(), nil
 
+	case *Message_LightBlockRequest:
+		return m.GetLightBlockRequest(), nil
+
+	case *Message_LightBlockResponse:
+		return m.GetLightBlockResponse(), nil
+
+	case *Message_ParamsRequest:
+		return m.GetParamsRequest(), nil
+
+	case *Message_ParamsResponse:
+		return m.GetParamsResponse(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -86,6 +110,23 @@ func (m *Message) Validate() error {
 			return errors.New("snapshot has no chunks")
 		}
 
+	case *Message_LightBlockRequest:
+		// A height of 0 requests the latest light block.
+
+	case *Message_LightBlockResponse:
+		// The light block is validated by the receiver, and may be nil if the
+		// peer doesn't have it.
+
+	case *Message_ParamsRequest:
+		if m.GetParamsRequest().Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+
+	case *Message_ParamsResponse:
+		if m.GetParamsResponse().Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+
 	default:
 		return fmt.Errorf("unknown message type: %T", msg)
 	}
@@ -98,6 +98,27 @@ func TestValidateMsg(t *testing.T) {
 			true,
 			false,
 		},
+
+		"LightBlockRequest valid":  {&ssproto.LightBlockRequest{Height: 1}, true, true},
+		"LightBlockRequest latest": {&ssproto.LightBlockRequest{Height: 0}, true, true},
+
+		"LightBlockResponse valid": {
+			&ssproto.LightBlockResponse{LightBlock: &tmproto.LightBlock{}},
+			true,
+			true,
+		},
+		"LightBlockResponse missing": {&ssproto.LightBlockResponse{}, true, true},
+
+		"ParamsRequest valid":    {&ssproto.ParamsRequest{Height: 1}, true, true},
+		"ParamsRequest 0 height": {&ssproto.ParamsRequest{Height: 0}, true, false},
+
+		"ParamsResponse valid": {
+			&ssproto.ParamsResponse{Height: 1, ConsensusParams: &tmproto.ConsensusParams{}},
+			true,
+			true,
+		},
+		"ParamsResponse missing":  {&ssproto.ParamsResponse{Height: 1}, true, true},
+		"ParamsResponse 0 height": {&ssproto.ParamsResponse{Height: 0}, true, false},
 	}
 
 	for name, tc := range testcases {
@@ -161,6 +182,34 @@ func TestStateSyncVectors(t *testing.T) {
 			},
 			"2214080110021803220c697427732061206368756e6b",
 		},
+		{
+			"LightBlockRequest",
+			&ssproto.LightBlockRequest{
+				Height: 100,
+			},
+			"2a020864",
+		},
+		{
+			"LightBlockResponse",
+			&ssproto.LightBlockResponse{
+				LightBlock: nil,
+			},
+			"3200",
+		},
+		{
+			"ParamsRequest",
+			&ssproto.ParamsRequest{
+				Height: 9001,
+			},
+			"3a0308a946",
+		},
+		{
+			"ParamsResponse",
+			&ssproto.ParamsResponse{
+				Height: 9001,
+			},
+			"420308a946",
+		},
 	}
 
 	for _, tc := range testCases {
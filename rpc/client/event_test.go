@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -98,6 +101,159 @@ func TestBlockEvents(t *testing.T) {
 	}
 }
 
+// subscribe to new blocks, resuming from a past event
+func TestBlockEventsSince(t *testing.T) {
+	c := getHTTPClient()
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	const subscriber = "TestBlockEventsSince"
+	query := types.QueryForEvent(types.EventNewBlock).String()
+
+	eventCh, err := c.Subscribe(context.Background(), subscriber, query)
+	require.NoError(t, err)
+	first := <-eventCh
+	second := <-eventCh
+	require.NoError(t, c.UnsubscribeAll(context.Background(), subscriber))
+	require.NotZero(t, first.Seq)
+	require.Greater(t, second.Seq, first.Seq)
+	require.NotEmpty(t, first.Epoch)
+
+	ws, err := jsonrpcclient.NewWS(rpctest.GetConfig().RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, ws.Start())
+	t.Cleanup(func() {
+		if err := ws.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	// a sequence number from another epoch is rejected
+	require.NoError(t, ws.SubscribeSince(context.Background(), query, "previous", first.Seq))
+	select {
+	case resp := <-ws.ResponsesCh:
+		require.NotNil(t, resp.Error)
+		assert.Contains(t, resp.Error.Error(), types.ErrEventsNotInHistory.Error())
+	case <-time.After(waitForEventTimeout):
+		t.Fatal("did not receive the subscribe error")
+	}
+
+	require.NoError(t, ws.SubscribeSince(context.Background(), query, first.Epoch, first.Seq))
+
+	for {
+		select {
+		case resp := <-ws.ResponsesCh:
+			require.Nil(t, resp.Error)
+			result := new(ctypes.ResultEvent)
+			require.NoError(t, tmjson.Unmarshal(resp.Result, result))
+			if result.Query == "" {
+				continue // the result of subscribe
+			}
+			assert.Equal(t, second.Seq, result.Seq)
+			assert.Equal(t, second.Data.(types.EventDataNewBlock).Block.Height,
+				result.Data.(types.EventDataNewBlock).Block.Height)
+			return
+		case <-time.After(waitForEventTimeout):
+			t.Fatal("did not receive the replayed event")
+		}
+	}
+}
+
+// resume a subscription to new blocks with the clients
+func TestClientBlockEventsSince(t *testing.T) {
+	for i, c := range GetClients() {
+		i, c := i, c
+		t.Run(reflect.TypeOf(c).String(), func(t *testing.T) {
+			// start for this test it if it wasn't already running
+			if !c.IsRunning() {
+				// if so, then we start it, listen, and stop it.
+				err := c.Start()
+				require.Nil(t, err, "%d: %+v", i, err)
+				t.Cleanup(func() {
+					if err := c.Stop(); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+
+			const subscriber = "TestClientBlockEventsSince"
+			query := types.QueryForEvent(types.EventNewBlock).String()
+
+			eventCh, err := c.Subscribe(context.Background(), subscriber, query)
+			require.NoError(t, err)
+			first := <-eventCh
+			second := <-eventCh
+			require.NoError(t, c.UnsubscribeAll(context.Background(), subscriber))
+
+			// the events of the previous subscription may still be received
+			eventCh, err = c.SubscribeSince(context.Background(), subscriber, query, first.Epoch, first.Seq, 100)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				if err := c.UnsubscribeAll(context.Background(), subscriber); err != nil {
+					t.Error(err)
+				}
+			})
+			for {
+				select {
+				case event := <-eventCh:
+					if event.Seq == second.Seq {
+						return
+					}
+				case <-time.After(waitForEventTimeout):
+					t.Fatal("did not receive the replayed event")
+				}
+			}
+		})
+	}
+}
+
+// poll new blocks and make sure height increments by 1
+func TestBlockEventsPolling(t *testing.T) {
+	for _, c := range GetClients() {
+		c := c
+		t.Run(reflect.TypeOf(c).String(), func(t *testing.T) {
+			query := types.QueryForEvent(types.EventNewBlock).String()
+
+			var (
+				epoch  string
+				after  uint64
+				events []*ctypes.ResultEvent
+			)
+			for len(events) < 3 {
+				res, err := c.Events(context.Background(), query, epoch, after, waitForEventTimeout)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.Events)
+				require.GreaterOrEqual(t, res.Cursor, res.Events[len(res.Events)-1].Seq)
+				require.Greater(t, res.Events[0].Seq, after)
+				epoch, after = res.Epoch, res.Cursor
+				events = append(events, res.Events...)
+			}
+
+			var firstBlockHeight int64
+			for i, event := range events {
+				blockEvent, ok := event.Data.(types.EventDataNewBlock)
+				require.True(t, ok)
+				if firstBlockHeight == 0 {
+					firstBlockHeight = blockEvent.Block.Header.Height
+				}
+				require.Equal(t, firstBlockHeight+int64(i), blockEvent.Block.Header.Height)
+			}
+
+			// without waiting, the call returns right away
+			res, err := c.Events(context.Background(), query, epoch, after, 0)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, res.Cursor, after)
+
+			// a cursor from another epoch is rejected
+			_, err = c.Events(context.Background(), query, "previous", after, 0)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestTxEventsSentWithBroadcastTxAsync(t *testing.T) { testTxEventsSent(t, "async") }
 func TestTxEventsSentWithBroadcastTxSync(t *testing.T)  { testTxEventsSent(t, "sync") }
 
@@ -13,10 +13,12 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
@@ -150,6 +152,28 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		require.EqualValues(t, rawpub, pk, "Stored PubKey not equal with expected, value %v", string(qres.Value))
 		require.Equal(t, int64(9), v.Power, "Stored Power not equal with expected, value %v", string(qres.Value))
 
+		pending, err := c.PendingEvidence(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, pending.Total)
+
+		committed, err := c.CommittedEvidence(context.Background(), nil, pv.Key.Address, nil, nil)
+		require.NoError(t, err)
+		var info *ctypes.EvidenceInfo
+		for j := range committed.Evidence {
+			if bytes.Equal(committed.Evidence[j].Hash, correct.Hash()) {
+				info = &committed.Evidence[j]
+			}
+		}
+		require.NotNil(t, info, "evidence %X was not committed", correct.Hash())
+		assert.Equal(t, "DUPLICATE_VOTE", info.Type)
+		assert.Equal(t, correct.Height(), info.Height)
+		assert.Equal(t, []tmbytes.HexBytes{tmbytes.HexBytes(pv.Key.Address)}, info.Validators)
+
+		committed, err = c.CommittedEvidence(context.Background(), &info.CommitHeight, nil, nil, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, committed.Evidence)
+		assert.EqualValues(t, correct.Hash(), committed.Evidence[0].Hash)
+
 		for _, fake := range fakes {
 			_, err := c.BroadcastEvidence(context.Background(), fake)
 			require.Error(t, err, "BroadcastEvidence(%s) succeeded, but the evidence was fake", fake)
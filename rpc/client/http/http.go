@@ -31,7 +31,9 @@ You can subscribe for any event published by Tendermint using Subscribe method.
 Note delivery is best-effort. If you don't read events fast enough or network is
 slow, Tendermint might cancel the subscription. The client will attempt to
 resubscribe (you don't need to do anything). It will keep trying every second
-indefinitely until successful.
+indefinitely until successful. When resubscribing, the events published since
+the last one received are replayed, provided they're still in the history of
+the node (see the event-history-size config option).
 
 Request batching is available for JSON RPC requests over HTTP, which conforms to
 the JSON RPC specification (https://www.jsonrpc.org/specification#batch). See
@@ -66,9 +68,10 @@ type HTTP struct {
 	*WSEvents
 }
 
-// BatchHTTP provides the same interface as `HTTP`, but allows for batching of
-// requests (as per https://www.jsonrpc.org/specification#batch). Do not
-// instantiate directly - rather use the HTTP.NewBatch() method to create an
+// BatchHTTP provides the same interface as `HTTP` (except for events), but
+// allows for batching of requests (as per
+// https://www.jsonrpc.org/specification#batch). Do not instantiate directly -
+// rather use the HTTP.NewBatch() or HTTP.NewWSBatch() methods to create an
 // instance of this struct.
 //
 // Batching of HTTP requests is thread-safe in the sense that multiple
@@ -90,6 +93,9 @@ type rpcClient interface {
 	rpcclient.NetworkClient
 	rpcclient.SignClient
 	rpcclient.StatusClient
+	rpcclient.EvidenceClient
+	rpcclient.MempoolClient
+	rpcclient.StateSyncClient
 }
 
 // baseRPCClient implements the basic RPC method logic without the actual
@@ -177,13 +183,26 @@ func (c *HTTP) NewBatch() *BatchHTTP {
 	}
 }
 
+// NewWSBatch creates a new batch client for this HTTP client, which sends the
+// batch over the WebSocket connection. The client must be started (see Start).
+func (c *HTTP) NewWSBatch() *BatchHTTP {
+	rpcBatch := c.WSEvents.ws.NewRequestBatch()
+	return &BatchHTTP{
+		rpcBatch: rpcBatch,
+		baseRPCClient: &baseRPCClient{
+			caller: rpcBatch,
+		},
+	}
+}
+
 //-----------------------------------------------------------------------------
 // BatchHTTP
 
 // Send is a convenience function for an HTTP batch that will trigger the
 // compilation of the batched requests and send them off using the client as a
 // single request. On success, this returns a list of the deserialized results
-// from each request in the sent batch.
+// from each request in the sent batch, in the same order. The result of a
+// failed request is its *jsonrpctypes.RPCError.
 func (b *BatchHTTP) Send(ctx context.Context) ([]interface{}, error) {
 	return b.rpcBatch.Send(ctx)
 }
@@ -449,6 +468,19 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	return result, nil
 }
 
+func (c *baseRPCClient) CommitSigners(ctx context.Context, height *int64) (*ctypes.ResultCommitSigners, error) {
+	result := new(ctypes.ResultCommitSigners)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "commit_signers", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -526,12 +558,103 @@ func (c *baseRPCClient) BroadcastEvidence(
 	return result, nil
 }
 
+func (c *baseRPCClient) PendingEvidence(
+	ctx context.Context,
+	page,
+	perPage *int,
+) (*ctypes.ResultPendingEvidence, error) {
+	result := new(ctypes.ResultPendingEvidence)
+	params := make(map[string]interface{})
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "pending_evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) CommittedEvidence(
+	ctx context.Context,
+	height *int64,
+	address []byte,
+	page,
+	perPage *int,
+) (*ctypes.ResultCommittedEvidence, error) {
+	result := new(ctypes.ResultCommittedEvidence)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	if len(address) > 0 {
+		params["address"] = address
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "committed_evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	result := new(ctypes.ResultSnapshots)
+	_, err := c.caller.Call(ctx, "snapshots", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SnapshotChunk(
+	ctx context.Context,
+	height uint64,
+	format, index uint32,
+) (*ctypes.ResultSnapshotChunk, error) {
+	result := new(ctypes.ResultSnapshotChunk)
+	params := map[string]interface{}{"height": height, "format": format, "index": index}
+	_, err := c.caller.Call(ctx, "snapshot_chunk", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Events polls the events matching the query over HTTP, as an alternative to
+// Subscribe. Note the timeout of the HTTP client, if any, must be greater than
+// waitTime.
+func (c *baseRPCClient) Events(
+	ctx context.Context,
+	query string,
+	epoch string,
+	after uint64,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	result := new(ctypes.ResultEvents)
+	params := map[string]interface{}{"query": query, "epoch": epoch, "after": after, "wait_time": waitTime}
+	_, err := c.caller.Call(ctx, "events", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 //-----------------------------------------------------------------------------
 // WSEvents
 
 var errNotRunning = errors.New("client is not running. Use .Start() method to start")
 
-// WSEvents is a wrapper around WSClient, which implements EventsClient.
+// WSEvents is a wrapper around WSClient, which implements the subscriptions of
+// EventsClient.
 type WSEvents struct {
 	service.BaseService
 	remote   string
@@ -539,14 +662,20 @@ type WSEvents struct {
 	ws       *jsonrpcclient.WSClient
 
 	mtx           tmsync.RWMutex
-	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	subscriptions map[string]*wsSubscription // query -> subscription
+}
+
+type wsSubscription struct {
+	out   chan ctypes.ResultEvent
+	seq   uint64 // sequence number of the last event, to resume from
+	epoch string // epoch of the last event
 }
 
 func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 	w := &WSEvents{
 		endpoint:      endpoint,
 		remote:        remote,
-		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		subscriptions: make(map[string]*wsSubscription),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
@@ -590,27 +719,52 @@ func (w *WSEvents) OnStop() {
 // It returns an error if WSEvents is not running.
 func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	return w.subscribe(ctx, query, "", 0, outCapacity...)
+}
+
+// SubscribeSince implements EventsClient by using WSClient to subscribe given
+// subscriber to query, replaying first the events published after the event
+// with the given epoch and sequence number.
+//
+// It returns an error if WSEvents is not running.
+func (w *WSEvents) SubscribeSince(ctx context.Context, subscriber, query, epoch string, since uint64,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	return w.subscribe(ctx, query, epoch, since, outCapacity...)
+}
+
+func (w *WSEvents) subscribe(ctx context.Context, query, epoch string, since uint64,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 
 	if !w.IsRunning() {
 		return nil, errNotRunning
 	}
 
-	if err := w.ws.Subscribe(ctx, query); err != nil {
-		return nil, err
-	}
-
 	outCap := 1
 	if len(outCapacity) > 0 {
 		outCap = outCapacity[0]
 	}
 
+	// register the subscription first, as the replayed events may be received
+	// right away
 	outc := make(chan ctypes.ResultEvent, outCap)
 	w.mtx.Lock()
 	// subscriber param is ignored because Tendermint will override it with
 	// remote IP anyway.
-	w.subscriptions[query] = outc
+	w.subscriptions[query] = &wsSubscription{out: outc, seq: since, epoch: epoch}
 	w.mtx.Unlock()
 
+	if since > 0 {
+		err = w.ws.SubscribeSince(ctx, query, epoch, since)
+	} else {
+		err = w.ws.Subscribe(ctx, query)
+	}
+	if err != nil {
+		w.mtx.Lock()
+		delete(w.subscriptions, query)
+		w.mtx.Unlock()
+		return nil, err
+	}
+
 	return outc, nil
 }
 
@@ -651,31 +805,54 @@ func (w *WSEvents) UnsubscribeAll(ctx context.Context, subscriber string) error
 	}
 
 	w.mtx.Lock()
-	w.subscriptions = make(map[string]chan ctypes.ResultEvent)
+	w.subscriptions = make(map[string]*wsSubscription)
 	w.mtx.Unlock()
 
 	return nil
 }
 
 // After being reconnected, it is necessary to redo subscription to server
-// otherwise no data will be automatically received.
+// otherwise no data will be automatically received. The subscriptions resume
+// from the last event received.
 func (w *WSEvents) redoSubscriptionsAfter(d time.Duration) {
 	time.Sleep(d)
 
 	w.mtx.RLock()
 	defer w.mtx.RUnlock()
-	for q := range w.subscriptions {
-		err := w.ws.Subscribe(context.Background(), q)
+	for q, sub := range w.subscriptions {
+		var err error
+		if sub.seq > 0 {
+			err = w.ws.SubscribeSince(context.Background(), q, sub.epoch, sub.seq)
+		} else {
+			err = w.ws.Subscribe(context.Background(), q)
+		}
 		if err != nil {
 			w.Logger.Error("Failed to resubscribe", "err", err)
 		}
 	}
 }
 
+// resetSubscriptions makes the subscriptions resume from the next event, when
+// the events since the last one received can't be replayed.
+func (w *WSEvents) resetSubscriptions() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for q, sub := range w.subscriptions {
+		if sub.seq > 0 {
+			w.Logger.Error("Events might have been missed", "query", q, "since", sub.seq)
+			sub.seq = 0
+		}
+	}
+}
+
 func isErrAlreadySubscribed(err error) bool {
 	return strings.Contains(err.Error(), tmpubsub.ErrAlreadySubscribed.Error())
 }
 
+func isErrEventsNotInHistory(err error) bool {
+	return strings.Contains(err.Error(), types.ErrEventsNotInHistory.Error())
+}
+
 func (w *WSEvents) eventListener() {
 	for {
 		select {
@@ -687,10 +864,13 @@ func (w *WSEvents) eventListener() {
 			if resp.Error != nil {
 				w.Logger.Error("WS error", "err", resp.Error.Error())
 				// Error can be ErrAlreadySubscribed or max client (subscriptions per
-				// client) reached or Tendermint exited.
+				// client) reached or Tendermint exited or ErrEventsNotInHistory.
 				// We can ignore ErrAlreadySubscribed, but need to retry in other
 				// cases.
-				if !isErrAlreadySubscribed(resp.Error) {
+				if isErrEventsNotInHistory(resp.Error) {
+					w.resetSubscriptions()
+					w.redoSubscriptionsAfter(0 * time.Second)
+				} else if !isErrAlreadySubscribed(resp.Error) {
 					// Resubscribe after 1 second to give Tendermint time to restart (if
 					// crashed).
 					w.redoSubscriptionsAfter(1 * time.Second)
@@ -705,19 +885,23 @@ func (w *WSEvents) eventListener() {
 				continue
 			}
 
-			w.mtx.RLock()
-			if out, ok := w.subscriptions[result.Query]; ok {
-				if cap(out) == 0 {
-					out <- *result
+			w.mtx.Lock()
+			sub, ok := w.subscriptions[result.Query]
+			if ok && result.Seq > 0 {
+				sub.seq, sub.epoch = result.Seq, result.Epoch
+			}
+			w.mtx.Unlock()
+			if ok {
+				if cap(sub.out) == 0 {
+					sub.out <- *result
 				} else {
 					select {
-					case out <- *result:
+					case sub.out <- *result:
 					default:
 						w.Logger.Error("wanted to publish ResultEvent, but out channel is full", "result", result, "query", result.Query)
 					}
 				}
 			}
-			w.mtx.RUnlock()
 		case <-w.Quit():
 			return
 		}
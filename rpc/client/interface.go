@@ -22,6 +22,7 @@ implementation.
 
 import (
 	"context"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/service"
@@ -41,6 +42,7 @@ type Client interface {
 	StatusClient
 	EvidenceClient
 	MempoolClient
+	StateSyncClient
 }
 
 // ABCIClient groups together the functionality that principally affects the
@@ -68,6 +70,7 @@ type SignClient interface {
 	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	CommitSigners(ctx context.Context, height *int64) (*ctypes.ResultCommitSigners, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int,
@@ -106,10 +109,24 @@ type EventsClient interface {
 	// ctx cannot be used to unsubscribe. To unsubscribe, use either Unsubscribe
 	// or UnsubscribeAll.
 	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error)
+	// SubscribeSince does the same as Subscribe, and also replays first the
+	// events matching query published after the event with the given epoch and
+	// sequence number, provided they're still in the history of the node.
+	SubscribeSince(
+		ctx context.Context,
+		subscriber, query, epoch string,
+		since uint64,
+		outCapacity ...int,
+	) (out <-chan ctypes.ResultEvent, err error)
 	// Unsubscribe unsubscribes given subscriber from query.
 	Unsubscribe(ctx context.Context, subscriber, query string) error
 	// UnsubscribeAll unsubscribes given subscriber from all the queries.
 	UnsubscribeAll(ctx context.Context, subscriber string) error
+	// Events returns the events matching query published after the event with
+	// the given epoch and sequence number (after, 0 for the events published
+	// from now on), waiting up to waitTime for new ones. Unlike Subscribe, it
+	// doesn't need a WebSocket connection.
+	Events(ctx context.Context, query, epoch string, after uint64, waitTime time.Duration) (*ctypes.ResultEvents, error)
 }
 
 // MempoolClient shows us data about current mempool state.
@@ -123,6 +140,16 @@ type MempoolClient interface {
 // behaviour.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	PendingEvidence(ctx context.Context, page, perPage *int) (*ctypes.ResultPendingEvidence, error)
+	CommittedEvidence(ctx context.Context, height *int64, address []byte,
+		page, perPage *int) (*ctypes.ResultCommittedEvidence, error)
+}
+
+// StateSyncClient lists the application snapshots and fetches their chunks,
+// e.g. to state sync a node over RPC.
+type StateSyncClient interface {
+	Snapshots(context.Context) (*ctypes.ResultSnapshots, error)
+	SnapshotChunk(ctx context.Context, height uint64, format, index uint32) (*ctypes.ResultSnapshotChunk, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
@@ -169,6 +169,10 @@ func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 	return core.Commit(c.ctx, height)
 }
 
+func (c *Local) CommitSigners(ctx context.Context, height *int64) (*ctypes.ResultCommitSigners, error) {
+	return core.CommitSigners(c.ctx, height)
+}
+
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return core.Validators(c.ctx, height, page, perPage)
 }
@@ -192,6 +196,31 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctyp
 	return core.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) PendingEvidence(ctx context.Context, page, perPage *int) (*ctypes.ResultPendingEvidence, error) {
+	return core.PendingEvidence(c.ctx, page, perPage)
+}
+
+func (c *Local) CommittedEvidence(
+	ctx context.Context,
+	height *int64,
+	address []byte,
+	page, perPage *int,
+) (*ctypes.ResultCommittedEvidence, error) {
+	return core.CommittedEvidence(c.ctx, height, address, page, perPage)
+}
+
+func (c *Local) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return core.Snapshots(c.ctx)
+}
+
+func (c *Local) SnapshotChunk(
+	ctx context.Context,
+	height uint64,
+	format, index uint32,
+) (*ctypes.ResultSnapshotChunk, error) {
+	return core.SnapshotChunk(c.ctx, height, format, index)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
@@ -218,20 +247,70 @@ func (c *Local) Subscribe(
 	}
 
 	outc := make(chan ctypes.ResultEvent, outCap)
-	go c.eventsRoutine(sub, subscriber, q, outc)
+	go c.eventsRoutine(sub, subscriber, q, 0, outc)
+
+	return outc, nil
+}
+
+// SubscribeSince does the same as Subscribe, and also replays first the events
+// matching query published after the event with the given epoch and sequence
+// number.
+func (c *Local) SubscribeSince(
+	ctx context.Context,
+	subscriber,
+	query,
+	epoch string,
+	since uint64,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+	subCap := outCap
+	if subCap < 1 {
+		subCap = 1
+	}
+
+	sub, missed, last, err := c.EventBus.SubscribeSince(ctx, subscriber, q, epoch, since, subCap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	outc := make(chan ctypes.ResultEvent, outCap)
+	go func() {
+		for _, msg := range missed {
+			select {
+			case outc <- c.resultEvent(q, msg):
+			case <-c.Quit():
+				return
+			}
+		}
+		c.eventsRoutine(sub, subscriber, q, last, outc)
+	}()
 
 	return outc, nil
 }
 
+// eventsRoutine forwards the events of the subscription, skipping the ones up
+// to the given sequence number (already replayed).
 func (c *Local) eventsRoutine(
 	sub types.Subscription,
 	subscriber string,
 	q tmpubsub.Query,
+	skip uint64,
 	outc chan<- ctypes.ResultEvent) {
 	for {
 		select {
 		case msg := <-sub.Out():
-			result := ctypes.ResultEvent{Query: q.String(), Data: msg.Data(), Events: msg.Events()}
+			if types.EventSeq(msg.Events()) <= skip {
+				continue
+			}
+			result := c.resultEvent(q, msg)
 			if cap(outc) == 0 {
 				outc <- result
 			} else {
@@ -257,6 +336,16 @@ func (c *Local) eventsRoutine(
 	}
 }
 
+func (c *Local) resultEvent(q tmpubsub.Query, msg tmpubsub.Message) ctypes.ResultEvent {
+	return ctypes.ResultEvent{
+		Query:  q.String(),
+		Data:   msg.Data(),
+		Events: msg.Events(),
+		Seq:    types.EventSeq(msg.Events()),
+		Epoch:  c.EventBus.Epoch(),
+	}
+}
+
 // Try to resubscribe with exponential backoff.
 func (c *Local) resubscribe(subscriber string, q tmpubsub.Query) types.Subscription {
 	attempts := 0
@@ -286,3 +375,13 @@ func (c *Local) Unsubscribe(ctx context.Context, subscriber, query string) error
 func (c *Local) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return c.EventBus.UnsubscribeAll(ctx, subscriber)
 }
+
+func (c *Local) Events(
+	ctx context.Context,
+	query string,
+	epoch string,
+	after uint64,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	return core.Events(c.ctx, query, epoch, after, waitTime)
+}
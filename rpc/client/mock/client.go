@@ -40,6 +40,7 @@ type Client struct {
 	client.EventsClient
 	client.EvidenceClient
 	client.MempoolClient
+	client.StateSyncClient
 	service.Service
 }
 
@@ -170,6 +171,10 @@ func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 	return core.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) CommitSigners(ctx context.Context, height *int64) (*ctypes.ResultCommitSigners, error) {
+	return core.CommitSigners(&rpctypes.Context{}, height)
+}
+
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height, page, perPage)
 }
@@ -14,6 +14,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/tendermint/tendermint/types"
 )
 
@@ -321,6 +323,52 @@ func (_m *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultC
 	return r0, r1
 }
 
+// CommitSigners provides a mock function with given fields: ctx, height
+func (_m *Client) CommitSigners(ctx context.Context, height *int64) (*coretypes.ResultCommitSigners, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultCommitSigners
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultCommitSigners); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultCommitSigners)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommittedEvidence provides a mock function with given fields: ctx, height, address, page, perPage
+func (_m *Client) CommittedEvidence(ctx context.Context, height *int64, address []byte, page *int, perPage *int) (*coretypes.ResultCommittedEvidence, error) {
+	ret := _m.Called(ctx, height, address, page, perPage)
+
+	var r0 *coretypes.ResultCommittedEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, []byte, *int, *int) *coretypes.ResultCommittedEvidence); ok {
+		r0 = rf(ctx, height, address, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultCommittedEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, []byte, *int, *int) error); ok {
+		r1 = rf(ctx, height, address, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusParams provides a mock function with given fields: ctx, height
 func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	ret := _m.Called(ctx, height)
@@ -390,6 +438,29 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// Events provides a mock function with given fields: ctx, query, epoch, after, waitTime
+func (_m *Client) Events(ctx context.Context, query string, epoch string, after uint64, waitTime time.Duration) (*coretypes.ResultEvents, error) {
+	ret := _m.Called(ctx, query, epoch, after, waitTime)
+
+	var r0 *coretypes.ResultEvents
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64, time.Duration) *coretypes.ResultEvents); ok {
+		r0 = rf(ctx, query, epoch, after, waitTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64, time.Duration) error); ok {
+		r1 = rf(ctx, query, epoch, after, waitTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -529,6 +600,29 @@ func (_m *Client) OnStop() {
 	_m.Called()
 }
 
+// PendingEvidence provides a mock function with given fields: ctx, page, perPage
+func (_m *Client) PendingEvidence(ctx context.Context, page *int, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	ret := _m.Called(ctx, page, perPage)
+
+	var r0 *coretypes.ResultPendingEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *int) *coretypes.ResultPendingEvidence); ok {
+		r0 = rf(ctx, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultPendingEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *int) error); ok {
+		r1 = rf(ctx, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Quit provides a mock function with given fields:
 func (_m *Client) Quit() <-chan struct{} {
 	ret := _m.Called()
@@ -564,6 +658,52 @@ func (_m *Client) SetLogger(_a0 log.Logger) {
 	_m.Called(_a0)
 }
 
+// SnapshotChunk provides a mock function with given fields: ctx, height, format, index
+func (_m *Client) SnapshotChunk(ctx context.Context, height uint64, format uint32, index uint32) (*coretypes.ResultSnapshotChunk, error) {
+	ret := _m.Called(ctx, height, format, index)
+
+	var r0 *coretypes.ResultSnapshotChunk
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint32, uint32) *coretypes.ResultSnapshotChunk); ok {
+		r0 = rf(ctx, height, format, index)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSnapshotChunk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint32, uint32) error); ok {
+		r1 = rf(ctx, height, format, index)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshots provides a mock function with given fields: _a0
+func (_m *Client) Snapshots(_a0 context.Context) (*coretypes.ResultSnapshots, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultSnapshots
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultSnapshots); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields:
 func (_m *Client) Start() error {
 	ret := _m.Called()
@@ -659,6 +799,36 @@ func (_m *Client) Subscribe(ctx context.Context, subscriber string, query string
 	return r0, r1
 }
 
+// SubscribeSince provides a mock function with given fields: ctx, subscriber, query, epoch, since, outCapacity
+func (_m *Client) SubscribeSince(ctx context.Context, subscriber string, query string, epoch string, since uint64, outCapacity ...int) (<-chan coretypes.ResultEvent, error) {
+	_va := make([]interface{}, len(outCapacity))
+	for _i := range outCapacity {
+		_va[_i] = outCapacity[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, subscriber, query, epoch, since)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan coretypes.ResultEvent
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, uint64, ...int) <-chan coretypes.ResultEvent); ok {
+		r0 = rf(ctx, subscriber, query, epoch, since, outCapacity...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan coretypes.ResultEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, uint64, ...int) error); ok {
+		r1 = rf(ctx, subscriber, query, epoch, since, outCapacity...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Tx provides a mock function with given fields: ctx, hash, prove
 func (_m *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.ResultTx, error) {
 	ret := _m.Called(ctx, hash, prove)
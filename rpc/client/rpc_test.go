@@ -22,6 +22,7 @@ import (
 	rpclocal "github.com/tendermint/tendermint/rpc/client/local"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	jsonrpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
@@ -633,6 +634,18 @@ func TestTxSearch(t *testing.T) {
 	}
 }
 
+func TestSnapshots(t *testing.T) {
+	for i, c := range GetClients() {
+		// the kvstore app doesn't take snapshots
+		res, err := c.Snapshots(context.Background())
+		require.NoError(t, err, "%d: %+v", i, err)
+		assert.Empty(t, res.Snapshots)
+
+		_, err = c.SnapshotChunk(context.Background(), 1, 1, 0)
+		assert.Error(t, err)
+	}
+}
+
 func TestBatchedJSONRPCCalls(t *testing.T) {
 	c := getHTTPClient()
 	testBatchedJSONRPCCalls(t, c)
@@ -687,6 +700,52 @@ func testBatchedJSONRPCCalls(t *testing.T, c *rpchttp.HTTP) {
 	require.Equal(t, qresult2.Response.Value, v2)
 }
 
+func TestBatchedJSONRPCCallsWS(t *testing.T) {
+	c := getHTTPClient()
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	batch := c.NewWSBatch()
+	_, err := batch.Status(ctx)
+	require.NoError(t, err)
+	height := int64(math.MaxInt64)
+	_, err = batch.Block(ctx, &height)
+	require.NoError(t, err)
+	_, err = batch.ABCIInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, batch.Count())
+
+	results, err := batch.Send(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, 0, batch.Count())
+
+	status, ok := results[0].(*ctypes.ResultStatus)
+	require.True(t, ok)
+	assert.Equal(t, rpctest.GetConfig().ChainID(), status.NodeInfo.Network)
+	_, ok = results[1].(*jsonrpctypes.RPCError)
+	assert.True(t, ok, "expected an error for a block in the future, got %T", results[1])
+	_, ok = results[2].(*ctypes.ResultABCIInfo)
+	assert.True(t, ok)
+
+	// the events are still delivered along with the batches
+	_, err = c.Subscribe(ctx, "TestBatchedJSONRPCCallsWS", types.EventQueryNewBlock.String())
+	require.NoError(t, err)
+	_, err = batch.Health(ctx)
+	require.NoError(t, err)
+	results, err = batch.Send(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NoError(t, c.UnsubscribeAll(ctx, "TestBatchedJSONRPCCallsWS"))
+
+	_, err = batch.Send(ctx)
+	require.Error(t, err, "sending an empty batch of JSON RPC requests should result in an error")
+}
+
 func TestBatchedJSONRPCCallsCancellation(t *testing.T) {
 	c := getHTTPClient()
 	_, _, tx1 := MakeTxKV()
@@ -113,7 +113,7 @@ func BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error
 // If no height is provided, it will fetch the commit for the latest block.
 // More: https://docs.tendermint.com/master/rpc/#/Info/commit
 func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, error) {
-	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	height, err := getHeaderHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +136,38 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 	return ctypes.NewResultCommit(&header, commit, true), nil
 }
 
+// CommitSigners gets the signed header at the given height, along with the
+// validators which signed its commit, each with a Merkle proof against the
+// validators hash of the header. If no height is provided, it will fetch the
+// commit for the latest block.
+//
+// It allows light clients to check a header can be trusted without fetching
+// the whole validator set.
+// More: https://docs.tendermint.com/master/rpc/#/Info/commit_signers
+func CommitSigners(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommitSigners, error) {
+	res, err := Commit(ctx, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+
+	vals, err := env.StateStore.LoadValidators(res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	hlb, err := types.NewHeaderOnlyLightBlock(&res.SignedHeader, vals)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultCommitSigners{
+		HeaderOnlyLightBlock: *hlb,
+		CanonicalCommit:      res.CanonicalCommit,
+	}, nil
+}
+
 // BlockResults gets ABCIResults at a given height.
 // If no height is provided, it will fetch results for the latest block.
 //
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -115,19 +116,55 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestCommitBackfilled(t *testing.T) {
+	// headers and commits below the base height were backfilled by state sync
+	backfilled := &types.BlockMeta{Header: types.Header{ChainID: "chain", Height: 5}}
+	commit := &types.Commit{Height: 5}
+	env = &Environment{}
+	env.BlockStore = mockBlockStore{
+		base:    10,
+		height:  20,
+		metas:   map[int64]*types.BlockMeta{5: backfilled},
+		commits: map[int64]*types.Commit{5: commit},
+	}
+
+	res, err := Commit(&rpctypes.Context{}, &backfilled.Header.Height)
+	require.NoError(t, err)
+	assert.Equal(t, &backfilled.Header, res.Header)
+	assert.Equal(t, commit, res.Commit)
+	assert.True(t, res.CanonicalCommit)
+
+	// heights which were not backfilled remain unavailable
+	height := int64(4)
+	_, err = Commit(&rpctypes.Context{}, &height)
+	assert.True(t, errors.Is(err, ErrHeightNotAvailable))
+
+	// and so do the blocks themselves
+	_, err = Block(&rpctypes.Context{}, &backfilled.Header.Height)
+	assert.True(t, errors.Is(err, ErrHeightNotAvailable))
+}
+
 type mockBlockStore struct {
-	height int64
+	base    int64
+	height  int64
+	metas   map[int64]*types.BlockMeta
+	commits map[int64]*types.Commit
 }
 
-func (mockBlockStore) Base() int64                                       { return 1 }
+func (store mockBlockStore) Base() int64 {
+	if store.base == 0 {
+		return 1
+	}
+	return store.base
+}
 func (store mockBlockStore) Height() int64                               { return store.height }
 func (store mockBlockStore) Size() int64                                 { return store.height }
 func (mockBlockStore) LoadBaseMeta() *types.BlockMeta                    { return nil }
-func (mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta       { return nil }
+func (store mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta { return store.metas[height] }
 func (mockBlockStore) LoadBlock(height int64) *types.Block               { return nil }
 func (mockBlockStore) LoadBlockByHash(hash []byte) *types.Block          { return nil }
 func (mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
-func (mockBlockStore) LoadBlockCommit(height int64) *types.Commit        { return nil }
+func (store mockBlockStore) LoadBlockCommit(height int64) *types.Commit  { return store.commits[height] }
 func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { return nil }
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
@@ -17,7 +17,7 @@ import (
 // More: https://docs.tendermint.com/master/rpc/#/Info/validators
 func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
 	// The latest validator that we know is the NextValidator of the last block.
-	height, err := getHeight(latestUncommittedHeight(), heightPtr)
+	height, err := getHeaderHeight(latestUncommittedHeight(), heightPtr)
 	if err != nil {
 		return nil, err
 	}
@@ -1,12 +1,14 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
@@ -29,6 +31,10 @@ const (
 var (
 	// set by Node
 	env *Environment
+
+	// ErrHeightNotAvailable is returned (wrapped) when the requested height is
+	// above the latest height or below the lowest height of the block store.
+	ErrHeightNotAvailable = errors.New("height not available")
 )
 
 // SetEnvironment sets up the given Environment.
@@ -46,6 +52,7 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetValidatorSigningInfo() types.ValidatorSigningInfo
 }
 
 type transport interface {
@@ -54,6 +61,11 @@ type transport interface {
 	NodeInfo() p2p.NodeInfo
 }
 
+type evidencePool interface {
+	sm.EvidencePool
+	CommittedEvidenceByValidator(address []byte) ([]evidence.CommittedEvidence, error)
+}
+
 type peers interface {
 	AddPersistentPeers([]string) error
 	AddUnconditionalPeerIDs([]string) error
@@ -67,13 +79,14 @@ type peers interface {
 // to be setup once during startup.
 type Environment struct {
 	// external, thread safe interfaces
-	ProxyAppQuery   proxy.AppConnQuery
-	ProxyAppMempool proxy.AppConnMempool
+	ProxyAppQuery    proxy.AppConnQuery
+	ProxyAppMempool  proxy.AppConnMempool
+	ProxyAppSnapshot proxy.AppConnSnapshot
 
 	// interfaces defined in types and above
 	StateStore     sm.Store
 	BlockStore     sm.BlockStore
-	EvidencePool   sm.EvidencePool
+	EvidencePool   evidencePool
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
@@ -145,19 +158,31 @@ func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 			return 0, fmt.Errorf("height must be greater than 0, but got %d", height)
 		}
 		if height > latestHeight {
-			return 0, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d",
-				height, latestHeight)
+			return 0, fmt.Errorf("%w: height %d must be less than or equal to the current blockchain height %d",
+				ErrHeightNotAvailable, height, latestHeight)
 		}
 		base := env.BlockStore.Base()
 		if height < base {
-			return 0, fmt.Errorf("height %v is not available, lowest height is %v",
-				height, base)
+			return 0, fmt.Errorf("%w: height %v is not available, lowest height is %v",
+				ErrHeightNotAvailable, height, base)
 		}
 		return height, nil
 	}
 	return latestHeight, nil
 }
 
+// getHeaderHeight is like getHeight, but also accepts heights below the block
+// store base whose header, commit and validator set were backfilled (e.g. by
+// state sync), for routes which don't need the block itself.
+func getHeaderHeight(latestHeight int64, heightPtr *int64) (int64, error) {
+	height, err := getHeight(latestHeight, heightPtr)
+	if errors.Is(err, ErrHeightNotAvailable) && *heightPtr > 0 && *heightPtr < env.BlockStore.Base() &&
+		env.BlockStore.LoadBlockMeta(*heightPtr) != nil {
+		return *heightPtr, nil
+	}
+	return height, err
+}
+
 func latestUncommittedHeight() int64 {
 	nodeIsSyncing := env.ConsensusReactor.WaitSync()
 	if nodeIsSyncing {
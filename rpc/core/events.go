@@ -2,26 +2,46 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
 	// Buffer on the Tendermint (server) side to allow some slowness in clients.
 	subBufferSize = 100
+
+	// Maximum number of events returned by /events.
+	maxEvents = 100
+)
+
+// pollers is the number of calls to /events which may wait for new events.
+// They count as subscription clients (see max_subscription_clients).
+var pollers int32
+
+var (
+	// clientSubs is the number of subscriptions made with SubscribeClient by
+	// each client.
+	clientSubs    = make(map[string]int)
+	clientSubsMtx tmsync.Mutex
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. If since is not 0, the events matching
+// the query published after the event with this epoch and sequence number are
+// replayed first, provided they're still in the history.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func Subscribe(ctx *rpctypes.Context, query, epoch string, since uint64) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
-	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+	if numClients() >= env.Config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
@@ -37,27 +57,51 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	var (
+		sub    types.Subscription
+		missed []tmpubsub.Message
+		last   uint64 // the events up to last were looked up in the history
+	)
+	if since > 0 {
+		sub, missed, last, err = env.EventBus.SubscribeSince(subCtx, addr, q, epoch, since, subBufferSize)
+	} else {
+		sub, err = env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
+	writeEvent := func(msg tmpubsub.Message) {
+		var (
+			resultEvent = &ctypes.ResultEvent{
+				Query:  query,
+				Data:   msg.Data(),
+				Events: msg.Events(),
+				Seq:    types.EventSeq(msg.Events()),
+				Epoch:  env.EventBus.Epoch(),
+			}
+			resp = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+		)
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err)
+		}
+	}
 	go func() {
+		for _, msg := range missed {
+			writeEvent(msg)
+		}
 		for {
 			select {
 			case msg := <-sub.Out():
-				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
-				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
-					env.Logger.Info("Can't write response (slow client)",
-						"to", addr, "subscriptionID", subscriptionID, "err", err)
+				if types.EventSeq(msg.Events()) <= last {
+					continue
 				}
+				writeEvent(msg)
 			case <-sub.Cancelled():
 				if sub.Err() != tmpubsub.ErrUnsubscribed {
 					var reason string
@@ -83,6 +127,127 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// SubscribeClient subscribes the given subscriber to query, for clients which
+// aren't connected via WebSocket (e.g. gRPC streams), within the same limits as
+// Subscribe. A client may subscribe with several subscribers (e.g. one per
+// stream), which each count as a subscription client, while their
+// subscriptions count against the max_subscriptions_per_client limit of the
+// client. The subscription must be removed with UnsubscribeClient.
+func SubscribeClient(ctx context.Context, client, subscriber, query string) (types.Subscription, error) {
+	if numClients() >= env.Config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	}
+	clientSubsMtx.Lock()
+	if clientSubs[client] >= env.Config.MaxSubscriptionsPerClient {
+		clientSubsMtx.Unlock()
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+	clientSubs[client]++
+	clientSubsMtx.Unlock()
+
+	sub, err := subscribeClient(ctx, subscriber, query)
+	if err != nil {
+		releaseClientSub(client)
+		return nil, err
+	}
+	return sub, nil
+}
+
+func subscribeClient(ctx context.Context, subscriber, query string) (types.Subscription, error) {
+	env.Logger.Info("Subscribe to query", "remote", subscriber, "query", query)
+
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
+	defer cancel()
+
+	return env.EventBus.Subscribe(subCtx, subscriber, q, subBufferSize)
+}
+
+// UnsubscribeClient removes a subscription made with SubscribeClient.
+func UnsubscribeClient(client, subscriber, query string) error {
+	releaseClientSub(client)
+
+	env.Logger.Info("Unsubscribe from query", "remote", subscriber, "query", query)
+	q, err := tmquery.New(query)
+	if err != nil {
+		return fmt.Errorf("failed to parse query: %w", err)
+	}
+	return env.EventBus.Unsubscribe(context.Background(), subscriber, q)
+}
+
+// releaseClientSub releases a subscription of the client counted by
+// SubscribeClient.
+func releaseClientSub(client string) {
+	clientSubsMtx.Lock()
+	defer clientSubsMtx.Unlock()
+	if clientSubs[client]--; clientSubs[client] <= 0 {
+		delete(clientSubs, client)
+	}
+}
+
+// Events returns the events matching the query published after the event with
+// the given epoch and sequence number (after), for clients which can't use
+// WebSocket subscriptions. If there are none, it waits up to waitTime (capped by
+// the events-max-wait-time config option) for new ones. If after is 0, only the
+// events published from now on are returned. The cursor and epoch of the result
+// must be passed as after and epoch to get the next events. The calls which may
+// wait count as subscription clients while they're running.
+// More: https://docs.tendermint.com/master/rpc/#/Info/events
+func Events(
+	ctx *rpctypes.Context,
+	query, epoch string,
+	after uint64,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	if waitTime < 0 {
+		return nil, errors.New("wait_time can't be negative")
+	}
+	if waitTime > env.Config.EventsMaxWaitTime {
+		waitTime = env.Config.EventsMaxWaitTime
+	}
+	if waitTime > 0 {
+		atomic.AddInt32(&pollers, 1)
+		defer atomic.AddInt32(&pollers, -1)
+		if numClients() > env.Config.MaxSubscriptionClients {
+			return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+		}
+	}
+
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx.Context(), waitTime)
+	defer cancel()
+	msgs, cursor, more, err := env.EventBus.EventsAfter(waitCtx, q, epoch, after, maxEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*ctypes.ResultEvent, len(msgs))
+	for i, msg := range msgs {
+		events[i] = &ctypes.ResultEvent{
+			Query:  query,
+			Data:   msg.Data(),
+			Events: msg.Events(),
+			Seq:    types.EventSeq(msg.Events()),
+			Epoch:  env.EventBus.Epoch(),
+		}
+	}
+	return &ctypes.ResultEvents{Events: events, Cursor: cursor, Epoch: env.EventBus.Epoch(), More: more}, nil
+}
+
+// numClients returns the number of subscription clients, including the calls
+// to /events which may wait.
+func numClients() int {
+	return env.EventBus.NumClients() + int(atomic.LoadInt32(&pollers))
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventsMaxPollers(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	env = &Environment{EventBus: eventBus, Config: *cfg.DefaultRPCConfig()}
+	env.Config.MaxSubscriptionClients = 1
+
+	query := types.QueryForEvent(types.EventNewBlock).String()
+
+	// a call waiting for new events counts as a subscription client
+	reqCtx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, "/events", nil)
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := Events(&rpctypes.Context{HTTPReq: req}, query, "", 0, time.Minute)
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&pollers) == 1 }, time.Second, time.Millisecond)
+
+	_, err = Events(&rpctypes.Context{}, query, "", 0, time.Second)
+	assert.Error(t, err)
+	_, err = Subscribe(&rpctypes.Context{}, query, "", 0)
+	assert.Error(t, err)
+
+	// calls which don't wait aren't limited
+	_, err = Events(&rpctypes.Context{}, query, "", 0, 0)
+	assert.NoError(t, err)
+
+	cancel()
+	<-done
+	assert.Zero(t, atomic.LoadInt32(&pollers))
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -25,3 +27,101 @@ func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.Result
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// PendingEvidence returns the evidence in the evidence pool which has not been
+// committed yet, from oldest to newest.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/pending_evidence
+func PendingEvidence(ctx *rpctypes.Context, pagePtr, perPagePtr *int) (*ctypes.ResultPendingEvidence, error) {
+	evList, _ := env.EvidencePool.PendingEvidence(-1)
+
+	totalCount := len(evList)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+
+	result := make([]ctypes.EvidenceInfo, 0, tmmath.MinInt(perPage, totalCount-skipCount))
+	for _, ev := range evList[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)] {
+		result = append(result, evidenceInfo(ev, 0))
+	}
+
+	return &ctypes.ResultPendingEvidence{
+		Evidence: result,
+		Count:    len(result),
+		Total:    totalCount}, nil
+}
+
+// CommittedEvidence returns the evidence committed in the block at the given
+// height, which defaults to the latest height. If a validator address is
+// given, it instead returns the evidence committed against that validator,
+// optionally only in the block at the given height.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/committed_evidence
+func CommittedEvidence(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	address []byte,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultCommittedEvidence, error) {
+	var evList []ctypes.EvidenceInfo
+	if len(address) > 0 {
+		committed, err := env.EvidencePool.CommittedEvidenceByValidator(address)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range committed {
+			if heightPtr == nil || *heightPtr == c.Height {
+				evList = append(evList, evidenceInfo(c.Evidence, c.Height))
+			}
+		}
+	} else {
+		height, err := getHeight(env.BlockStore.Height(), heightPtr)
+		if err != nil {
+			return nil, err
+		}
+		block := env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return nil, fmt.Errorf("block at height %d not found", height)
+		}
+		for _, ev := range block.Evidence.Evidence {
+			evList = append(evList, evidenceInfo(ev, height))
+		}
+	}
+
+	totalCount := len(evList)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+
+	result := evList[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+	if result == nil {
+		result = []ctypes.EvidenceInfo{}
+	}
+
+	return &ctypes.ResultCommittedEvidence{
+		Evidence: result,
+		Count:    len(result),
+		Total:    totalCount}, nil
+}
+
+// evidenceInfo describes the evidence along with the validators involved.
+// The commit height is zero for pending evidence.
+func evidenceInfo(ev types.Evidence, commitHeight int64) ctypes.EvidenceInfo {
+	info := ctypes.EvidenceInfo{
+		Evidence:     ev,
+		Hash:         ev.Hash(),
+		Height:       ev.Height(),
+		CommitHeight: commitHeight,
+	}
+	for _, abciEv := range ev.ABCI() {
+		info.Type = abciEv.Type.String()
+		info.Validators = append(info.Validators, bytes.HexBytes(abciEv.Validator.Address))
+	}
+	return info
+}
@@ -6,44 +6,61 @@ import (
 
 // TODO: better system than "unsafe" prefix
 
+// costly is the cost of the expensive routes within a batch of requests, whose
+// total cost is limited by the max-batch-cost setting.
+var costly = rpc.Cost(10)
+
+// immutable marks the routes whose results for heights below the latest one
+// never change, and can be cached.
+var immutable = rpc.Cacheable("height")
+
 // Routes is a map of available routes.
 var Routes = map[string]*rpc.RPCFunc{
 	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query,epoch,since"),
 	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+	// events polls the events over HTTP.
+	"events": rpc.NewRPCFunc(Events, "query,epoch,after,wait_time", costly),
 
 	// info API
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
-	"genesis":              rpc.NewRPCFunc(Genesis, ""),
-	"block":                rpc.NewRPCFunc(Block, "height"),
+	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", costly),
+	"genesis":              rpc.NewRPCFunc(Genesis, "", costly),
+	"block":                rpc.NewRPCFunc(Block, "height", immutable),
 	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":               rpc.NewRPCFunc(Commit, "height"),
-	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
+	"block_results":        rpc.NewRPCFunc(BlockResults, "height", immutable),
+	"commit":               rpc.NewRPCFunc(Commit, "height", immutable),
+	"commit_signers":       rpc.NewRPCFunc(CommitSigners, "height"),
+	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx", costly),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
-	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page"),
-	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
+	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by", costly),
+	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page", immutable),
+	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, "", costly),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
 	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
+	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx", costly),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
 	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
 
 	// abci API
-	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
+	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove", costly),
 	"abci_info":  rpc.NewRPCFunc(ABCIInfo, ""),
 
 	// evidence API
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"pending_evidence":   rpc.NewRPCFunc(PendingEvidence, "page,per_page"),
+	"committed_evidence": rpc.NewRPCFunc(CommittedEvidence, "height,address,page,per_page", costly),
+
+	// statesync API
+	"snapshots":      rpc.NewRPCFunc(Snapshots, ""),
+	"snapshot_chunk": rpc.NewRPCFunc(SnapshotChunk, "height,format,index", costly),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// Snapshots lists the snapshots available from the application, which can be
+// used to state sync other nodes.
+// More: https://docs.tendermint.com/master/rpc/#/ABCI/snapshots
+func Snapshots(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error) {
+	resp, err := env.ProxyAppSnapshot.ListSnapshotsSync(ctx.Context(), abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultSnapshots{Snapshots: resp.Snapshots}, nil
+}
+
+// SnapshotChunk loads a chunk of a snapshot from the application.
+// More: https://docs.tendermint.com/master/rpc/#/ABCI/snapshot_chunk
+func SnapshotChunk(ctx *rpctypes.Context, height uint64, format uint32, index uint32) (*ctypes.ResultSnapshotChunk, error) {
+	resp, err := env.ProxyAppSnapshot.LoadSnapshotChunkSync(ctx.Context(), abci.RequestLoadSnapshotChunk{
+		Height: height,
+		Format: format,
+		Chunk:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Chunk == nil {
+		return nil, errors.New("snapshot chunk not found")
+	}
+	return &ctypes.ResultSnapshotChunk{Chunk: resp.Chunk}, nil
+}
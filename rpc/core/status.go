@@ -50,6 +50,8 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 		votingPower = val.VotingPower
 	}
 
+	signingInfo := env.ConsensusState.GetValidatorSigningInfo()
+
 	result := &ctypes.ResultStatus{
 		NodeInfo: env.P2PTransport.NodeInfo(),
 		SyncInfo: ctypes.SyncInfo{
@@ -67,6 +69,10 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			Address:     env.PubKey.Address(),
 			PubKey:      env.PubKey,
 			VotingPower: votingPower,
+
+			SignatureWindow:  signingInfo.Window,
+			MissedSignatures: signingInfo.Missed,
+			LastSignedHeight: signingInfo.LastSignedHeight,
 		},
 	}
 
@@ -35,6 +35,12 @@ type ResultCommit struct {
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// Commit and Header, along with the validators which signed the commit
+type ResultCommitSigners struct {
+	types.HeaderOnlyLightBlock `json:"header_only_light_block"`
+	CanonicalCommit            bool `json:"canonical"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -79,6 +85,11 @@ type ValidatorInfo struct {
 	Address     bytes.HexBytes `json:"address"`
 	PubKey      crypto.PubKey  `json:"pub_key"`
 	VotingPower int64          `json:"voting_power"`
+
+	// Self-monitoring of the most recent commits
+	SignatureWindow  int64 `json:"signature_window"`
+	MissedSignatures int64 `json:"missed_signatures"`
+	LastSignedHeight int64 `json:"last_signed_height"`
 }
 
 // Node Status
@@ -218,6 +229,45 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Evidence along with the validators involved
+type EvidenceInfo struct {
+	Evidence   types.Evidence   `json:"evidence"`
+	Hash       bytes.HexBytes   `json:"hash"`
+	Type       string           `json:"type"`
+	Height     int64            `json:"height"`
+	Validators []bytes.HexBytes `json:"validators"`
+	// Height of the block the evidence was committed in, zero if pending
+	CommitHeight int64 `json:"commit_height"`
+}
+
+// List of uncommitted evidence
+type ResultPendingEvidence struct {
+	Evidence []EvidenceInfo `json:"evidence"`
+	// Count of evidence in this result
+	Count int `json:"count"`
+	// Total number of pending evidence
+	Total int `json:"total"`
+}
+
+// List of committed evidence
+type ResultCommittedEvidence struct {
+	Evidence []EvidenceInfo `json:"evidence"`
+	// Count of evidence in this result
+	Count int `json:"count"`
+	// Total number of committed evidence matching the query
+	Total int `json:"total"`
+}
+
+// List of snapshots available from the application
+type ResultSnapshots struct {
+	Snapshots []*abci.Snapshot `json:"snapshots"`
+}
+
+// Chunk of a snapshot
+type ResultSnapshotChunk struct {
+	Chunk []byte `json:"chunk"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
@@ -232,4 +282,19 @@ type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
+	// Sequence number of the event, to resume the subscription from (see
+	// types.EventBus#SubscribeSince), valid in the given epoch only.
+	Seq   uint64 `json:"seq"`
+	Epoch string `json:"epoch"`
+}
+
+// Events matching a query, polled with /events
+type ResultEvents struct {
+	Events []*ResultEvent `json:"events"`
+	// Sequence number of the last event looked up, to pass as after to get the
+	// next events, along with the epoch.
+	Cursor uint64 `json:"cursor"`
+	Epoch  string `json:"epoch"`
+	// Whether there are more events to look up, which didn't fit.
+	More bool `json:"more"`
 }
@@ -2,10 +2,15 @@ package coregrpc
 
 import (
 	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	core "github.com/tendermint/tendermint/rpc/core"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 type broadcastAPI struct {
@@ -37,3 +42,122 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 		},
 	}, nil
 }
+
+type lightAPI struct {
+}
+
+func (lapi *lightAPI) LightBlock(ctx context.Context, req *RequestLightBlock) (*ResponseLightBlock, error) {
+	commit, err := core.Commit(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	valSet, err := validatorSet(commit.Height)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	lb := &types.LightBlock{
+		SignedHeader: &commit.SignedHeader,
+		ValidatorSet: valSet,
+	}
+	pb, err := lb.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ResponseLightBlock{LightBlock: pb}, nil
+}
+
+func (lapi *lightAPI) Validators(ctx context.Context, req *RequestValidators) (*ResponseValidators, error) {
+	height := req.Height
+	if height == 0 {
+		res, err := core.Validators(&rpctypes.Context{}, nil, nil, nil)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		height = res.BlockHeight
+	}
+	valSet, err := validatorSet(height)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	pb, err := valSet.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ResponseValidators{Height: height, ValidatorSet: pb}, nil
+}
+
+func (lapi *lightAPI) ConsensusParams(
+	ctx context.Context,
+	req *RequestConsensusParams,
+) (*ResponseConsensusParams, error) {
+	res, err := core.ConsensusParams(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &ResponseConsensusParams{Height: res.BlockHeight, ConsensusParams: res.ConsensusParams}, nil
+}
+
+func (lapi *lightAPI) BroadcastEvidence(
+	ctx context.Context,
+	req *RequestBroadcastEvidence,
+) (*ResponseBroadcastEvidence, error) {
+	if req.Evidence == nil {
+		return nil, status.Error(codes.InvalidArgument, "no evidence was provided")
+	}
+	ev, err := types.EvidenceFromProto(req.Evidence)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := core.BroadcastEvidence(&rpctypes.Context{}, ev)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &ResponseBroadcastEvidence{Hash: res.Hash}, nil
+}
+
+// validatorSet returns the whole validator set at the given height, going
+// through all the pages of core.Validators.
+func validatorSet(height int64) (*types.ValidatorSet, error) {
+	var (
+		perPage = 100
+		vals    = []*types.Validator{}
+	)
+	for page := 1; ; page++ {
+		res, err := core.Validators(&rpctypes.Context{}, &height, &page, &perPage)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+	}
+	return types.ValidatorSetFromExistingValidators(vals)
+}
+
+// heightPtr returns nil for height 0, which stands for the latest height.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+// intPtr returns nil for 0, so the core functions use their defaults.
+func intPtr(n int32) *int {
+	if n == 0 {
+		return nil
+	}
+	i := int(n)
+	return &i
+}
+
+// statusError turns errors of the core functions into gRPC status errors.
+func statusError(err error) error {
+	if errors.Is(err, core.ErrHeightNotAvailable) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
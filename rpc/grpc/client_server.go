@@ -14,12 +14,15 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC server with the BroadcastAPI, LightAPI and
+// CoreAPI services using the given net.Listener and server options (see
+// RateLimit).
 // NOTE: This function blocks - you may want to call it in a go-routine.
-func StartGRPCServer(ln net.Listener) error {
-	grpcServer := grpc.NewServer()
+func StartGRPCServer(ln net.Listener, opts ...grpc.ServerOption) error {
+	grpcServer := grpc.NewServer(opts...)
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
+	RegisterLightAPIServer(grpcServer, &lightAPI{})
+	RegisterCoreAPIServer(grpcServer, &coreAPI{})
 	return grpcServer.Serve(ln)
 }
 
@@ -33,6 +36,26 @@ func StartGRPCClient(protoAddr string) BroadcastAPIClient {
 	return NewBroadcastAPIClient(conn)
 }
 
+// StartGRPCLightClient dials the gRPC server using protoAddr and returns a new
+// LightAPIClient.
+func StartGRPCLightClient(protoAddr string) (LightAPIClient, error) {
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		return nil, err
+	}
+	return NewLightAPIClient(conn), nil
+}
+
+// StartGRPCCoreClient dials the gRPC server using protoAddr and returns a new
+// CoreAPIClient.
+func StartGRPCCoreClient(protoAddr string) (CoreAPIClient, error) {
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		return nil, err
+	}
+	return NewCoreAPIClient(conn), nil
+}
+
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
 	return tmnet.Connect(addr)
 }
@@ -0,0 +1,327 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	cryptoproto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	core "github.com/tendermint/tendermint/rpc/core"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type coreAPI struct {
+	streams uint64 // number of Subscribe streams, numbering their subscribers
+}
+
+func (capi *coreAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := core.Status(&rpctypes.Context{})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var pubKey *cryptoproto.PublicKey
+	if res.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pubKey = &pk
+	}
+
+	return &ResponseStatus{
+		NodeInfo: res.NodeInfo.ToProto(),
+		SyncInfo: SyncInfo{
+			LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+			LatestAppHash:       res.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:     res.SyncInfo.LatestBlockTime,
+			EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+			EarliestBlockTime:   res.SyncInfo.EarliestBlockTime,
+			CatchingUp:          res.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: ValidatorInfo{
+			Address:          res.ValidatorInfo.Address,
+			PubKey:           pubKey,
+			VotingPower:      res.ValidatorInfo.VotingPower,
+			SignatureWindow:  res.ValidatorInfo.SignatureWindow,
+			MissedSignatures: res.ValidatorInfo.MissedSignatures,
+			LastSignedHeight: res.ValidatorInfo.LastSignedHeight,
+		},
+	}, nil
+}
+
+func (capi *coreAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := core.Block(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return blockToProto(res)
+}
+
+func (capi *coreAPI) BlockByHash(ctx context.Context, req *RequestBlockByHash) (*ResponseBlock, error) {
+	res, err := core.BlockByHash(&rpctypes.Context{}, req.Hash)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return blockToProto(res)
+}
+
+func (capi *coreAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := core.BlockResults(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &ResponseBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		BeginBlockEvents:      res.BeginBlockEvents,
+		EndBlockEvents:        res.EndBlockEvents,
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (capi *coreAPI) Commit(ctx context.Context, req *RequestCommit) (*ResponseCommit, error) {
+	res, err := core.Commit(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &ResponseCommit{SignedHeader: res.SignedHeader.ToProto(), Canonical: res.CanonicalCommit}, nil
+}
+
+func (capi *coreAPI) Validators(ctx context.Context, req *RequestValidatorsPage) (*ResponseValidatorsPage, error) {
+	res, err := core.Validators(&rpctypes.Context{}, heightPtr(req.Height), intPtr(req.Page), intPtr(req.PerPage))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	vals, err := validatorsToProto(res.Validators)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ResponseValidatorsPage{
+		BlockHeight: res.BlockHeight,
+		Validators:  vals,
+		Count:       int32(res.Count),
+		Total:       int32(res.Total),
+	}, nil
+}
+
+func (capi *coreAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := core.Tx(&rpctypes.Context{}, req.Hash, req.Prove)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return txToProto(res, req.Prove), nil
+}
+
+func (capi *coreAPI) TxSearch(ctx context.Context, req *RequestTxSearch) (*ResponseTxSearch, error) {
+	res, err := core.TxSearch(&rpctypes.Context{}, req.Query, req.Prove, intPtr(req.Page), intPtr(req.PerPage),
+		req.OrderBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	txs := make([]*ResponseTx, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = txToProto(tx, req.Prove)
+	}
+	return &ResponseTxSearch{Txs: txs, TotalCount: int32(res.TotalCount)}, nil
+}
+
+func (capi *coreAPI) ABCIQuery(ctx context.Context, req *RequestABCIQuery) (*ResponseABCIQuery, error) {
+	res, err := core.ABCIQuery(&rpctypes.Context{}, req.Path, req.Data, req.Height, req.Prove)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &ResponseABCIQuery{Response: res.Response}, nil
+}
+
+func (capi *coreAPI) BroadcastTxAsync(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTxSync, error) {
+	res, err := core.BroadcastTxAsync(&rpctypes.Context{}, req.Tx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return broadcastTxToProto(res), nil
+}
+
+func (capi *coreAPI) BroadcastTxSync(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTxSync, error) {
+	res, err := core.BroadcastTxSync(&rpctypes.Context{}, req.Tx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return broadcastTxToProto(res), nil
+}
+
+func (capi *coreAPI) BroadcastTxCommit(
+	ctx context.Context,
+	req *RequestBroadcastTx,
+) (*ResponseBroadcastTxCommit, error) {
+	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &ResponseBroadcastTxCommit{
+		CheckTx:   res.CheckTx,
+		DeliverTx: res.DeliverTx,
+		Hash:      res.Hash,
+		Height:    res.Height,
+	}, nil
+}
+
+// Subscribe streams the events matching the query until the client cancels the
+// stream or the subscription is cancelled. Subscriptions are limited per
+// connection, like the ones of a WebSocket connection, while each stream has its
+// own subscriber.
+func (capi *coreAPI) Subscribe(req *RequestSubscribe, stream CoreAPI_SubscribeServer) error {
+	client := "grpc"
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		client = p.Addr.String()
+	}
+	subscriber := fmt.Sprintf("%s#%d", client, atomic.AddUint64(&capi.streams, 1))
+
+	sub, err := core.SubscribeClient(stream.Context(), client, subscriber, req.Query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer func() {
+		// the subscription may have been cancelled already
+		_ = core.UnsubscribeClient(client, subscriber, req.Query)
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			res, err := eventToProto(req.Query, msg.Data(), msg.Events())
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		case <-sub.Cancelled():
+			if sub.Err() == tmpubsub.ErrUnsubscribed {
+				return nil
+			}
+			reason := "Tendermint exited"
+			if sub.Err() != nil {
+				reason = sub.Err().Error()
+			}
+			return status.Errorf(codes.Aborted, "subscription was cancelled (reason: %s)", reason)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func blockToProto(res *ctypes.ResultBlock) (*ResponseBlock, error) {
+	pb := &ResponseBlock{BlockId: res.BlockID.ToProto()}
+	if res.Block != nil {
+		block, err := res.Block.ToProto()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pb.Block = block
+	}
+	return pb, nil
+}
+
+func txToProto(res *ctypes.ResultTx, prove bool) *ResponseTx {
+	pb := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: res.TxResult,
+		Tx:       res.Tx,
+	}
+	if prove {
+		proof := res.Proof.ToProto()
+		pb.Proof = &proof
+	}
+	return pb
+}
+
+func broadcastTxToProto(res *ctypes.ResultBroadcastTx) *ResponseBroadcastTxSync {
+	return &ResponseBroadcastTxSync{
+		Code:      res.Code,
+		Data:      res.Data,
+		Log:       res.Log,
+		Codespace: res.Codespace,
+		Hash:      res.Hash,
+	}
+}
+
+func validatorsToProto(vals []*types.Validator) ([]*tmproto.Validator, error) {
+	pbs := make([]*tmproto.Validator, len(vals))
+	for i, val := range vals {
+		pb, err := val.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pbs[i] = pb
+	}
+	return pbs, nil
+}
+
+// eventToProto converts an event to protobuf. The data of the events without a
+// protobuf representation is encoded in JSON.
+func eventToProto(query string, data types.TMEventData, events map[string][]string) (*ResponseEvent, error) {
+	res := &ResponseEvent{Query: query, Events: make([]EventValues, 0, len(events))}
+	for key, values := range events {
+		res.Events = append(res.Events, EventValues{Key: key, Values: values})
+	}
+	sort.Slice(res.Events, func(i, j int) bool { return res.Events[i].Key < res.Events[j].Key })
+
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		block, err := data.Block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		res.Data = &ResponseEvent_NewBlock{NewBlock: &EventDataNewBlock{
+			Block:            block,
+			ResultBeginBlock: data.ResultBeginBlock,
+			ResultEndBlock:   data.ResultEndBlock,
+		}}
+	case types.EventDataNewBlockHeader:
+		res.Data = &ResponseEvent_NewBlockHeader{NewBlockHeader: &EventDataNewBlockHeader{
+			Header:           *data.Header.ToProto(),
+			NumTxs:           data.NumTxs,
+			ResultBeginBlock: data.ResultBeginBlock,
+			ResultEndBlock:   data.ResultEndBlock,
+		}}
+	case types.EventDataTx:
+		txResult := abci.TxResult(data.TxResult)
+		res.Data = &ResponseEvent_Tx{Tx: &txResult}
+	case types.EventDataVote:
+		res.Data = &ResponseEvent_Vote{Vote: data.Vote.ToProto()}
+	case types.EventDataValidatorSetUpdates:
+		vals, err := validatorsToProto(data.ValidatorUpdates)
+		if err != nil {
+			return nil, err
+		}
+		res.Data = &ResponseEvent_ValidatorSetUpdates{
+			ValidatorSetUpdates: &EventDataValidatorSetUpdates{ValidatorUpdates: vals},
+		}
+	default:
+		bz, err := tmjson.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("can't encode event data %T: %w", data, err)
+		}
+		res.Data = &ResponseEvent_Json{Json: bz}
+	}
+	return res, nil
+}
@@ -6,10 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	core_grpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestMain(m *testing.M) {
@@ -33,3 +36,109 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestLightAPI(t *testing.T) {
+	client, err := core_grpc.StartGRPCLightClient(rpctest.GetConfig().RPC.GRPCListenAddress)
+	require.NoError(t, err)
+
+	res, err := client.LightBlock(context.Background(), &core_grpc.RequestLightBlock{})
+	require.NoError(t, err)
+	lb, err := types.LightBlockFromProto(res.LightBlock)
+	require.NoError(t, err)
+	require.NoError(t, lb.ValidateBasic(lb.ChainID))
+
+	vals, err := client.Validators(context.Background(), &core_grpc.RequestValidators{Height: lb.Height})
+	require.NoError(t, err)
+	require.Equal(t, lb.Height, vals.Height)
+	valSet, err := types.ValidatorSetFromProto(vals.ValidatorSet)
+	require.NoError(t, err)
+	require.EqualValues(t, lb.ValidatorsHash, valSet.Hash())
+
+	_, err = client.LightBlock(context.Background(), &core_grpc.RequestLightBlock{Height: lb.Height + 1000})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCoreAPI(t *testing.T) {
+	client, err := core_grpc.StartGRPCCoreClient(rpctest.GetConfig().RPC.GRPCListenAddress)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st, err := client.Status(ctx, &core_grpc.RequestStatus{})
+	require.NoError(t, err)
+	require.Equal(t, rpctest.GetConfig().ChainID(), st.NodeInfo.Network)
+	require.NotNil(t, st.ValidatorInfo.PubKey)
+
+	sub, err := client.Subscribe(ctx, &core_grpc.RequestSubscribe{Query: types.EventQueryNewBlock.String()})
+	require.NoError(t, err)
+
+	tx := []byte("grpc=core")
+	commit, err := client.BroadcastTxCommit(ctx, &core_grpc.RequestBroadcastTx{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, commit.CheckTx.Code)
+	require.EqualValues(t, 0, commit.DeliverTx.Code)
+
+	block, err := client.Block(ctx, &core_grpc.RequestBlock{Height: commit.Height})
+	require.NoError(t, err)
+	require.Equal(t, commit.Height, block.Block.Header.Height)
+	require.Contains(t, block.Block.Data.Txs, tx)
+
+	res, err := client.Tx(ctx, &core_grpc.RequestTx{Hash: commit.Hash, Prove: true})
+	require.NoError(t, err)
+	require.Equal(t, commit.Height, res.Height)
+	require.EqualValues(t, tx, res.Tx)
+	require.NotNil(t, res.Proof)
+
+	search, err := client.TxSearch(ctx, &core_grpc.RequestTxSearch{Query: "app.key='grpc'"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, search.TotalCount)
+	require.Nil(t, search.Txs[0].Proof)
+
+	query, err := client.ABCIQuery(ctx, &core_grpc.RequestABCIQuery{Data: []byte("grpc")})
+	require.NoError(t, err)
+	require.EqualValues(t, "core", query.Response.Value)
+
+	vals, err := client.Validators(ctx, &core_grpc.RequestValidatorsPage{Height: commit.Height})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, vals.Total)
+
+	_, err = client.Block(ctx, &core_grpc.RequestBlock{Height: commit.Height + 1000})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	event, err := sub.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, event.GetNewBlock())
+	require.NotNil(t, event.GetNewBlock().Block)
+}
+
+func TestCoreAPISubscribeStreams(t *testing.T) {
+	client, err := core_grpc.StartGRPCCoreClient(rpctest.GetConfig().RPC.GRPCListenAddress)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the streams of a connection may subscribe to the same query
+	query := types.EventQueryNewBlock.String()
+	ctx1, cancel1 := context.WithCancel(ctx)
+	sub1, err := client.Subscribe(ctx1, &core_grpc.RequestSubscribe{Query: query})
+	require.NoError(t, err)
+	sub2, err := client.Subscribe(ctx, &core_grpc.RequestSubscribe{Query: query})
+	require.NoError(t, err)
+	for _, sub := range []core_grpc.CoreAPI_SubscribeClient{sub1, sub2} {
+		event, err := sub.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, event.GetNewBlock())
+	}
+
+	// closing a stream doesn't cancel the subscription of the other one
+	cancel1()
+	for err == nil {
+		_, err = sub1.Recv() // events may have been received before
+	}
+	require.Equal(t, codes.Canceled, status.Code(err))
+	for i := 0; i < 2; i++ {
+		event, err := sub2.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, event.GetNewBlock())
+	}
+}
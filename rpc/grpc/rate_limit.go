@@ -0,0 +1,100 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+)
+
+// APIKeyMetadata is the metadata key carrying the API key of a client (see
+// rpcserver.APIKeyHeader).
+var APIKeyMetadata = strings.ToLower(rpcserver.APIKeyHeader)
+
+// coreRoutes maps the CoreAPI methods to the JSON-RPC routes they share their
+// rate limits with.
+var coreRoutes = map[string]string{
+	"/tendermint.rpc.grpc.CoreAPI/Status":            "status",
+	"/tendermint.rpc.grpc.CoreAPI/Block":             "block",
+	"/tendermint.rpc.grpc.CoreAPI/BlockByHash":       "block_by_hash",
+	"/tendermint.rpc.grpc.CoreAPI/BlockResults":      "block_results",
+	"/tendermint.rpc.grpc.CoreAPI/Commit":            "commit",
+	"/tendermint.rpc.grpc.CoreAPI/Validators":        "validators",
+	"/tendermint.rpc.grpc.CoreAPI/Tx":                "tx",
+	"/tendermint.rpc.grpc.CoreAPI/TxSearch":          "tx_search",
+	"/tendermint.rpc.grpc.CoreAPI/ABCIQuery":         "abci_query",
+	"/tendermint.rpc.grpc.CoreAPI/BroadcastTxAsync":  "broadcast_tx_async",
+	"/tendermint.rpc.grpc.CoreAPI/BroadcastTxSync":   "broadcast_tx_sync",
+	"/tendermint.rpc.grpc.CoreAPI/BroadcastTxCommit": "broadcast_tx_commit",
+	"/tendermint.rpc.grpc.CoreAPI/Subscribe":         "subscribe",
+}
+
+// RateLimit returns the server options limiting the calls of each client,
+// identified by its API key (see APIKeyMetadata) or, without one, by its IP
+// address, with the given RateLimiter. The CoreAPI methods are limited like the
+// JSON-RPC routes of the same name, and a stream counts as one call.
+func RateLimit(rl *rpcserver.RateLimiter) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			if err := allowCall(ctx, rl, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			if err := allowCall(ss.Context(), rl, info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// allowCall takes a token from the bucket of the client calling the given
+// method, returning a gRPC status error if it can't.
+func allowCall(ctx context.Context, rl *rpcserver.RateLimiter, fullMethod string) error {
+	var ip, apiKey string
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(APIKeyMetadata); len(keys) > 0 {
+			apiKey = keys[0]
+		}
+	}
+	method, ok := coreRoutes[fullMethod]
+	if !ok {
+		method = fullMethod
+	}
+
+	err := rl.Allow(ip, apiKey, method)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, rpcserver.ErrInvalidAPIKey):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package coregrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+)
+
+func TestRateLimit(t *testing.T) {
+	rl, err := rpcserver.NewRateLimiter(
+		rpcserver.Limit{Rate: 0.001, Burst: 1},
+		rpcserver.RouteLimit("tx_search", rpcserver.Limit{Rate: 0.001, Burst: 2}),
+	)
+	require.NoError(t, err)
+
+	clientCtx := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 26658},
+		})
+	}
+	code := func(err error) codes.Code {
+		return status.Code(err)
+	}
+
+	// the CoreAPI methods share the limits of the JSON-RPC routes
+	alice := clientCtx("1.1.1.1")
+	assert.NoError(t, allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/Status"))
+	assert.Equal(t, codes.ResourceExhausted, code(allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/Status")))
+	assert.NoError(t, allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/TxSearch"))
+	assert.NoError(t, allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/TxSearch"))
+	assert.Equal(t, codes.ResourceExhausted, code(allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/TxSearch")))
+	// the JSON-RPC calls of the client share its buckets, and so do the streams
+	assert.Error(t, rl.Allow("1.1.1.1", "", "status"))
+	assert.Equal(t, codes.ResourceExhausted, code(allowCall(alice, rl, "/tendermint.rpc.grpc.CoreAPI/Subscribe")))
+
+	// each client has its own bucket
+	assert.NoError(t, allowCall(clientCtx("2.2.2.2"), rl, "/tendermint.rpc.grpc.CoreAPI/Status"))
+
+	// an unknown API key is rejected
+	ctx := metadata.NewIncomingContext(clientCtx("3.3.3.3"), metadata.Pairs(APIKeyMetadata, "unknown"))
+	assert.Equal(t, codes.Unauthenticated, code(allowCall(ctx, rl, "/tendermint.rpc.grpc.CoreAPI/Status")))
+}
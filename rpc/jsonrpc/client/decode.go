@@ -49,9 +49,27 @@ func unmarshalResponseBytesArray(
 	)
 
 	if err := json.Unmarshal(responseBytes, &responses); err != nil {
+		// the whole batch may have been rejected
+		response := &types.RPCResponse{}
+		if err := json.Unmarshal(responseBytes, response); err == nil && response.Error != nil {
+			return nil, response.Error
+		}
 		return nil, fmt.Errorf("error unmarshalling: %w", err)
 	}
 
+	return unmarshalResponsesArray(responses, expectedIDs, results)
+}
+
+// unmarshalResponsesArray unmarshals the results of the responses to a batch
+// of requests, which may be in any order, into the results of the requests
+// with the same IDs. The results of failed requests are replaced by their
+// *types.RPCError.
+func unmarshalResponsesArray(
+	responses []types.RPCResponse,
+	expectedIDs []types.JSONRPCIntID,
+	results []interface{},
+) ([]interface{}, error) {
+
 	// No response error checking here as there may be a mixture of successful
 	// and unsuccessful responses.
 
@@ -76,9 +94,18 @@ func unmarshalResponseBytesArray(
 		return nil, fmt.Errorf("wrong IDs: %w", err)
 	}
 
-	for i := 0; i < len(responses); i++ {
-		if err := tmjson.Unmarshal(responses[i].Result, results[i]); err != nil {
-			return nil, fmt.Errorf("error unmarshalling #%d result: %w", i, err)
+	indexes := make(map[types.JSONRPCIntID]int, len(expectedIDs))
+	for i, id := range expectedIDs {
+		indexes[id] = i
+	}
+	for i, resp := range responses {
+		idx := indexes[ids[i]]
+		if resp.Error != nil {
+			results[idx] = resp.Error
+			continue
+		}
+		if err := tmjson.Unmarshal(resp.Result, results[idx]); err != nil {
+			return nil, fmt.Errorf("error unmarshalling #%d result: %w", idx, err)
 		}
 	}
 
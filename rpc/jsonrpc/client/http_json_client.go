@@ -205,6 +205,8 @@ func (c *Client) NewRequestBatch() *RequestBatch {
 	}
 }
 
+var _ batchClient = (*Client)(nil)
+
 func (c *Client) sendBatch(ctx context.Context, requests []*jsonRPCBufferedRequest) ([]interface{}, error) {
 	reqs := make([]types.RPCRequest, 0, len(requests))
 	results := make([]interface{}, 0, len(requests))
@@ -268,11 +270,17 @@ type jsonRPCBufferedRequest struct {
 	result  interface{} // The result will be deserialized into this object.
 }
 
+// batchClient is a client able to send a batch of requests.
+type batchClient interface {
+	nextRequestID() types.JSONRPCIntID
+	sendBatch(ctx context.Context, requests []*jsonRPCBufferedRequest) ([]interface{}, error)
+}
+
 // RequestBatch allows us to buffer multiple request/response structures
 // into a single batch request. Note that this batch acts like a FIFO queue, and
 // is thread-safe.
 type RequestBatch struct {
-	client *Client
+	client batchClient
 
 	mtx      tmsync.Mutex
 	requests []*jsonRPCBufferedRequest
@@ -306,7 +314,8 @@ func (b *RequestBatch) clear() int {
 
 // Send will attempt to send the current batch of enqueued requests, and then
 // will clear out the requests once done. On success, this returns the
-// deserialized list of results from each of the enqueued requests.
+// deserialized list of results from each of the enqueued requests, in the same
+// order. The result of a failed request is its *types.RPCError.
 func (b *RequestBatch) Send(ctx context.Context) ([]interface{}, error) {
 	b.mtx.Lock()
 	defer func() {
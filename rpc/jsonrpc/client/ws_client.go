@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -44,10 +45,10 @@ type WSClient struct { // nolint: maligned
 	onReconnect func()
 
 	// internal channels
-	send            chan types.RPCRequest // user requests
-	backlog         chan types.RPCRequest // stores a single user request received during a conn failure
-	reconnectAfter  chan error            // reconnect requests
-	readRoutineQuit chan struct{}         // a way for readRoutine to close writeRoutine
+	send            chan interface{} // user requests (a types.RPCRequest or a batch of them)
+	backlog         chan interface{} // stores a single user request received during a conn failure
+	reconnectAfter  chan error       // reconnect requests
+	readRoutineQuit chan struct{}    // a way for readRoutine to close writeRoutine
 
 	// Maximum reconnect attempts (0 or greater; default: 25).
 	maxReconnectAttempts int
@@ -62,6 +63,8 @@ type WSClient struct { // nolint: maligned
 	reconnecting   bool
 	nextReqID      int
 	// sentIDs        map[types.JSONRPCIntID]bool // IDs of the requests currently in flight
+	// responses to the requests of the batches in flight, by request ID
+	pending map[types.JSONRPCIntID]chan<- types.RPCResponse
 
 	// Time allowed to write a message to the server. 0 means block until operation succeeds.
 	writeWait time.Duration
@@ -111,6 +114,7 @@ func NewWS(remoteAddr, endpoint string, options ...func(*WSClient)) (*WSClient,
 		protocol:             parsedURL.Scheme,
 
 		// sentIDs: make(map[types.JSONRPCIntID]bool),
+		pending: make(map[types.JSONRPCIntID]chan<- types.RPCResponse),
 	}
 	c.BaseService = *service.NewBaseService(nil, "WSClient", c)
 	for _, option := range options {
@@ -153,6 +157,11 @@ func PingPeriod(pingPeriod time.Duration) func(*WSClient) {
 
 // OnReconnect sets the callback, which will be called every time after
 // successful reconnect.
+//
+// NOTE: WSClient doesn't restore the subscriptions after reconnecting, nor
+// keep track of the events received. The callback must subscribe again, using
+// SubscribeSince with the sequence number and epoch of the last event received
+// to get the events published in the meantime (see rpc/client/http.WSEvents).
 func OnReconnect(cb func()) func(*WSClient) {
 	return func(c *WSClient) {
 		c.onReconnect = cb
@@ -174,13 +183,13 @@ func (c *WSClient) OnStart() error {
 
 	c.ResponsesCh = make(chan types.RPCResponse)
 
-	c.send = make(chan types.RPCRequest)
+	c.send = make(chan interface{})
 	// 1 additional error may come from the read/write
 	// goroutine depending on which failed first.
 	c.reconnectAfter = make(chan error, 1)
 	// capacity for 1 request. a user won't be able to send more because the send
 	// channel is unbuffered.
-	c.backlog = make(chan types.RPCRequest, 1)
+	c.backlog = make(chan interface{}, 1)
 
 	c.startReadWriteRoutines()
 	go c.reconnectRoutine()
@@ -217,6 +226,75 @@ func (c *WSClient) IsActive() bool {
 // ResponsesCh, errors, if any, on ErrorsCh. Will block until send succeeds or
 // ctx.Done is closed.
 func (c *WSClient) Send(ctx context.Context, request types.RPCRequest) error {
+	return c.write(ctx, request)
+}
+
+// NewRequestBatch starts a batch of requests for this client. The batch is
+// sent as a single message, and its results are not available on ResponsesCh.
+func (c *WSClient) NewRequestBatch() *RequestBatch {
+	return &RequestBatch{
+		requests: make([]*jsonRPCBufferedRequest, 0),
+		client:   c,
+	}
+}
+
+var _ batchClient = (*WSClient)(nil)
+
+func (c *WSClient) sendBatch(ctx context.Context, requests []*jsonRPCBufferedRequest) ([]interface{}, error) {
+	if !c.IsRunning() {
+		return nil, errors.New("client is not running")
+	}
+	if len(requests) == 0 {
+		return nil, errors.New("empty batch")
+	}
+
+	var (
+		reqs      = make([]types.RPCRequest, 0, len(requests))
+		results   = make([]interface{}, 0, len(requests))
+		ids       = make([]types.JSONRPCIntID, 0, len(requests))
+		responses = make([]types.RPCResponse, 0, len(requests))
+		// buffered, so readRoutine never blocks on it
+		responsesCh = make(chan types.RPCResponse, len(requests))
+	)
+	for _, req := range requests {
+		reqs = append(reqs, req.request)
+		results = append(results, req.result)
+		ids = append(ids, req.request.ID.(types.JSONRPCIntID))
+	}
+
+	c.mtx.Lock()
+	for _, id := range ids {
+		c.pending[id] = responsesCh
+	}
+	c.mtx.Unlock()
+	defer func() {
+		c.mtx.Lock()
+		for _, id := range ids {
+			delete(c.pending, id)
+		}
+		c.mtx.Unlock()
+	}()
+
+	if err := c.write(ctx, reqs); err != nil {
+		return nil, err
+	}
+
+	for len(responses) < len(reqs) {
+		select {
+		case response := <-responsesCh:
+			responses = append(responses, response)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.Quit():
+			return nil, errors.New("client stopped")
+		}
+	}
+
+	return unmarshalResponsesArray(responses, ids, results)
+}
+
+// write sends the given request or batch of requests to the server.
+func (c *WSClient) write(ctx context.Context, request interface{}) error {
 	select {
 	case c.send <- request:
 		c.Logger.Info("sent a request", "req", request)
@@ -494,6 +572,16 @@ func (c *WSClient) readRoutine() {
 			continue
 		}
 
+		// responses to batches are returned by RequestBatch.Send
+		c.mtx.Lock()
+		responsesCh, ok := c.pending[response.ID.(types.JSONRPCIntID)]
+		delete(c.pending, response.ID.(types.JSONRPCIntID))
+		c.mtx.Unlock()
+		if ok {
+			responsesCh <- response
+			continue
+		}
+
 		// TODO: events resulting from /subscribe do not work with ->
 		// because they are implemented as responses with the subscribe request's
 		// ID. According to the spec, they should be notifications (requests
@@ -529,6 +617,15 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeSince subscribes to a query, replaying the events published after
+// the event with the given epoch and sequence number first, e.g. to resume a
+// subscription after reconnecting (see OnReconnect). Note the server must have
+// a "subscribe" route defined, with "epoch" and "since" parameters.
+func (c *WSClient) SubscribeSince(ctx context.Context, query, epoch string, since uint64) error {
+	params := map[string]interface{}{"query": query, "epoch": epoch, "since": since}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// MaxBatchSize sets the maximum number of requests in a batch. Defaults to 0
+// (unlimited).
+func MaxBatchSize(size int) HandlerOption {
+	return func(o *handlerOptions) {
+		o.maxBatchSize = size
+	}
+}
+
+// MaxBatchCost sets the maximum total cost (see Cost) of the requests in a
+// batch. Defaults to 0 (unlimited).
+func MaxBatchCost(cost int) HandlerOption {
+	return func(o *handlerOptions) {
+		o.maxBatchCost = cost
+	}
+}
+
+// BatchConcurrency sets the number of requests of a batch executed
+// concurrently. Defaults to 1 (one after the other).
+func BatchConcurrency(concurrency int) HandlerOption {
+	return func(o *handlerOptions) {
+		if concurrency > 0 {
+			o.batchConcurrency = concurrency
+		}
+	}
+}
+
+// checkBatch returns an error if the batch of requests exceeds the limits.
+// Unknown methods don't count towards the cost, as they aren't called.
+func (o handlerOptions) checkBatch(requests []types.RPCRequest, funcMap map[string]*RPCFunc) error {
+	if o.maxBatchSize > 0 && len(requests) > o.maxBatchSize {
+		return fmt.Errorf("batch of %d requests exceeds the max batch size %d", len(requests), o.maxBatchSize)
+	}
+	if o.maxBatchCost > 0 {
+		cost := 0
+		for _, request := range requests {
+			if rpcFunc, ok := funcMap[request.Method]; ok {
+				cost += rpcFunc.cost
+			}
+		}
+		if cost > o.maxBatchCost {
+			return fmt.Errorf("batch of cost %d exceeds the max batch cost %d", cost, o.maxBatchCost)
+		}
+	}
+	return nil
+}
+
+// runBatch calls call for each request, using up to o.batchConcurrency
+// goroutines, and sends the responses on the returned channel in the order they
+// are ready. The channel is closed once all the requests have been handled.
+// call returns nil for requests which aren't answered (notifications).
+//
+// A panic in call is returned as an internal error.
+func (o handlerOptions) runBatch(
+	requests []types.RPCRequest,
+	call func(types.RPCRequest) *types.RPCResponse,
+	logger log.Logger,
+) <-chan types.RPCResponse {
+
+	var (
+		wg        sync.WaitGroup
+		queue     = make(chan types.RPCRequest)
+		responses = make(chan types.RPCResponse, len(requests))
+	)
+
+	handle := func(request types.RPCRequest) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Panic in RPC batch handler", "method", request.Method, "err", r,
+					"stack", string(debug.Stack()))
+				if request.ID != nil {
+					responses <- types.RPCInternalError(request.ID, fmt.Errorf("panic: %v", r))
+				}
+			}
+		}()
+		if res := call(request); res != nil {
+			responses <- *res
+		}
+	}
+
+	workers := o.batchConcurrency
+	if workers > len(requests) {
+		workers = len(requests)
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for request := range queue {
+				handle(request)
+			}
+		}()
+	}
+
+	go func() {
+		for _, request := range requests {
+			queue <- request
+		}
+		close(queue)
+		wg.Wait()
+		close(responses)
+	}()
+
+	return responses
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// ResponseCache is an LRU cache of the encoded results of the calls to
+// cacheable functions (see Cacheable), which are immutable. It also sets the
+// Cache-Control and ETag headers of their HTTP responses, so that they can be
+// cached by proxies and CDNs.
+type ResponseCache struct {
+	maxBytes     int64
+	maxAge       time.Duration
+	latestHeight func() int64
+	metrics      *Metrics
+
+	mtx     tmsync.Mutex
+	size    int64
+	entries map[string]*list.Element
+	lru     *list.List // front is the most recently used
+}
+
+type cacheEntry struct {
+	key    string
+	result json.RawMessage
+	etag   string
+}
+
+// NewResponseCache returns a cache holding up to maxBytes of encoded results (0
+// disables the cache, but not the HTTP headers). HTTP responses may be cached
+// for maxAge (0 disables the headers). latestHeight returns the latest height,
+// the calls for heights below which are immutable.
+func NewResponseCache(
+	maxBytes int64,
+	maxAge time.Duration,
+	latestHeight func() int64,
+	options ...func(*ResponseCache),
+) *ResponseCache {
+	c := &ResponseCache{
+		maxBytes:     maxBytes,
+		maxAge:       maxAge,
+		latestHeight: latestHeight,
+		metrics:      NopMetrics(),
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// ResponseCacheMetrics sets the metrics.
+func ResponseCacheMetrics(metrics *Metrics) func(*ResponseCache) {
+	return func(c *ResponseCache) {
+		c.metrics = metrics
+	}
+}
+
+// ResponseCaching caches the results of the calls to cacheable functions in the
+// given ResponseCache. Defaults to nil (no caching).
+func ResponseCaching(c *ResponseCache) HandlerOption {
+	return func(o *handlerOptions) {
+		o.responseCache = c
+	}
+}
+
+// cacheable returns true if the call to rpcFunc with the given arguments
+// (including the context) is immutable: its height is below the latest height.
+// A nil ResponseCache caches nothing.
+func (c *ResponseCache) cacheable(rpcFunc *RPCFunc, args []reflect.Value) bool {
+	if c == nil || rpcFunc.cacheHeightArg < 0 || len(args) <= rpcFunc.cacheHeightArg+1 {
+		return false
+	}
+	height := args[rpcFunc.cacheHeightArg+1]
+	if height.IsNil() {
+		return false // latest height
+	}
+	return height.Elem().Int() < c.latestHeight()
+}
+
+func (c *ResponseCache) get(method, key string) (cacheEntry, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.metrics.ResponseCacheMisses.With("method", method).Add(1)
+		return cacheEntry{}, false
+	}
+	c.metrics.ResponseCacheHits.With("method", method).Add(1)
+	c.lru.MoveToFront(elem)
+	return elem.Value.(cacheEntry), true
+}
+
+// add adds the encoded result of a call, evicting the least recently used
+// results if needed, and returns the ETag of the result. Results larger than
+// the cache aren't added.
+func (c *ResponseCache) add(key string, result json.RawMessage) string {
+	hash := sha256.Sum256(result)
+	entry := cacheEntry{key: key, result: result, etag: `"` + hex.EncodeToString(hash[:16]) + `"`}
+
+	size := int64(len(key) + len(result))
+	if size > c.maxBytes {
+		return entry.etag
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return entry.etag // added by a concurrent call
+	}
+	for c.size+size > c.maxBytes {
+		oldest := c.lru.Remove(c.lru.Back()).(cacheEntry)
+		delete(c.entries, oldest.key)
+		c.size -= int64(len(oldest.key) + len(oldest.result))
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += size
+	return entry.etag
+}
+
+// setHeaders sets the caching headers of the HTTP response of an immutable
+// result with the given ETag. It returns true if the client already has the
+// result (If-None-Match), in which case the response must be 304 Not Modified.
+func (c *ResponseCache) setHeaders(w http.ResponseWriter, r *http.Request, etag string) bool {
+	if c.maxAge <= 0 {
+		return false
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int64(c.maxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	return r.Header.Get("If-None-Match") == etag
+}
+
+// call calls rpcFunc with the given arguments (including the context), or gets
+// its result from the cache, and returns the encoded result. For immutable
+// results, it also returns their ETag.
+func (o handlerOptions) call(method string, rpcFunc *RPCFunc, args []reflect.Value) (json.RawMessage, string, error) {
+	cache := o.responseCache
+	var key string
+	cacheable := cache.cacheable(rpcFunc, args)
+	if cacheable {
+		key, cacheable = cacheKey(method, args)
+	}
+	if cacheable {
+		if entry, ok := cache.get(method, key); ok {
+			return entry.result, entry.etag, nil
+		}
+	}
+
+	returns := rpcFunc.f.Call(args)
+	result, err := unreflectResult(returns)
+	if err != nil {
+		return nil, "", err
+	}
+	bz, err := tmjson.Marshal(result)
+	if err != nil {
+		return nil, "", fmt.Errorf("error marshalling response: %w", err)
+	}
+	if !cacheable {
+		return bz, "", nil
+	}
+	return bz, cache.add(key, bz), nil
+}
+
+// cacheKey returns the cache key of the call to method with the given arguments
+// (including the context): the method followed by the JSON encoded arguments.
+// It returns false if the arguments can't be encoded.
+func cacheKey(method string, args []reflect.Value) (string, bool) {
+	var key strings.Builder
+	key.WriteString(method)
+	for _, arg := range args[1:] {
+		bz, err := tmjson.Marshal(arg.Interface())
+		if err != nil {
+			return "", false
+		}
+		key.WriteByte(',')
+		key.Write(bz)
+	}
+	return key.String(), true
+}
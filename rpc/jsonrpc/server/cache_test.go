@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestResponseCache(t *testing.T) {
+	calls := 0
+	funcMap := map[string]*RPCFunc{
+		"block": NewRPCFunc(func(ctx *types.Context, height *int64) (string, error) {
+			calls++
+			if height == nil {
+				return "latest", nil
+			}
+			return fmt.Sprintf("block %d", *height), nil
+		}, "height", Cacheable("height")),
+	}
+	// room for a single result
+	cache := NewResponseCache(32, time.Hour, func() int64 { return 10 })
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), ResponseCaching(cache))
+
+	get := func(url string, header ...string) *http.Response {
+		req := httptest.NewRequest("GET", url, nil)
+		for i := 0; i < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	// results below the latest height are cached, with caching headers
+	res := get("http://localhost/block?height=5")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "public, max-age=3600, immutable", res.Header.Get("Cache-Control"))
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	res = get("http://localhost/block?height=5")
+	var response types.RPCResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+	assert.JSONEq(t, `"block 5"`, string(response.Result))
+	assert.Equal(t, etag, res.Header.Get("ETag"))
+	assert.Equal(t, 1, calls)
+
+	res = get("http://localhost/block?height=5", "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Equal(t, 1, calls)
+
+	// the cache is shared with JSON-RPC requests
+	req := httptest.NewRequest("POST", "http://localhost/",
+		strings.NewReader(`{"jsonrpc": "2.0", "method": "block", "id": 7, "params": {"height": "5"}}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	response = types.RPCResponse{}
+	require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&response))
+	assert.Equal(t, types.JSONRPCIntID(7), response.ID)
+	assert.JSONEq(t, `"block 5"`, string(response.Result))
+	assert.Equal(t, 1, calls)
+
+	// the latest height isn't cached
+	for _, url := range []string{"http://localhost/block?height=10", "http://localhost/block"} {
+		res = get(url)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Cache-Control"))
+		assert.Empty(t, res.Header.Get("ETag"))
+	}
+	assert.Equal(t, 3, calls)
+
+	// the least recently used result is evicted
+	get("http://localhost/block?height=6")
+	get("http://localhost/block?height=6")
+	assert.Equal(t, 4, calls)
+	get("http://localhost/block?height=5")
+	assert.Equal(t, 5, calls)
+}
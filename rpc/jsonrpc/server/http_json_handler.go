@@ -17,7 +17,7 @@ import (
 // HTTP + JSON handler
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, opts handlerOptions, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -39,11 +39,12 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			return
 		}
 
+		call := func(request types.RPCRequest) *types.RPCResponse {
+			return callJSONRPC(funcMap, r, request, opts, logger)
+		}
+
 		// first try to unmarshal the incoming request as an array of RPC requests
-		var (
-			requests  []types.RPCRequest
-			responses []types.RPCResponse
-		)
+		var requests []types.RPCRequest
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
 			var request types.RPCRequest
@@ -57,59 +58,71 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				)
 				return
 			}
-			requests = []types.RPCRequest{request}
+			if res := call(request); res != nil {
+				WriteRPCResponseHTTP(w, *res)
+			}
+			return
 		}
 
-		for _, request := range requests {
-			request := request
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				logger.Debug(
-					"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
-				continue
-			}
-			if len(r.URL.Path) > 1 {
-				responses = append(
-					responses,
-					types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)),
-				)
-				continue
-			}
-			rpcFunc, ok := funcMap[request.Method]
-			if !ok || rpcFunc.ws {
-				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
-				continue
-			}
-			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					responses = append(
-						responses,
-						types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
-			returns := rpcFunc.f.Call(args)
-			logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
-			result, err := unreflectResult(returns)
-			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
-				continue
-			}
-			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
+		if err := opts.checkBatch(requests, funcMap); err != nil {
+			WriteRPCResponseHTTPError(w, http.StatusBadRequest, types.RPCInvalidRequestError(nil, err))
+			return
 		}
-		if len(responses) > 0 {
-			WriteRPCResponseHTTP(w, responses...)
+		writeRPCResponsesHTTP(w, opts.runBatch(requests, call, logger))
+	}
+}
+
+// callJSONRPC calls the function of the given request and returns its
+// response, or nil if the request is a notification.
+func callJSONRPC(
+	funcMap map[string]*RPCFunc,
+	r *http.Request,
+	request types.RPCRequest,
+	opts handlerOptions,
+	logger log.Logger,
+) *types.RPCResponse {
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		logger.Debug(
+			"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return nil
+	}
+
+	var res types.RPCResponse
+	if len(r.URL.Path) > 1 {
+		res = types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path))
+		return &res
+	}
+	rpcFunc, ok := funcMap[request.Method]
+	if !ok || rpcFunc.ws {
+		res = types.RPCMethodNotFoundError(request.ID)
+		return &res
+	}
+	if err := opts.rateLimiter.allow(rateClientOf(r), request.Method); err != nil {
+		res, _ = rateLimitError(request, err)
+		return &res
+	}
+	ctx := &types.Context{JSONReq: &request, HTTPReq: r}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			res = types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err))
+			return &res
 		}
+		args = append(args, fnArgs...)
+	}
+	result, _, err := opts.call(request.Method, rpcFunc, args)
+	logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "result", result)
+	if err != nil {
+		res = types.RPCInternalError(request.ID, err)
+		return &res
 	}
+	res = types.RPCResponse{JSONRPC: "2.0", ID: request.ID, Result: result}
+	return &res
 }
 
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
@@ -216,6 +216,105 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatchLimits(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+		"d": NewRPCFunc(func(ctx *types.Context) (string, error) { return "bar", nil }, "", Cost(3)),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), MaxBatchSize(4), MaxBatchCost(5))
+
+	tests := []struct {
+		methods []string
+		wantErr string
+	}{
+		{[]string{"c", "c", "d"}, ""},
+		{[]string{"c", "c", "c", "c", "c"}, "max batch size 4"},
+		{[]string{"d", "d"}, "max batch cost 5"},
+		// unknown methods aren't called, so they cost nothing
+		{[]string{"c", "c", "d", "x"}, ""},
+	}
+	for i, tt := range tests {
+		requests := make([]types.RPCRequest, len(tt.methods))
+		for j, method := range tt.methods {
+			requests[j] = types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCIntID(j), Method: method}
+		}
+		payload, err := json.Marshal(requests)
+		require.NoError(t, err)
+
+		req, _ := http.NewRequest("POST", "http://localhost/", bytes.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		blob, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		if tt.wantErr != "" {
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "#%d", i)
+			var response types.RPCResponse
+			require.NoError(t, json.Unmarshal(blob, &response), "#%d", i)
+			require.NotNil(t, response.Error, "#%d", i)
+			assert.Contains(t, response.Error.Data, tt.wantErr, "#%d", i)
+			continue
+		}
+		var responses []types.RPCResponse
+		require.NoError(t, json.Unmarshal(blob, &responses), "#%d: %s", i, blob)
+		assert.Len(t, responses, len(tt.methods), "#%d", i)
+	}
+}
+
+func TestRPCBatchConcurrency(t *testing.T) {
+	const n = 4
+	var (
+		started = make(chan struct{}, n)
+		release = make(chan struct{})
+	)
+	funcMap := map[string]*RPCFunc{
+		// returns once all the requests of the batch are running
+		"wait": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			started <- struct{}{}
+			<-release
+			return "done", nil
+		}, ""),
+		"panic": NewRPCFunc(func(ctx *types.Context) (string, error) { panic("boom") }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), BatchConcurrency(n))
+	go func() {
+		for i := 0; i < n; i++ {
+			<-started
+		}
+		close(release)
+	}()
+
+	payload := `[
+		{"jsonrpc": "2.0", "method": "wait", "id": 0},
+		{"jsonrpc": "2.0", "method": "wait", "id": 1},
+		{"jsonrpc": "2.0", "method": "panic", "id": 2},
+		{"jsonrpc": "2.0", "method": "wait", "id": 3},
+		{"jsonrpc": "2.0", "method": "wait", "id": 4}
+	]`
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var responses []types.RPCResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&responses))
+	require.Len(t, responses, 5)
+	for _, response := range responses {
+		if response.ID == types.JSONRPCIntID(2) {
+			require.NotNil(t, response.Error)
+			assert.Contains(t, response.Error.Data, "boom")
+			continue
+		}
+		assert.Nil(t, response.Error)
+	}
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
@@ -131,6 +131,40 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) {
 	}
 }
 
+// writeRPCResponsesHTTP streams the responses of a batch to w as a JSON array,
+// writing each response as soon as it's received. Nothing is written if the
+// channel is closed without any response (all requests were notifications).
+//
+// Panics if it can't Marshal a response or write to w.
+func writeRPCResponsesHTTP(w http.ResponseWriter, responses <-chan types.RPCResponse) {
+	flusher, _ := w.(http.Flusher)
+	n := 0
+	for res := range responses {
+		jsonBytes, err := json.MarshalIndent(res, "  ", "  ")
+		if err != nil {
+			panic(err)
+		}
+		sep := ",\n  "
+		if n == 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(200)
+			sep = "[\n  "
+		}
+		if _, err := w.Write(append([]byte(sep), jsonBytes...)); err != nil {
+			panic(err)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		n++
+	}
+	if n > 0 {
+		if _, err := w.Write([]byte("\n]")); err != nil {
+			panic(err)
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 // RecoverAndLogHandler wraps an HTTP handler, adding error logging.
@@ -224,6 +258,13 @@ func (w *responseWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return w.ResponseWriter.(http.Hijacker).Hijack()
 }
 
+// implements http.Flusher
+func (w *responseWriterWrapper) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 type maxBytesHandler struct {
 	h http.Handler
 	n int64
@@ -18,7 +18,12 @@ import (
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(
+	funcName string,
+	rpcFunc *RPCFunc,
+	opts handlerOptions,
+	logger log.Logger,
+) func(http.ResponseWriter, *http.Request) {
 	// Always return -1 as there's no ID here.
 	dummyID := types.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -34,6 +39,12 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		if err := opts.rateLimiter.allow(rateClientOf(r), funcName); err != nil {
+			res, status := rateLimitError(types.RPCRequest{ID: dummyID}, err)
+			WriteRPCResponseHTTPError(w, status, res)
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -51,16 +62,18 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 		args = append(args, fnArgs...)
 
-		returns := rpcFunc.f.Call(args)
-
-		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
-		result, err := unreflectResult(returns)
+		result, etag, err := opts.call(funcName, rpcFunc, args)
+		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "result", result)
 		if err != nil {
 			WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
 				types.RPCInternalError(dummyID, err))
 			return
 		}
-		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(dummyID, result))
+		if etag != "" && opts.responseCache.setHeaders(w, r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		WriteRPCResponseHTTP(w, types.RPCResponse{JSONRPC: "2.0", ID: dummyID, Result: result})
 	}
 }
 
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of calls rejected by the rate limiter, by method and API key name
+	// (empty for clients identified by their IP address).
+	RateLimitedCalls metrics.Counter
+	// Number of cacheable calls answered from the response cache, by method.
+	ResponseCacheHits metrics.Counter
+	// Number of cacheable calls missing from the response cache, by method.
+	ResponseCacheMisses metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RateLimitedCalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_calls",
+			Help:      "Number of calls rejected by the rate limiter.",
+		}, append(labels, "method", "api_key")).With(labelsAndValues...),
+		ResponseCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_hits",
+			Help:      "Number of cacheable calls answered from the response cache.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		ResponseCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_misses",
+			Help:      "Number of cacheable calls missing from the response cache.",
+		}, append(labels, "method")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RateLimitedCalls:    discard.NewCounter(),
+		ResponseCacheHits:   discard.NewCounter(),
+		ResponseCacheMisses: discard.NewCounter(),
+	}
+}
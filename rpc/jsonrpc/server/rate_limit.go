@@ -0,0 +1,331 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const (
+	// APIKeyHeader is the HTTP header carrying the API key of a client.
+	APIKeyHeader = "X-API-Key"
+	// APIKeyParam is the query parameter carrying the API key of a client, for
+	// clients which can't set headers (e.g. browsers opening a websocket).
+	APIKeyParam = "api_key"
+
+	// buckets are pruned at most once per pruneInterval
+	pruneInterval = time.Minute
+)
+
+// ErrInvalidAPIKey is returned by RateLimiter.Allow for an unknown API key.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// Limit is the limit of a token bucket: a client may make up to Burst calls at
+// once, and Rate calls per second on average. A zero Rate means unlimited.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// ValidateBasic performs basic validation.
+func (l Limit) ValidateBasic() error {
+	if l.Rate < 0 {
+		return errors.New("rate can't be negative")
+	}
+	if l.Rate > 0 && l.Burst < 1 {
+		return errors.New("burst must be at least 1")
+	}
+	return nil
+}
+
+// APIKey identifies a client, which passes it in the X-API-Key header or the
+// api_key query parameter. The calls of the client are limited by the Limit of
+// its key instead of the limit of the RateLimiter, except for the routes with
+// their own limit (see RouteLimit), which still apply to the client with their
+// own bucket, unless Routes overrides them for the key.
+type APIKey struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	Limit
+	Routes map[string]Limit `json:"routes,omitempty"`
+}
+
+// LoadAPIKeys reads a JSON array of API keys from the given file.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(bz, &keys); err != nil {
+		return nil, fmt.Errorf("can't decode API keys from %s: %w", path, err)
+	}
+	seen := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("API key #%d (%q) is empty", i, key.Name)
+		}
+		if seen[key.Key] {
+			return nil, fmt.Errorf("API key #%d (%q) is duplicated", i, key.Name)
+		}
+		seen[key.Key] = true
+		if err := key.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("API key #%d (%q): %w", i, key.Name, err)
+		}
+		for route, limit := range key.Routes {
+			if err := limit.ValidateBasic(); err != nil {
+				return nil, fmt.Errorf("API key #%d (%q), limit of route %s: %w", i, key.Name, route, err)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// RateLimiter limits the calls of each client, identified by its API key or,
+// without one, by its IP address, using a token bucket per client.
+type RateLimiter struct {
+	limit    Limit            // limit of the calls of a client
+	routes   map[string]Limit // limits of the calls of a client to a route, instead of limit
+	keysFile string
+	metrics  *Metrics
+	now      func() time.Time
+
+	mtx       tmsync.Mutex
+	keys      map[string]APIKey
+	buckets   map[bucketID]*bucket
+	lastPrune time.Time
+}
+
+// NewRateLimiter returns a RateLimiter limiting the calls of each client to the
+// given limit. If an API keys file is given (see APIKeysFile), its keys are
+// loaded.
+func NewRateLimiter(limit Limit, options ...func(*RateLimiter)) (*RateLimiter, error) {
+	if err := limit.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	rl := &RateLimiter{
+		limit:   limit,
+		routes:  make(map[string]Limit),
+		metrics: NopMetrics(),
+		now:     time.Now,
+		keys:    make(map[string]APIKey),
+		buckets: make(map[bucketID]*bucket),
+	}
+	for _, option := range options {
+		option(rl)
+	}
+	for route, limit := range rl.routes {
+		if err := limit.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("limit of route %s: %w", route, err)
+		}
+	}
+	if rl.keysFile != "" {
+		if err := rl.ReloadAPIKeys(); err != nil {
+			return nil, err
+		}
+	}
+	return rl, nil
+}
+
+// RouteLimit sets the limit of the calls of a client to the given route,
+// instead of the limit of the RateLimiter. The calls to the route have their
+// own bucket.
+func RouteLimit(route string, limit Limit) func(*RateLimiter) {
+	return func(rl *RateLimiter) {
+		rl.routes[route] = limit
+	}
+}
+
+// APIKeysFile sets the file the API keys are loaded from (see LoadAPIKeys).
+func APIKeysFile(path string) func(*RateLimiter) {
+	return func(rl *RateLimiter) {
+		rl.keysFile = path
+	}
+}
+
+// RateLimiterMetrics sets the metrics.
+func RateLimiterMetrics(metrics *Metrics) func(*RateLimiter) {
+	return func(rl *RateLimiter) {
+		rl.metrics = metrics
+	}
+}
+
+// RateLimit limits the calls of each client with the given RateLimiter.
+// Defaults to nil (unlimited).
+func RateLimit(rl *RateLimiter) HandlerOption {
+	return func(o *handlerOptions) {
+		o.rateLimiter = rl
+	}
+}
+
+// ReloadAPIKeys reloads the API keys from the API keys file. On error, the
+// current keys are kept.
+func (rl *RateLimiter) ReloadAPIKeys() error {
+	if rl.keysFile == "" {
+		return errors.New("no API keys file")
+	}
+	keys, err := LoadAPIKeys(rl.keysFile)
+	if err != nil {
+		return err
+	}
+
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.keys = make(map[string]APIKey, len(keys))
+	for _, key := range keys {
+		rl.keys[key.Key] = key
+	}
+	// the limits of the keys may have changed
+	for id := range rl.buckets {
+		if id.apiKey {
+			delete(rl.buckets, id)
+		}
+	}
+	return nil
+}
+
+// Allow takes a token from the bucket of the client with the given IP address
+// and API key (optional) for the given method (route). It returns
+// ErrInvalidAPIKey if the API key is unknown, or an error if the limit is
+// exceeded.
+func (rl *RateLimiter) Allow(ip, apiKey, method string) error {
+	return rl.allow(rateClient{ip: ip, apiKey: apiKey}, method)
+}
+
+// allow takes a token from the bucket of the client for the given method. It
+// returns an error if the bucket is empty, or if the API key of the client is
+// unknown. A nil RateLimiter allows all calls.
+func (rl *RateLimiter) allow(client rateClient, method string) error {
+	if rl == nil {
+		return nil
+	}
+
+	now := rl.now()
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.prune(now)
+
+	var (
+		id    = bucketID{client: client.ip}
+		limit = rl.limit
+		key   APIKey
+	)
+	if client.apiKey != "" {
+		var ok bool
+		if key, ok = rl.keys[client.apiKey]; !ok {
+			return ErrInvalidAPIKey
+		}
+		id = bucketID{client: key.Key, apiKey: true}
+		limit = key.Limit
+	}
+	// the limit of a route also applies to the clients with an API key, unless
+	// their key overrides it
+	routeLimit, ok := key.Routes[method]
+	if !ok {
+		routeLimit, ok = rl.routes[method]
+	}
+	if ok {
+		id.route = method
+		limit = routeLimit
+	}
+	if limit.Rate == 0 {
+		return nil
+	}
+
+	b, ok := rl.buckets[id]
+	if !ok {
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		rl.buckets[id] = b
+	}
+	if !b.take(now) {
+		rl.metrics.RateLimitedCalls.With("method", method, "api_key", key.Name).Add(1)
+		return fmt.Errorf("rate limit of %v calls per second (burst %d) exceeded", limit.Rate, limit.Burst)
+	}
+	return nil
+}
+
+// prune removes the buckets which are full, as they are equivalent to new
+// ones.
+func (rl *RateLimiter) prune(now time.Time) {
+	if now.Sub(rl.lastPrune) < pruneInterval {
+		return
+	}
+	rl.lastPrune = now
+	for id, b := range rl.buckets {
+		if b.refill(now) >= float64(b.limit.Burst) {
+			delete(rl.buckets, id)
+		}
+	}
+}
+
+// rateClient identifies a client of the RPC.
+type rateClient struct {
+	ip     string
+	apiKey string
+}
+
+// rateClientOf returns the client making the request, identified by the
+// address of the connection (r.RemoteAddr): the clients of a reverse proxy
+// share its IP address, and X-Forwarded-For is ignored since clients can set
+// it.
+func rateClientOf(r *http.Request) rateClient {
+	apiKey := r.Header.Get(APIKeyHeader)
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get(APIKeyParam)
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return rateClient{ip: ip, apiKey: apiKey}
+}
+
+// rateLimitError returns the response to the request and the HTTP status code
+// for an error of RateLimiter.allow.
+func rateLimitError(request types.RPCRequest, err error) (types.RPCResponse, int) {
+	if errors.Is(err, ErrInvalidAPIKey) {
+		return types.RPCInvalidRequestError(request.ID, err), http.StatusUnauthorized
+	}
+	return types.RPCRateLimitedError(request.ID, err), http.StatusTooManyRequests
+}
+
+type bucketID struct {
+	client string // IP address or API key
+	apiKey bool
+	route  string // set for the routes with their own limit
+}
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill, up to the burst,
+// and returns the number of tokens.
+func (b *bucket) refill(now time.Time) float64 {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.limit.Rate
+		if b.tokens > float64(b.limit.Burst) {
+			b.tokens = float64(b.limit.Burst)
+		}
+		b.last = now
+	}
+	return b.tokens
+}
+
+// take takes a token, if there's one left.
+func (b *bucket) take(now time.Time) bool {
+	if b.refill(now) < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func writeAPIKeys(t *testing.T, path string, keys ...APIKey) {
+	bz, err := json.Marshal(keys)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, bz, 0600))
+}
+
+func TestRateLimiter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rate-limit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "api_keys.json")
+	writeAPIKeys(t, keysFile,
+		APIKey{Name: "explorer", Key: "secret", Limit: Limit{Rate: 10, Burst: 3}},
+		APIKey{Name: "trusted", Key: "trusted", Routes: map[string]Limit{"tx_search": {}}},
+	)
+
+	rl, err := NewRateLimiter(Limit{Rate: 1, Burst: 2},
+		RouteLimit("tx_search", Limit{Rate: 0.5, Burst: 1}),
+		RouteLimit("health", Limit{}),
+		APIKeysFile(keysFile),
+	)
+	require.NoError(t, err)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	alice := rateClient{ip: "1.1.1.1"}
+	bob := rateClient{ip: "2.2.2.2"}
+
+	// the bucket of a client holds up to burst tokens, shared by the routes
+	// without their own limit
+	assert.NoError(t, rl.allow(alice, "status"))
+	assert.NoError(t, rl.allow(alice, "block"))
+	assert.Error(t, rl.allow(alice, "status"))
+	assert.NoError(t, rl.allow(bob, "status"))
+
+	// routes with their own limit have their own bucket
+	assert.NoError(t, rl.allow(alice, "tx_search"))
+	assert.Error(t, rl.allow(alice, "tx_search"))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, rl.allow(alice, "health"))
+	}
+
+	// tokens are added at the rate, up to the burst
+	now = now.Add(time.Second)
+	assert.NoError(t, rl.allow(alice, "status"))
+	assert.Error(t, rl.allow(alice, "status"))
+	assert.Error(t, rl.allow(alice, "tx_search"))
+	now = now.Add(time.Second)
+	assert.NoError(t, rl.allow(alice, "tx_search"))
+
+	// the limit of an API key applies to the routes without their own limit
+	explorer := rateClient{ip: "1.1.1.1", apiKey: "secret"}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, rl.allow(explorer, "status"))
+	}
+	assert.Error(t, rl.allow(explorer, "block"))
+	// the limits of the routes apply to the keys too, with their own bucket,
+	// unless the key overrides them
+	assert.NoError(t, rl.allow(explorer, "tx_search"))
+	assert.Error(t, rl.allow(explorer, "tx_search"))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, rl.allow(rateClient{apiKey: "trusted"}, "status"))
+		assert.NoError(t, rl.allow(rateClient{apiKey: "trusted"}, "tx_search"))
+	}
+	err = rl.allow(rateClient{ip: "1.1.1.1", apiKey: "unknown"}, "status")
+	assert.Equal(t, ErrInvalidAPIKey, err)
+
+	// full buckets are pruned
+	now = now.Add(pruneInterval)
+	assert.NoError(t, rl.allow(bob, "status"))
+	assert.Len(t, rl.buckets, 1)
+
+	// reloading the keys replaces them, and an invalid file is rejected
+	writeAPIKeys(t, keysFile, APIKey{Name: "explorer", Key: "rotated", Limit: Limit{Rate: 1, Burst: 1}})
+	require.NoError(t, rl.ReloadAPIKeys())
+	assert.Equal(t, ErrInvalidAPIKey, rl.allow(explorer, "status"))
+	assert.NoError(t, rl.allow(rateClient{apiKey: "rotated"}, "status"))
+
+	writeAPIKeys(t, keysFile, APIKey{Name: "explorer", Key: "rotated", Limit: Limit{Rate: 1}})
+	assert.Error(t, rl.ReloadAPIKeys())
+	assert.Error(t, rl.allow(rateClient{apiKey: "rotated"}, "status"))
+}
+
+func TestRPCRateLimit(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	rl, err := NewRateLimiter(Limit{Rate: 0.001, Burst: 1})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), RateLimit(rl))
+
+	do := func(req *http.Request) (*http.Response, types.RPCResponse) {
+		req.RemoteAddr = "1.2.3.4:5678"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+		var response types.RPCResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		return res, response
+	}
+
+	req := httptest.NewRequest("GET", "http://localhost/c", nil)
+	res, response := do(req)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Nil(t, response.Error)
+
+	// the bucket is shared by the URI and JSON-RPC calls
+	req = httptest.NewRequest("GET", "http://localhost/c", nil)
+	res, response = do(req)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32001, response.Error.Code)
+
+	req = httptest.NewRequest("POST", "http://localhost/",
+		strings.NewReader(`{"jsonrpc": "2.0", "method": "c", "id": 0}`))
+	_, response = do(req)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32001, response.Error.Code)
+
+	// unknown API keys are rejected
+	req = httptest.NewRequest("GET", "http://localhost/c?api_key=unknown", nil)
+	res, response = do(req)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	require.NotNil(t, response.Error)
+	assert.Contains(t, response.Error.Data, "invalid API key")
+}
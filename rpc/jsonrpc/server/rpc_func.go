@@ -12,15 +12,43 @@ import (
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
-// every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+// every RPCResponse. The requests are handled with the given options (see
+// MaxBatchSize, MaxBatchCost, BatchConcurrency, RateLimit and ResponseCaching).
+func RegisterRPCFuncs(
+	mux *http.ServeMux,
+	funcMap map[string]*RPCFunc,
+	logger log.Logger,
+	options ...HandlerOption,
+) {
+	opts := newHandlerOptions(options...)
+
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, opts, logger))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, opts, logger)))
+}
+
+// handlerOptions are the options of the HTTP and WebSocket handlers.
+type handlerOptions struct {
+	maxBatchSize     int            // max number of requests in a batch, 0 - unlimited
+	maxBatchCost     int            // max total cost of the requests in a batch, 0 - unlimited
+	batchConcurrency int            // number of requests of a batch executed concurrently
+	rateLimiter      *RateLimiter   // limits the calls of each client, nil - unlimited
+	responseCache    *ResponseCache // caches immutable results, nil - no caching
+}
+
+// HandlerOption sets an option of the HTTP and WebSocket handlers.
+type HandlerOption func(*handlerOptions)
+
+func newHandlerOptions(options ...HandlerOption) handlerOptions {
+	opts := handlerOptions{batchConcurrency: 1}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
 }
 
 // Function introspection
@@ -32,31 +60,68 @@ type RPCFunc struct {
 	returns  []reflect.Type // type of each return arg
 	argNames []string       // name of each argument
 	ws       bool           // websocket only
+	cost     int            // cost of a call within a batch
+
+	// index of the height argument of cacheable functions, -1 if not cacheable
+	cacheHeightArg int
 }
 
 // NewRPCFunc wraps a function for introspection.
 // f is the function, args are comma separated argument names
-func NewRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, false)
+func NewRPCFunc(f interface{}, args string, options ...func(*RPCFunc)) *RPCFunc {
+	return newRPCFunc(f, args, false, options)
 }
 
 // NewWSRPCFunc wraps a function for introspection and use in the websockets.
-func NewWSRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, true)
+func NewWSRPCFunc(f interface{}, args string, options ...func(*RPCFunc)) *RPCFunc {
+	return newRPCFunc(f, args, true, options)
 }
 
-func newRPCFunc(f interface{}, args string, ws bool) *RPCFunc {
+// Cost sets the cost of a call to the function within a batch of requests,
+// whose total cost is limited by MaxBatchCost. Defaults to 1.
+func Cost(cost int) func(*RPCFunc) {
+	return func(f *RPCFunc) {
+		f.cost = cost
+	}
+}
+
+// Cacheable marks the calls to the function as immutable when the given height
+// argument, of type *int64, is below the latest height, so that their results
+// can be cached (see ResponseCache).
+func Cacheable(heightArg string) func(*RPCFunc) {
+	return func(f *RPCFunc) {
+		for i, name := range f.argNames {
+			if name == heightArg {
+				if f.args[i+1] != reflect.TypeOf((*int64)(nil)) {
+					panic(fmt.Sprintf("cacheable height argument %s must be a *int64", heightArg))
+				}
+				f.cacheHeightArg = i
+				return
+			}
+		}
+		panic(fmt.Sprintf("no cacheable height argument %s", heightArg))
+	}
+}
+
+func newRPCFunc(f interface{}, args string, ws bool, options []func(*RPCFunc)) *RPCFunc {
 	var argNames []string
 	if args != "" {
 		argNames = strings.Split(args, ",")
 	}
-	return &RPCFunc{
+	rpcFunc := &RPCFunc{
 		f:        reflect.ValueOf(f),
 		args:     funcArgTypes(f),
 		returns:  funcReturnTypes(f),
 		argNames: argNames,
 		ws:       ws,
+		cost:     1,
+
+		cacheHeightArg: -1,
+	}
+	for _, option := range options {
+		option(rpcFunc)
 	}
+	return rpcFunc
 }
 
 // return a function's argument types
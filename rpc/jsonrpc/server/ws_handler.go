@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -86,6 +87,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.client = rateClientOf(r)
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -136,6 +138,11 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// options of the handler, as for HTTP
+	handlerOptions handlerOptions
+	// client identity for the rate limiter
+	client rateClient
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -160,6 +167,7 @@ func newWSConnection(
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
 		readRoutineQuit:   make(chan struct{}),
+		handlerOptions:    newHandlerOptions(),
 	}
 	for _, option := range options {
 		option(wsc)
@@ -217,6 +225,16 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// WSHandlerOptions sets the options of the handler, such as the limits of the
+// requests and their batches (see MaxBatchSize, MaxBatchCost, BatchConcurrency
+// and RateLimit).
+// It should only be used in the constructor - not Goroutine-safe.
+func WSHandlerOptions(options ...HandlerOption) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.handlerOptions = newHandlerOptions(options...)
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
@@ -334,8 +352,8 @@ func (wsc *wsConnection) readRoutine() {
 			}
 
 			dec := json.NewDecoder(r)
-			var request types.RPCRequest
-			err = dec.Decode(&request)
+			var msg json.RawMessage
+			err = dec.Decode(&msg)
 			if err != nil {
 				if err := wsc.WriteRPCResponse(writeCtx,
 					types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))); err != nil {
@@ -344,60 +362,104 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				wsc.Logger.Debug(
-					"WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
-				continue
-			}
-
-			// Now, fetch the RPCFunc and execute it.
-			rpcFunc := wsc.funcMap[request.Method]
-			if rpcFunc == nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCMethodNotFoundError(request.ID)); err != nil {
-					wsc.Logger.Error("Error writing RPC response", "err", err)
-				}
-				continue
-			}
-
-			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
+			// A batch of requests is answered with one message per response, in the
+			// order they are ready.
+			if bytes.HasPrefix(bytes.TrimSpace(msg), []byte("[")) {
+				var requests []types.RPCRequest
+				if err := json.Unmarshal(msg, &requests); err != nil {
 					if err := wsc.WriteRPCResponse(writeCtx,
-						types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					); err != nil {
+						types.RPCParseError(fmt.Errorf("error unmarshaling requests: %w", err))); err != nil {
 						wsc.Logger.Error("Error writing RPC response", "err", err)
 					}
 					continue
 				}
-				args = append(args, fnArgs...)
+				// a rejected batch is answered with an error for each request, so
+				// that clients can tell which batch it was
+				if err := wsc.handlerOptions.checkBatch(requests, wsc.funcMap); err != nil {
+					for _, request := range requests {
+						if request.ID == nil {
+							continue
+						}
+						if err := wsc.WriteRPCResponse(writeCtx, types.RPCInvalidRequestError(request.ID, err)); err != nil {
+							wsc.Logger.Error("Error writing RPC response", "err", err)
+						}
+					}
+					continue
+				}
+				for res := range wsc.handlerOptions.runBatch(requests, wsc.call, wsc.Logger) {
+					if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
+						wsc.Logger.Error("Error writing RPC response", "err", err)
+					}
+				}
+				continue
 			}
 
-			returns := rpcFunc.f.Call(args)
-
-			// TODO: Need to encode args/returns to string if we want to log them
-			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
-
-			result, err := unreflectResult(returns)
-			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+			var request types.RPCRequest
+			if err := json.Unmarshal(msg, &request); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx,
+					types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
 			}
-
-			if err := wsc.WriteRPCResponse(writeCtx, types.NewRPCSuccessResponse(request.ID, result)); err != nil {
-				wsc.Logger.Error("Error writing RPC response", "err", err)
+			if res := wsc.call(request); res != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, *res); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
 			}
 		}
 	}
 }
 
+// call calls the function of the given request and returns its response, or
+// nil if the request is a notification.
+func (wsc *wsConnection) call(request types.RPCRequest) *types.RPCResponse {
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		wsc.Logger.Debug(
+			"WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return nil
+	}
+
+	// Now, fetch the RPCFunc and execute it.
+	var res types.RPCResponse
+	rpcFunc := wsc.funcMap[request.Method]
+	if rpcFunc == nil {
+		res = types.RPCMethodNotFoundError(request.ID)
+		return &res
+	}
+	if err := wsc.handlerOptions.rateLimiter.allow(wsc.client, request.Method); err != nil {
+		res, _ = rateLimitError(request, err)
+		return &res
+	}
+
+	ctx := &types.Context{JSONReq: &request, WSConn: wsc}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			res = types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err))
+			return &res
+		}
+		args = append(args, fnArgs...)
+	}
+
+	result, _, err := wsc.handlerOptions.call(request.Method, rpcFunc, args)
+
+	// TODO: Need to encode args/returns to string if we want to log them
+	wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+
+	if err != nil {
+		res = types.RPCInternalError(request.ID, err)
+		return &res
+	}
+	res = types.RPCResponse{JSONRPC: "2.0", ID: request.ID, Result: result}
+	return &res
+}
+
 // receives on a write channel and writes out on the socket
 func (wsc *wsConnection) writeRoutine() {
 	pingTicker := time.NewTicker(wsc.pingPeriod)
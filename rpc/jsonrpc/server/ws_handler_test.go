@@ -42,11 +42,51 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	dialResp.Body.Close()
 }
 
-func newWSServer() *httptest.Server {
+func TestWebsocketManagerHandlerBatch(t *testing.T) {
+	s := newWSServer(WSHandlerOptions(MaxBatchSize(2)))
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+
+	batch := func(ids ...int) []types.RPCRequest {
+		requests := make([]types.RPCRequest, len(ids))
+		for i, id := range ids {
+			req, err := types.MapToRequest(types.JSONRPCIntID(id), "c", map[string]interface{}{"s": "a", "i": 10})
+			require.NoError(t, err)
+			requests[i] = req
+		}
+		return requests
+	}
+
+	// each response is sent in its own message
+	require.NoError(t, c.WriteJSON(batch(1, 2)))
+	ids := make([]interface{}, 0, 2)
+	for i := 0; i < 2; i++ {
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		require.Nil(t, resp.Error)
+		ids = append(ids, resp.ID)
+	}
+	require.ElementsMatch(t, []interface{}{types.JSONRPCIntID(1), types.JSONRPCIntID(2)}, ids)
+
+	// a batch exceeding the limits is rejected with an error per request
+	require.NoError(t, c.WriteJSON(batch(3, 4, 5)))
+	for i := 0; i < 3; i++ {
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, types.JSONRPCIntID(3+i), resp.ID)
+	}
+}
+
+func newWSServer(options ...func(*wsConnection)) *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
 	}
-	wm := NewWebsocketManager(funcMap)
+	wm := NewWebsocketManager(funcMap, options...)
 	wm.SetLogger(log.TestingLogger())
 
 	mux := http.NewServeMux()
@@ -240,6 +240,10 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+func RPCRateLimitedError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32001, "Rate limit exceeded", err.Error())
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
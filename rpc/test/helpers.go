@@ -99,6 +99,8 @@ func createConfig() *cfg.Config {
 	c.RPC.ListenAddress = rpc
 	c.RPC.CORSAllowedOrigins = []string{"https://tendermint.com/"}
 	c.RPC.GRPCListenAddress = grpc
+	// the test node publishes many events per second
+	c.RPC.EventHistorySize = 10000
 	return c
 }
 
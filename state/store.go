@@ -64,6 +64,8 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *tmstate.ABCIResponses) error
+	// SaveValidatorSets saves the validator set for a range of heights it was unchanged over
+	SaveValidatorSets(int64, int64, *types.ValidatorSet) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
@@ -455,6 +457,26 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// SaveValidatorSets saves the validator set for the heights from lowerHeight
+// to upperHeight (inclusive), over which it was unchanged, with the proposer
+// priorities as of lowerHeight. It is used e.g. by state sync to backfill the
+// validator sets needed to verify evidence and serve light clients.
+func (store dbStore) SaveValidatorSets(lowerHeight, upperHeight int64, vals *types.ValidatorSet) error {
+	if lowerHeight <= 0 || lowerHeight > upperHeight {
+		return fmt.Errorf("invalid height range %v-%v", lowerHeight, upperHeight)
+	}
+	for height := lowerHeight; height <= upperHeight; height++ {
+		valSet := vals
+		if height != lowerHeight && height%valSetCheckpointInterval == 0 {
+			valSet = vals.CopyIncrementProposerPriority(tmmath.SafeConvertInt32(height - lowerHeight))
+		}
+		if err := store.saveValidatorsInfo(height, lowerHeight, valSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -46,6 +46,40 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestStoreSaveValidatorSets(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	vals, _ := types.RandValidatorSet(3, 10)
+
+	require.Error(t, stateStore.SaveValidatorSets(0, 2, vals))
+	require.Error(t, stateStore.SaveValidatorSets(3, 2, vals))
+
+	// the validator set is loaded with the proposer priorities of each height
+	require.NoError(t, stateStore.SaveValidatorSets(1, 4, vals))
+	for height := int64(1); height <= 4; height++ {
+		loadedVals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		assert.Equal(t, incrementedVals(vals, height-1), loadedVals)
+	}
+	_, err := stateStore.LoadValidators(5)
+	require.Error(t, err)
+
+	// including at checkpoint heights
+	lower := int64(sm.ValSetCheckpointInterval - 2)
+	require.NoError(t, stateStore.SaveValidatorSets(lower, lower+3, vals))
+	for height := lower; height <= lower+3; height++ {
+		loadedVals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		assert.Equal(t, incrementedVals(vals, height-lower), loadedVals)
+	}
+}
+
+func incrementedVals(vals *types.ValidatorSet, times int64) *types.ValidatorSet {
+	if times == 0 {
+		return vals.Copy()
+	}
+	return vals.CopyIncrementProposerPriority(int32(times))
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -0,0 +1,272 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// backfillFetchers is the number of light blocks fetched concurrently while
+	// backfilling.
+	backfillFetchers = 4
+	// backfillMaxRetries is the number of times fetching a light block is
+	// attempted before giving up on the backfill.
+	backfillMaxRetries = 20
+	// backfillRetryInterval is the time to wait before retrying to fetch a
+	// light block, e.g. after a peer didn't have it.
+	backfillRetryInterval = time.Second
+	// backfillBusyInterval is the time to wait for a peer to become available
+	// when all peers are busy serving other requests.
+	backfillBusyInterval = 50 * time.Millisecond
+)
+
+// Backfill fetches the light blocks below the state's last block from peers,
+// going backwards, verifies their hash chain and commits, and saves their
+// headers, commits and validator sets into the block and state stores. This
+// allows a node that was state synced to serve light clients and verify
+// evidence for historical heights. It stops once both the given number of
+// blocks and duration before the last block have been covered, or the initial
+// height is reached. It is a noop if neither is set.
+func (r *Reactor) Backfill(state sm.State, blocks int64, duration time.Duration) error {
+	if blocks <= 0 && duration <= 0 {
+		return nil
+	}
+	if r.blockStore == nil || r.stateStore == nil {
+		return errors.New("backfill requires a block store and state store")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stopHeight := state.LastBlockHeight - blocks
+	stopTime := state.LastBlockTime.Add(-duration)
+	r.Logger.Info("Starting backfill", "height", state.LastBlockHeight, "stop_height", stopHeight,
+		"stop_time", stopTime)
+
+	return r.backfill(ctx, state.ChainID, state.LastBlockHeight, state.InitialHeight, stopHeight,
+		stopTime, state.LastBlockID)
+}
+
+// backfill backfills light blocks from startHeight down to stopHeight and
+// stopTime, or initialHeight. The block at startHeight must have the trusted
+// block ID, which the hash chain is verified from.
+func (r *Reactor) backfill(
+	ctx context.Context,
+	chainID string,
+	startHeight, initialHeight, stopHeight int64,
+	stopTime time.Time,
+	trustedBlockID types.BlockID,
+) (err error) {
+	var (
+		vals                 *types.ValidatorSet // validator set of the current run of heights
+		valsLower, valsUpper int64               // heights the validator set was unchanged over
+		backfilled           int64
+	)
+
+	// Validator sets are saved once they change, or when we're done.
+	saveVals := func() error {
+		if vals == nil {
+			return nil
+		}
+		if err := r.stateStore.SaveValidatorSets(valsLower, valsUpper, vals); err != nil {
+			return fmt.Errorf("failed to save validator sets at heights %v-%v: %w", valsLower, valsUpper, err)
+		}
+		vals = nil
+		return nil
+	}
+	defer func() {
+		if saveErr := saveVals(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}()
+
+	for height := startHeight; height >= initialHeight; {
+		batch := r.fetchBackfillBatch(ctx, chainID, height, initialHeight)
+
+		for _, fetched := range batch {
+			lb := fetched.lightBlock
+			if fetched.err == nil {
+				if err := verifyBackfillBlock(chainID, lb, trustedBlockID); err != nil {
+					r.Logger.Info("Peer sent invalid light block, refetching", "height", height,
+						"peer", fetched.peer.String(), "err", err)
+					r.sendLightBlockPeerError(fetched.peer, err, p2p.PeerErrorSeverityHigh)
+					lb, fetched.err = r.refetchBackfillBlock(ctx, chainID, height, trustedBlockID)
+				}
+			}
+			if fetched.err != nil {
+				return fmt.Errorf("failed to backfill light block at height %v: %w", height, fetched.err)
+			}
+
+			if err := r.blockStore.SaveSignedHeader(lb.SignedHeader, trustedBlockID); err != nil {
+				return fmt.Errorf("failed to save signed header at height %v: %w", height, err)
+			}
+			if vals != nil && !bytes.Equal(vals.Hash(), lb.ValidatorSet.Hash()) {
+				if err := saveVals(); err != nil {
+					return err
+				}
+			}
+			if vals == nil {
+				valsUpper = height
+			}
+			vals, valsLower = lb.ValidatorSet, height
+			trustedBlockID = lb.LastBlockID
+			backfilled++
+
+			if height <= stopHeight && !lb.Time.After(stopTime) {
+				r.Logger.Info("Backfill complete", "height", height, "blocks", backfilled)
+				return nil
+			}
+			height--
+		}
+	}
+
+	r.Logger.Info("Backfill complete, reached initial height", "height", initialHeight, "blocks", backfilled)
+	return nil
+}
+
+// backfillResult is the result of fetching a light block while backfilling.
+type backfillResult struct {
+	lightBlock *types.LightBlock
+	peer       p2p.PeerID
+	err        error
+}
+
+// fetchBackfillBatch concurrently fetches up to backfillFetchers light blocks
+// going backwards from height, but not below initialHeight.
+func (r *Reactor) fetchBackfillBatch(
+	ctx context.Context,
+	chainID string,
+	height, initialHeight int64,
+) []backfillResult {
+	size := int64(backfillFetchers)
+	if height-initialHeight+1 < size {
+		size = height - initialHeight + 1
+	}
+
+	batch := make([]backfillResult, size)
+	wg := sync.WaitGroup{}
+	for i := int64(0); i < size; i++ {
+		wg.Add(1)
+		go func(i int64) {
+			defer wg.Done()
+			lb, peer, err := r.fetchBackfillBlock(ctx, chainID, height-i)
+			batch[i] = backfillResult{lightBlock: lb, peer: peer, err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	return batch
+}
+
+// refetchBackfillBlock fetches a light block again after a peer sent an
+// invalid one, until it can be verified against the trusted block ID.
+func (r *Reactor) refetchBackfillBlock(
+	ctx context.Context,
+	chainID string,
+	height int64,
+	trustedBlockID types.BlockID,
+) (*types.LightBlock, error) {
+	for attempt := 1; ; attempt++ {
+		lb, peer, err := r.fetchBackfillBlock(ctx, chainID, height)
+		if err != nil {
+			return nil, err
+		}
+		err = verifyBackfillBlock(chainID, lb, trustedBlockID)
+		if err == nil {
+			return lb, nil
+		}
+		r.sendLightBlockPeerError(peer, err, p2p.PeerErrorSeverityHigh)
+		if attempt >= backfillMaxRetries {
+			return nil, fmt.Errorf("no valid light block after %v attempts: %w", attempt, err)
+		}
+	}
+}
+
+// fetchBackfillBlock fetches a basically valid light block from any peer,
+// retrying if peers don't have it or fail to respond.
+func (r *Reactor) fetchBackfillBlock(
+	ctx context.Context,
+	chainID string,
+	height int64,
+) (*types.LightBlock, p2p.PeerID, error) {
+	var lastErr error
+	for attempt := 1; ; {
+		lb, peer, err := r.dispatcher.LightBlock(ctx, height)
+		switch {
+		case ctx.Err() != nil:
+			return nil, nil, ctx.Err()
+
+		case errors.Is(err, errPeersBusy):
+			// Don't count this as an attempt, since another fetcher is using the peers.
+			select {
+			case <-time.After(backfillBusyInterval):
+				continue
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+
+		case err != nil:
+			lastErr = err
+
+		case lb == nil:
+			lastErr = fmt.Errorf("peer %v doesn't have light block", peer)
+
+		default:
+			err = lb.ValidateBasic(chainID)
+			if err == nil {
+				return lb, peer, nil
+			}
+			lastErr = fmt.Errorf("invalid light block from peer %v: %w", peer, err)
+			r.sendLightBlockPeerError(peer, err, p2p.PeerErrorSeverityHigh)
+		}
+
+		r.Logger.Debug("Failed to fetch light block", "height", height, "attempt", attempt, "err", lastErr)
+		if attempt >= backfillMaxRetries {
+			return nil, nil, fmt.Errorf("giving up after %v attempts: %w", attempt, lastErr)
+		}
+		attempt++
+
+		select {
+		case <-time.After(backfillRetryInterval):
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+}
+
+// verifyBackfillBlock verifies that a light block has the trusted block ID,
+// and that its commit is signed by its validator set.
+func verifyBackfillBlock(chainID string, lb *types.LightBlock, trustedBlockID types.BlockID) error {
+	if !bytes.Equal(lb.Hash(), trustedBlockID.Hash) {
+		return fmt.Errorf("expected light block hash %X, got %X", trustedBlockID.Hash, lb.Hash())
+	}
+	return lb.ValidatorSet.VerifyCommitLight(chainID, trustedBlockID, lb.Height, lb.Commit)
+}
+
+// sendLightBlockPeerError reports a peer on the light block channel, unless the
+// reactor is stopping.
+func (r *Reactor) sendLightBlockPeerError(peer p2p.PeerID, err error, severity p2p.PeerErrorSeverity) {
+	select {
+	case r.blockCh.Error() <- p2p.PeerError{
+		PeerID:   peer,
+		Err:      err,
+		Severity: severity,
+	}:
+	case <-r.closeCh:
+	}
+}
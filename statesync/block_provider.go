@@ -0,0 +1,60 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ lightprovider.Provider = (*blockProvider)(nil)
+
+// blockProvider is a light client provider which fetches light blocks from a
+// single peer over the LightBlockChannel.
+type blockProvider struct {
+	chainID    string
+	peer       p2p.PeerID
+	dispatcher *dispatcher
+}
+
+// newBlockProvider creates a light client provider for a peer.
+func newBlockProvider(chainID string, peer p2p.PeerID, dispatcher *dispatcher) *blockProvider {
+	return &blockProvider{
+		chainID:    chainID,
+		peer:       peer,
+		dispatcher: dispatcher,
+	}
+}
+
+// LightBlock implements light/provider.Provider.
+func (p *blockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	lb, err := p.dispatcher.lightBlock(ctx, height, p.peer)
+	switch {
+	case errors.Is(err, errNoResponse), errors.Is(err, context.DeadlineExceeded):
+		return nil, lightprovider.ErrNoResponse
+	case err != nil:
+		return nil, err
+	case lb == nil:
+		return nil, lightprovider.ErrLightBlockNotFound
+	}
+
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence implements light/provider.Provider. Evidence of light client
+// attacks is not reported to peers, since a node can't submit evidence while
+// state syncing.
+func (p *blockProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (p *blockProvider) String() string {
+	return fmt.Sprintf("peer %v", p.peer)
+}
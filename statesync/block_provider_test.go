@@ -0,0 +1,58 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestBlockProvider_LightBlock(t *testing.T) {
+	blocks := mockLightBlocks(t, "test-chain", 3, time.Now())
+	peer := p2p.PeerID{0xAA}
+
+	requestCh := make(chan p2p.Envelope, 1)
+	d := newDispatcher(requestCh, make(chan p2p.Envelope, 1), time.Second)
+	d.addPeer(peer)
+
+	// Respond to each request with the light block at the requested height,
+	// if any, as part of the given chain.
+	respond := func(chainBlocks map[int64]*tmproto.LightBlock) {
+		envelope := <-requestCh
+		height := int64(envelope.Message.(*ssproto.LightBlockRequest).Height)
+		require.NoError(t, d.respond(chainBlocks[height], envelope.To))
+	}
+	pbs := make(map[int64]*tmproto.LightBlock, len(blocks))
+	for height, lb := range blocks {
+		pb, err := lb.ToProto()
+		require.NoError(t, err)
+		pbs[height] = pb
+	}
+
+	ctx := context.Background()
+
+	provider := newBlockProvider("test-chain", peer, d)
+	go respond(pbs)
+	lb, err := provider.LightBlock(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, blocks[2].Hash(), lb.Hash())
+
+	go respond(pbs)
+	_, err = provider.LightBlock(ctx, 5)
+	require.Equal(t, lightprovider.ErrLightBlockNotFound, err)
+
+	otherChain := newBlockProvider("other-chain", peer, d)
+	go respond(pbs)
+	_, err = otherChain.LightBlock(ctx, 2)
+	require.IsType(t, lightprovider.ErrBadLightBlock{}, err)
+
+	d.removePeer(peer)
+	_, err = provider.LightBlock(ctx, 2)
+	require.Equal(t, lightprovider.ErrNoResponse, err)
+}
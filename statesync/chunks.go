@@ -1,18 +1,39 @@
 package statesync
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tempfile"
 	"github.com/tendermint/tendermint/p2p"
 )
 
+const (
+	// chunkDirName is the name of the directory persistent chunk queues are stored in.
+	chunkDirName = "statesync"
+	// progressFileName is the name of the file persistent chunk queues record their snapshot in.
+	progressFileName = "progress.json"
+	// chunkLogFileName is the name of the file persistent chunk queues append chunk records to.
+	chunkLogFileName = "chunks.log"
+)
+
+// Operations recorded in the chunk log of a persistent chunk queue.
+const (
+	chunkFetched   = "fetched"
+	chunkApplied   = "applied"
+	chunkDiscarded = "discarded"
+	chunksReset    = "reset" // all applied chunks are to be applied again
+)
+
 // errDone is returned by chunkQueue.Next() when all chunks have been returned.
 var errDone = errors.New("chunk queue has completed")
 
@@ -36,7 +57,28 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]p2p.PeerID      // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkApplied   map[uint32]bool            // chunks applied to the app via MarkApplied()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
+	chunkLog       *os.File                   // if set, progress is recorded in dir for resumption
+}
+
+// chunkQueueProgress is the snapshot of a persistent chunk queue, recorded on disk along with
+// the chunk log so that the sync can be resumed after a restart.
+type chunkQueueProgress struct {
+	Height   uint64 `json:"height"`
+	Format   uint32 `json:"format"`
+	Chunks   uint32 `json:"chunks"`
+	Hash     []byte `json:"hash"`
+	Metadata []byte `json:"metadata"`
+}
+
+// chunkRecord records an operation on a chunk of a persistent chunk queue. Records are appended
+// to the chunk log, one JSON object per line, such that recording progress doesn't depend on the
+// number of chunks.
+type chunkRecord struct {
+	Op     string `json:"op"`
+	Index  uint32 `json:"index"`
+	Sender string `json:"sender,omitempty"` // only for chunkFetched
 }
 
 // newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
@@ -50,6 +92,170 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		return nil, errors.New("snapshot has no chunks")
 	}
 
+	return makeChunkQueue(snapshot, dir), nil
+}
+
+// newPersistentChunkQueue creates a new chunk queue for a snapshot, storing chunks and progress
+// in a statesync directory within dir, which replaces any previous one. The queue can be loaded
+// with loadChunkQueue() to resume the sync after a restart. Callers must call Close() when done.
+func newPersistentChunkQueue(snapshot *snapshot, dir string) (*chunkQueue, error) {
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	dir = filepath.Join(dir, chunkDirName)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clean up state sync dir %v: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create dir for state sync chunks: %w", err)
+	}
+
+	bz, err := tmjson.Marshal(chunkQueueProgress{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state sync progress: %w", err)
+	}
+	path := filepath.Join(dir, progressFileName)
+	if err := tempfile.WriteFileAtomic(path, bz, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save state sync progress to file %v: %w", path, err)
+	}
+
+	q := makeChunkQueue(snapshot, dir)
+	if q.chunkLog, err = openChunkLog(dir); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// openChunkLog opens the chunk log in dir for appending, creating it if necessary.
+func openChunkLog(dir string) (*os.File, error) {
+	path := filepath.Join(dir, chunkLogFileName)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state sync chunk log %v: %w", path, err)
+	}
+	return f, nil
+}
+
+// loadChunkQueue loads a persistent chunk queue from the statesync directory within dir, or
+// returns nil if there is none. Chunks that were applied to the app are not returned via Next()
+// unless they're retried. A partial record at the end of the chunk log, left by an interrupted
+// write, is dropped. The loaded snapshot has no trusted app hash, which must be set by the
+// caller. Callers must call Close() when done.
+func loadChunkQueue(dir string) (*chunkQueue, error) {
+	dir = filepath.Join(dir, chunkDirName)
+	bz, err := ioutil.ReadFile(filepath.Join(dir, progressFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load state sync progress: %w", err)
+	}
+
+	var progress chunkQueueProgress
+	if err := tmjson.Unmarshal(bz, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode state sync progress: %w", err)
+	}
+	if progress.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+
+	q := makeChunkQueue(&snapshot{
+		Height:   progress.Height,
+		Format:   progress.Format,
+		Chunks:   progress.Chunks,
+		Hash:     progress.Hash,
+		Metadata: progress.Metadata,
+	}, dir)
+
+	senders, applied, err := replayChunkLog(dir, progress.Chunks)
+	if err != nil {
+		return nil, err
+	}
+	for index, sender := range senders {
+		path := q.chunkPath(index)
+		if _, err := os.Stat(path); err != nil {
+			continue // the chunk will be fetched again
+		}
+
+		q.chunkFiles[index] = path
+		q.chunkSenders[index] = sender
+		q.chunkAllocated[index] = true
+		if applied[index] {
+			q.chunkApplied[index] = true
+			q.chunkReturned[index] = true
+		}
+	}
+
+	if q.chunkLog, err = openChunkLog(dir); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// replayChunkLog replays the chunk log in dir, returning the senders of the fetched chunks and
+// the applied chunks. A partial record at the end of the log is truncated.
+func replayChunkLog(dir string, chunks uint32) (map[uint32]p2p.PeerID, map[uint32]bool, error) {
+	path := filepath.Join(dir, chunkLogFileName)
+	bz, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to load state sync chunk log: %w", err)
+	}
+
+	var (
+		senders = make(map[uint32]p2p.PeerID)
+		applied = make(map[uint32]bool)
+		offset  int
+	)
+	for {
+		end := bytes.IndexByte(bz[offset:], '\n')
+		if end == -1 {
+			break
+		}
+		line := bz[offset : offset+end]
+		offset += end + 1
+
+		var record chunkRecord
+		if err := tmjson.Unmarshal(line, &record); err != nil {
+			return nil, nil, fmt.Errorf("invalid record in state sync chunk log: %w", err)
+		}
+		if record.Index >= chunks && record.Op != chunksReset {
+			return nil, nil, fmt.Errorf("invalid chunk %v in state sync chunk log", record.Index)
+		}
+		switch record.Op {
+		case chunkFetched:
+			sender, err := p2p.PeerIDFromString(record.Sender)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid sender for chunk %v in state sync chunk log: %w",
+					record.Index, err)
+			}
+			senders[record.Index] = sender
+		case chunkApplied:
+			applied[record.Index] = true
+		case chunkDiscarded:
+			delete(senders, record.Index)
+			delete(applied, record.Index)
+		case chunksReset:
+			applied = make(map[uint32]bool)
+		default:
+			return nil, nil, fmt.Errorf("invalid operation %q in state sync chunk log", record.Op)
+		}
+	}
+
+	if offset < len(bz) {
+		if err := os.Truncate(path, int64(offset)); err != nil {
+			return nil, nil, fmt.Errorf("failed to truncate state sync chunk log: %w", err)
+		}
+	}
+	return senders, applied, nil
+}
+
+// makeChunkQueue creates a chunk queue for a snapshot, storing chunks in dir.
+func makeChunkQueue(snapshot *snapshot, dir string) *chunkQueue {
 	return &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
@@ -57,8 +263,34 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]p2p.PeerID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkApplied:   make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
+}
+
+// chunkPath returns the path of the file a chunk is stored in.
+func (q *chunkQueue) chunkPath(index uint32) string {
+	return filepath.Join(q.dir, strconv.FormatUint(uint64(index), 10))
+}
+
+// record appends a record to the chunk log of a persistent chunk queue and syncs it to disk. The
+// caller must hold the mutex lock.
+func (q *chunkQueue) record(record chunkRecord) error {
+	if q.chunkLog == nil || q.snapshot == nil {
+		return nil
+	}
+
+	bz, err := tmjson.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode state sync chunk record: %w", err)
+	}
+	if _, err := q.chunkLog.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to save state sync chunk record: %w", err)
+	}
+	if err := q.chunkLog.Sync(); err != nil {
+		return fmt.Errorf("failed to save state sync chunk record: %w", err)
+	}
+	return nil
 }
 
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
@@ -86,7 +318,7 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 		return false, nil
 	}
 
-	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
+	path := q.chunkPath(chunk.Index)
 	err := ioutil.WriteFile(path, chunk.Chunk, 0600)
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
@@ -94,6 +326,9 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 
 	q.chunkFiles[chunk.Index] = path
 	q.chunkSenders[chunk.Index] = chunk.Sender
+	if err := q.record(chunkRecord{Op: chunkFetched, Index: chunk.Index, Sender: chunk.Sender.String()}); err != nil {
+		return false, err
+	}
 
 	// Signal any waiters that the chunk has arrived.
 	for _, waiter := range q.waiters[chunk.Index] {
@@ -148,6 +383,11 @@ func (q *chunkQueue) Close() error {
 	q.waiters = nil
 	q.snapshot = nil
 
+	if q.chunkLog != nil {
+		if err := q.chunkLog.Close(); err != nil {
+			return fmt.Errorf("failed to close state sync chunk log: %w", err)
+		}
+	}
 	if err := os.RemoveAll(q.dir); err != nil {
 		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
 	}
@@ -182,8 +422,9 @@ func (q *chunkQueue) discard(index uint32) error {
 	delete(q.chunkFiles, index)
 	delete(q.chunkReturned, index)
 	delete(q.chunkAllocated, index)
+	delete(q.chunkApplied, index)
 
-	return nil
+	return q.record(chunkRecord{Op: chunkDiscarded, Index: index})
 }
 
 // DiscardSender discards all *unreturned* chunks from a given sender. If the caller wants to
@@ -300,6 +541,40 @@ func (q *chunkQueue) nextUp() (uint32, error) {
 	return 0, errDone
 }
 
+// MarkApplied records that a chunk has been applied to the app, such that it is not returned via
+// Next() again if the sync is resumed.
+func (q *chunkQueue) MarkApplied(index uint32) error {
+	q.Lock()
+	defer q.Unlock()
+	if q.snapshot == nil || q.chunkFiles[index] == "" {
+		return nil
+	}
+	q.chunkApplied[index] = true
+	return q.record(chunkRecord{Op: chunkApplied, Index: index})
+}
+
+// Applied returns the number of chunks that have been applied to the app.
+func (q *chunkQueue) Applied() uint32 {
+	q.Lock()
+	defer q.Unlock()
+	return uint32(len(q.chunkApplied))
+}
+
+// AppliedChunks returns the indexes of the chunks that have been applied to the app, in order.
+func (q *chunkQueue) AppliedChunks() []uint32 {
+	q.Lock()
+	defer q.Unlock()
+	if len(q.chunkApplied) == 0 {
+		return nil
+	}
+	indexes := make([]uint32, 0, len(q.chunkApplied))
+	for index := range q.chunkApplied {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
 // Retry schedules a chunk to be retried, without refetching it.
 func (q *chunkQueue) Retry(index uint32) {
 	q.Lock()
@@ -307,11 +582,17 @@ func (q *chunkQueue) Retry(index uint32) {
 	delete(q.chunkReturned, index)
 }
 
-// RetryAll schedules all chunks to be retried, without refetching them.
-func (q *chunkQueue) RetryAll() {
+// RetryAll schedules all chunks to be retried, without refetching them. This also resets the
+// applied chunks, since the app will restore the snapshot from scratch.
+func (q *chunkQueue) RetryAll() error {
 	q.Lock()
 	defer q.Unlock()
 	q.chunkReturned = make(map[uint32]bool)
+	if len(q.chunkApplied) == 0 {
+		return nil
+	}
+	q.chunkApplied = make(map[uint32]bool)
+	return q.record(chunkRecord{Op: chunksReset})
 }
 
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
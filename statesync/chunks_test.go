@@ -3,6 +3,7 @@ package statesync
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,95 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestPersistentChunkQueue_Resume(t *testing.T) {
+	s := &snapshot{
+		Height:   3,
+		Format:   1,
+		Chunks:   5,
+		Hash:     []byte{7},
+		Metadata: []byte{8},
+	}
+	dir, err := ioutil.TempDir("", "persistentchunkqueue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// There's nothing to load before a queue has been created.
+	loaded, err := loadChunkQueue(dir)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	queue, err := newPersistentChunkQueue(s, dir)
+	require.NoError(t, err)
+	for i := uint32(0); i < 3; i++ {
+		_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: i, Chunk: []byte{3, 1, byte(i)},
+			Sender: p2p.PeerID{0xA0 + byte(i)}})
+		require.NoError(t, err)
+	}
+	c, err := queue.Next()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, c.Index)
+	require.NoError(t, queue.MarkApplied(0))
+	c, err = queue.Next()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, c.Index)
+
+	// The queue is loaded as it was left, without chunk 1 which wasn't applied yet.
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, s, loaded.snapshot)
+	assert.EqualValues(t, 1, loaded.Applied())
+	for i := uint32(0); i < 5; i++ {
+		assert.Equal(t, i < 3, loaded.Has(i))
+	}
+	assert.Equal(t, p2p.PeerID{0xA2}, loaded.GetSender(2))
+
+	// Only chunks 3 and 4 remain to be fetched.
+	index, err := loaded.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, index)
+
+	c, err = loaded.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1, 1}, Sender: p2p.PeerID{0xA1}}, c)
+
+	// Discarding and retrying chunks is recorded too.
+	require.NoError(t, loaded.Discard(2))
+	require.NoError(t, loaded.RetryAll())
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, loaded.Applied())
+	assert.True(t, loaded.Has(1))
+	assert.False(t, loaded.Has(2))
+
+	// A partial record left by an interrupted write is dropped, and later records are kept.
+	f, err := os.OpenFile(filepath.Join(dir, chunkDirName, chunkLogFileName), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"appl`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	require.NoError(t, loaded.MarkApplied(1))
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, loaded.AppliedChunks())
+
+	// Closing the queue removes it, and starting a new one replaces the previous one.
+	require.NoError(t, loaded.Close())
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	queue, err = newPersistentChunkQueue(&snapshot{Height: 4, Format: 1, Chunks: 1}, dir)
+	require.NoError(t, err)
+	loaded, err = loadChunkQueue(dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, loaded.snapshot.Height)
+	assert.False(t, loaded.Has(0))
+	require.NoError(t, queue.Close())
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -467,7 +557,7 @@ func TestChunkQueue_RetryAll(t *testing.T) {
 	_, err := queue.Next()
 	assert.Equal(t, errDone, err)
 
-	queue.RetryAll()
+	require.NoError(t, queue.RetryAll())
 
 	_, err = queue.Allocate()
 	assert.Equal(t, errDone, err)
@@ -0,0 +1,280 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	// errNoPeers is returned by the dispatcher when there are no peers to send
+	// a request to.
+	errNoPeers = errors.New("no available peers to dispatch request to")
+	// errPeersBusy is returned by the dispatcher when all peers already have a
+	// request in flight.
+	errPeersBusy = errors.New("all peers are busy")
+	// errNoResponse is returned by the dispatcher when a peer disconnects, or
+	// sends an invalid response, while a request to it is in flight.
+	errNoResponse = errors.New("peer disconnected or sent an invalid response")
+)
+
+// dispatcher sends light block and consensus params requests to peers and
+// matches them with their responses. Since responses for missing light blocks
+// don't carry a height, only one request of each kind per peer is in flight at
+// a time.
+type dispatcher struct {
+	requestCh chan<- p2p.Envelope
+	paramsCh  chan<- p2p.Envelope
+	timeout   time.Duration
+
+	mtx         tmsync.Mutex
+	peers       map[string]p2p.PeerID
+	calls       map[string]chan *types.LightBlock       // in-flight light block requests by peer
+	paramsCalls map[string]chan *ssproto.ParamsResponse // in-flight params requests by peer
+}
+
+// newDispatcher creates a new dispatcher, which sends light block requests on
+// requestCh and params requests on paramsCh, and gives up on responses after
+// timeout.
+func newDispatcher(requestCh, paramsCh chan<- p2p.Envelope, timeout time.Duration) *dispatcher {
+	return &dispatcher{
+		requestCh:   requestCh,
+		paramsCh:    paramsCh,
+		timeout:     timeout,
+		peers:       make(map[string]p2p.PeerID),
+		calls:       make(map[string]chan *types.LightBlock),
+		paramsCalls: make(map[string]chan *ssproto.ParamsResponse),
+	}
+}
+
+// addPeer makes a peer available for requests.
+func (d *dispatcher) addPeer(peer p2p.PeerID) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.peers[peer.String()] = peer
+}
+
+// removePeer removes a peer, failing any requests in flight to it.
+func (d *dispatcher) removePeer(peer p2p.PeerID) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	delete(d.peers, peer.String())
+	if call, ok := d.calls[peer.String()]; ok {
+		close(call)
+		delete(d.calls, peer.String())
+	}
+	if call, ok := d.paramsCalls[peer.String()]; ok {
+		close(call)
+		delete(d.paramsCalls, peer.String())
+	}
+}
+
+// Peers returns the peers available for requests.
+func (d *dispatcher) Peers() []p2p.PeerID {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	peers := make([]p2p.PeerID, 0, len(d.peers))
+	for _, peer := range d.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// LightBlock requests a light block from any idle peer, returning the light
+// block, or nil if the peer doesn't have it, along with the peer it came from.
+func (d *dispatcher) LightBlock(ctx context.Context, height int64) (*types.LightBlock, p2p.PeerID, error) {
+	d.mtx.Lock()
+	var (
+		peer p2p.PeerID
+		call chan *types.LightBlock
+	)
+	for key, p := range d.peers {
+		if _, busy := d.calls[key]; !busy {
+			peer, call = p, make(chan *types.LightBlock, 1)
+			d.calls[key] = call
+			break
+		}
+	}
+	numPeers := len(d.peers)
+	d.mtx.Unlock()
+
+	switch {
+	case numPeers == 0:
+		return nil, nil, errNoPeers
+	case peer == nil:
+		return nil, nil, errPeersBusy
+	}
+	lb, err := d.await(ctx, height, peer, call)
+	return lb, peer, err
+}
+
+// lightBlock requests a light block from a specific peer, returning the light
+// block, or nil if the peer doesn't have it. A height of 0 requests the peer's
+// latest light block.
+func (d *dispatcher) lightBlock(ctx context.Context, height int64, peer p2p.PeerID) (*types.LightBlock, error) {
+	d.mtx.Lock()
+	if _, ok := d.peers[peer.String()]; !ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("peer %v: %w", peer, errNoResponse)
+	}
+	if _, ok := d.calls[peer.String()]; ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("a request to peer %v is already in flight", peer)
+	}
+	call := make(chan *types.LightBlock, 1)
+	d.calls[peer.String()] = call
+	d.mtx.Unlock()
+
+	return d.await(ctx, height, peer, call)
+}
+
+// await sends a light block request to a peer, and waits for the response to
+// be delivered on call.
+func (d *dispatcher) await(
+	ctx context.Context,
+	height int64,
+	peer p2p.PeerID,
+	call chan *types.LightBlock,
+) (*types.LightBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	select {
+	case d.requestCh <- p2p.Envelope{
+		To:      peer,
+		Message: &ssproto.LightBlockRequest{Height: uint64(height)},
+	}:
+	case <-ctx.Done():
+		d.release(peer, call)
+		return nil, ctx.Err()
+	}
+
+	select {
+	case lb, ok := <-call:
+		if !ok {
+			return nil, errNoResponse
+		}
+		if lb != nil && height != 0 && lb.Height != height {
+			return nil, fmt.Errorf("peer sent light block at height %v, expected %v", lb.Height, height)
+		}
+		return lb, nil
+
+	case <-ctx.Done():
+		d.release(peer, call)
+		return nil, fmt.Errorf("light block request at height %v to peer %v: %w", height, peer, ctx.Err())
+	}
+}
+
+// release removes a request to a peer, if it is still in flight.
+func (d *dispatcher) release(peer p2p.PeerID, call chan *types.LightBlock) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.calls[peer.String()] == call {
+		delete(d.calls, peer.String())
+	}
+}
+
+// respond delivers a light block response from a peer to its request. It
+// errors if the response is unsolicited or the light block can't be decoded.
+func (d *dispatcher) respond(pb *tmproto.LightBlock, peer p2p.PeerID) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	call, ok := d.calls[peer.String()]
+	if !ok {
+		return fmt.Errorf("unsolicited light block response from peer %v", peer)
+	}
+	delete(d.calls, peer.String())
+
+	if pb == nil {
+		call <- nil
+		return nil
+	}
+	lb, err := types.LightBlockFromProto(pb)
+	if err != nil {
+		close(call)
+		return fmt.Errorf("invalid light block from peer %v: %w", peer, err)
+	}
+	call <- lb
+	return nil
+}
+
+// consensusParams requests the consensus params at a height from a specific
+// peer, returning the params, or nil if the peer doesn't have them.
+func (d *dispatcher) consensusParams(
+	ctx context.Context,
+	height int64,
+	peer p2p.PeerID,
+) (*tmproto.ConsensusParams, error) {
+	d.mtx.Lock()
+	if _, ok := d.peers[peer.String()]; !ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("peer %v: %w", peer, errNoResponse)
+	}
+	if _, ok := d.paramsCalls[peer.String()]; ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("a params request to peer %v is already in flight", peer)
+	}
+	call := make(chan *ssproto.ParamsResponse, 1)
+	d.paramsCalls[peer.String()] = call
+	d.mtx.Unlock()
+
+	release := func() {
+		d.mtx.Lock()
+		defer d.mtx.Unlock()
+		if d.paramsCalls[peer.String()] == call {
+			delete(d.paramsCalls, peer.String())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	select {
+	case d.paramsCh <- p2p.Envelope{
+		To:      peer,
+		Message: &ssproto.ParamsRequest{Height: uint64(height)},
+	}:
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+
+	select {
+	case msg, ok := <-call:
+		if !ok {
+			return nil, errNoResponse
+		}
+		if int64(msg.Height) != height {
+			return nil, fmt.Errorf("peer sent params at height %v, expected %v", msg.Height, height)
+		}
+		return msg.ConsensusParams, nil
+
+	case <-ctx.Done():
+		release()
+		return nil, fmt.Errorf("params request at height %v to peer %v: %w", height, peer, ctx.Err())
+	}
+}
+
+// respondParams delivers a consensus params response from a peer to its
+// request. It errors if the response is unsolicited.
+func (d *dispatcher) respondParams(msg *ssproto.ParamsResponse, peer p2p.PeerID) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	call, ok := d.paramsCalls[peer.String()]
+	if !ok {
+		return fmt.Errorf("unsolicited params response from peer %v", peer)
+	}
+	delete(d.paramsCalls, peer.String())
+
+	call <- msg
+	return nil
+}
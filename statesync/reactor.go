@@ -11,10 +11,15 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/light"
 	"github.com/tendermint/tendermint/p2p"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -48,6 +53,24 @@ var (
 				RecvMessageCapacity: chunkMsgSize,
 			},
 		},
+		LightBlockChannel: {
+			MsgType: new(ssproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(LightBlockChannel),
+				Priority:            2,
+				SendQueueCapacity:   10,
+				RecvMessageCapacity: lightBlockMsgSize,
+			},
+		},
+		ParamsChannel: {
+			MsgType: new(ssproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(ParamsChannel),
+				Priority:            2,
+				SendQueueCapacity:   10,
+				RecvMessageCapacity: paramMsgSize,
+			},
+		},
 	}
 )
 
@@ -58,6 +81,12 @@ const (
 	// ChunkChannel exchanges chunk contents
 	ChunkChannel = p2p.ChannelID(0x61)
 
+	// LightBlockChannel exchanges light blocks
+	LightBlockChannel = p2p.ChannelID(0x62)
+
+	// ParamsChannel exchanges consensus params
+	ParamsChannel = p2p.ChannelID(0x63)
+
 	// recentSnapshots is the number of recent snapshots to send and receive per peer.
 	recentSnapshots = 10
 
@@ -66,6 +95,24 @@ const (
 
 	// chunkMsgSize is the maximum size of a chunkResponseMessage
 	chunkMsgSize = int(16e6)
+
+	// lightBlockMsgSize is the maximum size of a lightBlockResponseMessage
+	lightBlockMsgSize = int(1e7)
+
+	// paramMsgSize is the maximum size of a paramsResponseMessage
+	paramMsgSize = int(1e5)
+
+	// lightBlockResponseTimeout is how long we wait for a peer to respond to a
+	// light block request
+	lightBlockResponseTimeout = 10 * time.Second
+
+	// minStateProviderPeers is the number of peers needed by the P2P state
+	// provider, to have a light client primary and at least one witness
+	minStateProviderPeers = 2
+
+	// peerWaitInterval is how often we check whether enough peers are connected
+	// for the P2P state provider
+	peerWaitInterval = time.Second
 )
 
 // Reactor handles state sync, both restoring snapshots for the local node and serving snapshots
@@ -75,12 +122,25 @@ type Reactor struct {
 
 	conn        proxy.AppConnSnapshot
 	connQuery   proxy.AppConnQuery
+	stateStore  sm.Store
+	blockStore  *store.BlockStore
 	tempDir     string
 	snapshotCh  *p2p.Channel
 	chunkCh     *p2p.Channel
+	blockCh     *p2p.Channel
+	paramsCh    *p2p.Channel
 	peerUpdates *p2p.PeerUpdatesCh
 	closeCh     chan struct{}
 
+	// Dispatches light block and params requests to peers, e.g. to backfill
+	// blocks or to verify snapshots without RPC servers.
+	dispatcher *dispatcher
+
+	// RPC servers to discover snapshots and fetch chunks from, see
+	// SetSnapshotServers.
+	snapshotServers     map[string]rpcclient.StateSyncClient
+	snapshotServersOnly bool
+
 	// This will only be set when a state sync is in progress. It is used to feed
 	// received snapshots and chunks into the sync.
 	mtx    tmsync.RWMutex
@@ -89,24 +149,35 @@ type Reactor struct {
 
 // NewReactor returns a reference to a new state sync reactor, which implements
 // the service.Service interface. It accepts a logger, connections for snapshots
-// and querying, references to p2p Channels and a channel to listen for peer
-// updates on. Note, the reactor will close all p2p Channels when stopping.
+// and querying, references to p2p Channels, a channel to listen for peer
+// updates on, and the state and block stores light blocks and consensus params
+// are served from, and light blocks are backfilled into. Snapshot chunks and sync progress are stored in a directory within
+// tempDir, such that an interrupted sync can be resumed; if tempDir is empty,
+// they're stored in a temporary directory and the sync can't be resumed. Note,
+// the reactor will close all p2p Channels when stopping.
 func NewReactor(
 	logger log.Logger,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
-	snapshotCh, chunkCh *p2p.Channel,
+	snapshotCh, chunkCh, blockCh, paramsCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdatesCh,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
 	tempDir string,
 ) *Reactor {
 	r := &Reactor{
 		conn:        conn,
 		connQuery:   connQuery,
+		stateStore:  stateStore,
+		blockStore:  blockStore,
 		snapshotCh:  snapshotCh,
 		chunkCh:     chunkCh,
+		blockCh:     blockCh,
+		paramsCh:    paramsCh,
 		peerUpdates: peerUpdates,
 		closeCh:     make(chan struct{}),
 		tempDir:     tempDir,
+		dispatcher:  newDispatcher(blockCh.Out(), paramsCh.Out(), lightBlockResponseTimeout),
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
@@ -130,6 +201,15 @@ func (r *Reactor) OnStart() error {
 	// have to deal with bounding workers or pools.
 	go r.processChunkCh()
 
+	// Listen for envelopes on the light block p2p Channel in a separate
+	// go-routine, such that light blocks can be served and backfilled while
+	// snapshots are restored.
+	go r.processLightBlockCh()
+
+	// Listen for envelopes on the params p2p Channel in a separate go-routine,
+	// such that consensus params can be served while light blocks are.
+	go r.processParamsCh()
+
 	go r.processPeerUpdates()
 
 	return nil
@@ -147,6 +227,8 @@ func (r *Reactor) OnStop() {
 	// panics will occur.
 	<-r.snapshotCh.Done()
 	<-r.chunkCh.Done()
+	<-r.blockCh.Done()
+	<-r.paramsCh.Done()
 	<-r.peerUpdates.Done()
 }
 
@@ -313,6 +395,88 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// handleLightBlockMessage handles envelopes sent from peers on the
+// LightBlockChannel. It returns an error if the Envelope.Message is unknown for
+// this channel, or if the peer sent an unsolicited or invalid light block. This
+// should never be called outside of handleMessage.
+func (r *Reactor) handleLightBlockMessage(envelope p2p.Envelope) error {
+	switch msg := envelope.Message.(type) {
+	case *ssproto.LightBlockRequest:
+		r.Logger.Debug("received light block request", "height", msg.Height, "peer", envelope.From.String())
+		lb, err := r.fetchLightBlock(msg.Height)
+		if err != nil {
+			r.Logger.Error("failed to fetch light block", "height", msg.Height, "err", err)
+			return nil
+		}
+
+		var pb *tmproto.LightBlock
+		if lb != nil {
+			pb, err = lb.ToProto()
+			if err != nil {
+				r.Logger.Error("failed to convert light block to proto", "height", msg.Height, "err", err)
+				return nil
+			}
+		}
+		r.blockCh.Out() <- p2p.Envelope{
+			To:      envelope.From,
+			Message: &ssproto.LightBlockResponse{LightBlock: pb},
+		}
+
+	case *ssproto.LightBlockResponse:
+		r.Logger.Debug("received light block response", "peer", envelope.From.String())
+		if err := r.dispatcher.respond(msg.LightBlock, envelope.From); err != nil {
+			r.Logger.Error("failed to handle light block response", "err", err, "peer", envelope.From.String())
+			return err
+		}
+
+	default:
+		r.Logger.Error("received unknown message", "msg", msg, "peer", envelope.From.String())
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+// handleParamsMessage handles envelopes sent from peers on the ParamsChannel.
+// It returns an error if the Envelope.Message is unknown for this channel, or
+// if the peer sent unsolicited params. This should never be called outside of
+// handleMessage.
+func (r *Reactor) handleParamsMessage(envelope p2p.Envelope) error {
+	switch msg := envelope.Message.(type) {
+	case *ssproto.ParamsRequest:
+		r.Logger.Debug("received consensus params request", "height", msg.Height, "peer", envelope.From.String())
+		var params *tmproto.ConsensusParams
+		if r.stateStore != nil {
+			cp, err := r.stateStore.LoadConsensusParams(int64(msg.Height))
+			if err == nil {
+				params = &cp
+			} else {
+				r.Logger.Debug("failed to load consensus params", "height", msg.Height, "err", err)
+			}
+		}
+		r.paramsCh.Out() <- p2p.Envelope{
+			To: envelope.From,
+			Message: &ssproto.ParamsResponse{
+				Height:          msg.Height,
+				ConsensusParams: params,
+			},
+		}
+
+	case *ssproto.ParamsResponse:
+		r.Logger.Debug("received consensus params response", "height", msg.Height, "peer", envelope.From.String())
+		if err := r.dispatcher.respondParams(msg, envelope.From); err != nil {
+			r.Logger.Error("failed to handle params response", "err", err, "peer", envelope.From.String())
+			return err
+		}
+
+	default:
+		r.Logger.Error("received unknown message", "msg", msg, "peer", envelope.From.String())
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
@@ -331,6 +495,12 @@ func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err
 	case ChunkChannel:
 		err = r.handleChunkMessage(envelope)
 
+	case LightBlockChannel:
+		err = r.handleLightBlockMessage(envelope)
+
+	case ParamsChannel:
+		err = r.handleParamsMessage(envelope)
+
 	default:
 		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
 	}
@@ -390,6 +560,54 @@ func (r *Reactor) processChunkCh() {
 	}
 }
 
+// processLightBlockCh initiates a blocking process where we listen for and
+// handle envelopes on the LightBlockChannel. Any error encountered during
+// message execution will result in a PeerError being sent on the
+// LightBlockChannel. When the reactor is stopped, we will catch the singal and
+// close the p2p Channel gracefully.
+func (r *Reactor) processLightBlockCh() {
+	defer r.blockCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.blockCh.In():
+			if err := r.handleMessage(r.blockCh.ID(), envelope); err != nil {
+				r.sendLightBlockPeerError(envelope.From, err, p2p.PeerErrorSeverityLow)
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on light block channel; closing...")
+			return
+		}
+	}
+}
+
+// processParamsCh initiates a blocking process where we listen for and handle
+// envelopes on the ParamsChannel. Any error encountered during message
+// execution will result in a PeerError being sent on the ParamsChannel. When
+// the reactor is stopped, we will catch the singal and close the p2p Channel
+// gracefully.
+func (r *Reactor) processParamsCh() {
+	defer r.paramsCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.paramsCh.In():
+			if err := r.handleMessage(r.paramsCh.ID(), envelope); err != nil {
+				r.paramsCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on params channel; closing...")
+			return
+		}
+	}
+}
+
 // processPeerUpdate processes a PeerUpdate, returning an error upon failing to
 // handle the PeerUpdate or if a panic is recovered.
 func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) (err error) {
@@ -402,6 +620,14 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) (err error) {
 
 	r.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
 
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		r.dispatcher.addPeer(peerUpdate.PeerID)
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		r.dispatcher.removePeer(peerUpdate.PeerID)
+	}
+
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
@@ -475,6 +701,26 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
+// SetSnapshotServers sets RPC servers to discover snapshots and fetch their
+// chunks from over HTTP during Sync, in addition to peers. If only is true,
+// chunks are never requested from peers. It must be called before Sync.
+func (r *Reactor) SetSnapshotServers(servers []string, only bool) error {
+	clients := make(map[string]rpcclient.StateSyncClient, len(servers))
+	for _, server := range servers {
+		client, err := rpcClient(server)
+		if err != nil {
+			return fmt.Errorf("failed to set up RPC client for %v: %w", server, err)
+		}
+		clients[server] = client
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.snapshotServers = clients
+	r.snapshotServersOnly = only
+	return nil
+}
+
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
@@ -485,6 +731,10 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 	}
 
 	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out(), r.chunkCh.Out(), r.tempDir)
+	for remote, client := range r.snapshotServers {
+		r.syncer.AddRPCSource(remote, client)
+	}
+	r.syncer.rpcSourcesOnly = r.snapshotServersOnly
 	r.mtx.Unlock()
 
 	// request snapshots from all currently connected peers
@@ -502,3 +752,87 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 
 	return state, commit, err
 }
+
+// P2PStateProvider creates a state provider which verifies snapshots using a
+// light client, with connected peers as primary and witnesses, fetching light
+// blocks and consensus params over the LightBlockChannel and ParamsChannel. It
+// waits until enough peers are connected, or the context is done or the
+// reactor stopped.
+func (r *Reactor) P2PStateProvider(
+	ctx context.Context,
+	chainID string,
+	version tmstate.Version,
+	initialHeight int64,
+	trustOptions light.TrustOptions,
+) (StateProvider, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(peerWaitInterval)
+	defer ticker.Stop()
+
+	peers := r.dispatcher.Peers()
+	if len(peers) < minStateProviderPeers {
+		r.Logger.Info("Waiting for peers to verify snapshots with", "peers", len(peers),
+			"required", minStateProviderPeers)
+	}
+	for len(peers) < minStateProviderPeers {
+		select {
+		case <-ticker.C:
+			peers = r.dispatcher.Peers()
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for peers: %w", ctx.Err())
+		}
+	}
+
+	return newP2PStateProvider(ctx, chainID, version, initialHeight, peers, r.dispatcher, trustOptions,
+		r.Logger.With("module", "light"))
+}
+
+// fetchLightBlock loads the light block at the given height, or the latest one
+// if height is 0, from the block and state stores, or returns nil if it isn't
+// available.
+func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
+	if r.blockStore == nil || r.stateStore == nil {
+		return nil, nil
+	}
+	h := int64(height)
+	if h == 0 {
+		h = r.blockStore.Height()
+	}
+
+	blockMeta := r.blockStore.LoadBlockMeta(h)
+	if blockMeta == nil {
+		return nil, nil
+	}
+
+	// The commit of the last block is only available as a seen commit, until
+	// the next block is committed.
+	commit := r.blockStore.LoadBlockCommit(h)
+	if commit == nil {
+		commit = r.blockStore.LoadSeenCommit(h)
+	}
+	if commit == nil {
+		return nil, nil
+	}
+
+	vals, err := r.stateStore.LoadValidators(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &blockMeta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}, nil
+}
@@ -6,13 +6,23 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light"
 	"github.com/tendermint/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/statesync/mocks"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 type reactorTestSuite struct {
@@ -33,7 +43,20 @@ type reactorTestSuite struct {
 	chunkOutCh     chan p2p.Envelope
 	chunkPeerErrCh chan p2p.PeerError
 
+	blockChannel   *p2p.Channel
+	blockInCh      chan p2p.Envelope
+	blockOutCh     chan p2p.Envelope
+	blockPeerErrCh chan p2p.PeerError
+
+	paramsChannel   *p2p.Channel
+	paramsInCh      chan p2p.Envelope
+	paramsOutCh     chan p2p.Envelope
+	paramsPeerErrCh chan p2p.PeerError
+
 	peerUpdates *p2p.PeerUpdatesCh
+
+	stateStore sm.Store
+	blockStore *store.BlockStore
 }
 
 func setup(
@@ -62,7 +85,15 @@ func setup(
 		chunkInCh:         make(chan p2p.Envelope, chBuf),
 		chunkOutCh:        make(chan p2p.Envelope, chBuf),
 		chunkPeerErrCh:    make(chan p2p.PeerError, chBuf),
-		peerUpdates:       p2p.NewPeerUpdates(),
+		blockInCh:         make(chan p2p.Envelope, chBuf),
+		blockOutCh:        make(chan p2p.Envelope, chBuf),
+		blockPeerErrCh:    make(chan p2p.PeerError, chBuf),
+		paramsInCh:        make(chan p2p.Envelope, chBuf),
+		paramsOutCh:       make(chan p2p.Envelope, chBuf),
+		paramsPeerErrCh:   make(chan p2p.PeerError, chBuf),
+		peerUpdates:       p2p.NewPeerUpdates(make(chan p2p.PeerUpdate)),
+		stateStore:        sm.NewStore(dbm.NewMemDB()),
+		blockStore:        store.NewBlockStore(dbm.NewMemDB()),
 		conn:              conn,
 		connQuery:         connQuery,
 		stateProvider:     stateProvider,
@@ -84,13 +115,33 @@ func setup(
 		rts.chunkPeerErrCh,
 	)
 
+	rts.blockChannel = p2p.NewChannel(
+		LightBlockChannel,
+		new(ssproto.Message),
+		rts.blockInCh,
+		rts.blockOutCh,
+		rts.blockPeerErrCh,
+	)
+
+	rts.paramsChannel = p2p.NewChannel(
+		ParamsChannel,
+		new(ssproto.Message),
+		rts.paramsInCh,
+		rts.paramsOutCh,
+		rts.paramsPeerErrCh,
+	)
+
 	rts.reactor = NewReactor(
 		log.NewNopLogger(),
 		conn,
 		connQuery,
 		rts.snapshotChannel,
 		rts.chunkChannel,
+		rts.blockChannel,
+		rts.paramsChannel,
 		rts.peerUpdates,
+		rts.stateStore,
+		rts.blockStore,
 		"",
 	)
 
@@ -270,6 +321,294 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_LightBlockResponse(t *testing.T) {
+	rts := setup(t, nil, nil, nil, 2)
+	blocks := mockLightBlocks(t, "test-chain", 2, time.Now())
+
+	lb := blocks[1]
+	require.NoError(t, rts.blockStore.SaveSignedHeader(lb.SignedHeader, lb.Commit.BlockID))
+	require.NoError(t, rts.stateStore.SaveValidatorSets(1, 1, lb.ValidatorSet))
+
+	rts.blockInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.LightBlockRequest{Height: 1},
+	}
+	response := <-rts.blockOutCh
+	require.Equal(t, p2p.PeerID{0xAA}, response.To)
+	received, err := types.LightBlockFromProto(response.Message.(*ssproto.LightBlockResponse).LightBlock)
+	require.NoError(t, err)
+	require.Equal(t, lb.Hash(), received.Hash())
+	require.Equal(t, lb.ValidatorSet.Hash(), received.ValidatorSet.Hash())
+
+	// light blocks we don't have are responded to without a light block
+	rts.blockInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.LightBlockRequest{Height: 2},
+	}
+	response = <-rts.blockOutCh
+	require.Nil(t, response.Message.(*ssproto.LightBlockResponse).LightBlock)
+
+	// unsolicited light blocks are rejected
+	rts.blockInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.LightBlockResponse{},
+	}
+	peerErr := <-rts.blockPeerErrCh
+	require.Equal(t, p2p.PeerID{0xAA}, peerErr.PeerID)
+}
+
+func TestReactor_Backfill(t *testing.T) {
+	const chainID = "test-chain"
+	startTime := time.Now().Add(-time.Hour)
+
+	testcases := map[string]struct {
+		blocks      int64
+		duration    time.Duration
+		stopHeight  int64
+		fromInitial bool
+	}{
+		"disabled":        {0, 0, 11, false},
+		"blocks":          {5, 0, 5, false},
+		"duration":        {0, 7 * time.Minute, 3, false},
+		"both":            {5, 7 * time.Minute, 3, false},
+		"initial height":  {20, 0, 2, false},
+		"initial height1": {20, 0, 1, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			rts := setup(t, nil, nil, nil, 100)
+			blocks := mockLightBlocks(t, chainID, 10, startTime)
+			fork := mockLightBlocks(t, chainID, 10, startTime)
+
+			initialHeight := int64(2)
+			if tc.fromInitial {
+				initialHeight = 1
+			}
+
+			// Peer 0xAA serves our chain, while peer 0xBB serves a fork of it.
+			goodPeer, badPeer := p2p.PeerID{0xAA}, p2p.PeerID{0xBB}
+			rts.reactor.dispatcher.addPeer(goodPeer)
+			rts.reactor.dispatcher.addPeer(badPeer)
+			closeCh := make(chan struct{})
+			defer close(closeCh)
+			go func() {
+				for {
+					select {
+					case envelope := <-rts.blockOutCh:
+						height := int64(envelope.Message.(*ssproto.LightBlockRequest).Height)
+						lb := blocks[height]
+						if envelope.To.Equal(badPeer) {
+							lb = fork[height]
+						}
+						pb, err := lb.ToProto()
+						require.NoError(t, err)
+						rts.blockInCh <- p2p.Envelope{
+							From:    envelope.To,
+							Message: &ssproto.LightBlockResponse{LightBlock: pb},
+						}
+					case peerErr := <-rts.blockPeerErrCh:
+						require.Equal(t, badPeer, peerErr.PeerID)
+					case <-closeCh:
+						return
+					}
+				}
+			}()
+
+			last := blocks[10]
+			err := rts.reactor.Backfill(sm.State{
+				ChainID:         chainID,
+				InitialHeight:   initialHeight,
+				LastBlockHeight: last.Height,
+				LastBlockID:     last.Commit.BlockID,
+				LastBlockTime:   last.Time,
+			}, tc.blocks, tc.duration)
+			require.NoError(t, err)
+
+			for height := int64(1); height <= 10; height++ {
+				meta := rts.blockStore.LoadBlockMeta(height)
+				vals, err := rts.stateStore.LoadValidators(height)
+				if height < tc.stopHeight || height < initialHeight {
+					require.Nil(t, meta, "height %v", height)
+					require.Error(t, err, "height %v", height)
+					continue
+				}
+				require.NotNil(t, meta, "height %v", height)
+				require.Equal(t, blocks[height].Hash(), meta.Header.Hash())
+				require.Equal(t, blocks[height].Commit.Hash(), rts.blockStore.LoadBlockCommit(height).Hash())
+				require.NoError(t, err, "height %v", height)
+				require.Equal(t, blocks[height].ValidatorSet.Hash(), vals.Hash())
+			}
+		})
+	}
+}
+
+func TestReactor_ParamsResponse(t *testing.T) {
+	rts := setup(t, nil, nil, nil, 2)
+
+	vals, _ := types.RandValidatorSet(1, 10)
+	params := *types.DefaultConsensusParams()
+	params.Block.MaxBytes = 1024
+	require.NoError(t, rts.stateStore.Bootstrap(sm.State{
+		LastBlockHeight: 1,
+		InitialHeight:   1,
+		LastValidators:  vals,
+		Validators:      vals,
+		NextValidators:  vals,
+		ConsensusParams: params,
+	}))
+
+	rts.paramsInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.ParamsRequest{Height: 2},
+	}
+	response := <-rts.paramsOutCh
+	require.Equal(t, p2p.PeerID{0xAA}, response.To)
+	require.Equal(t, &ssproto.ParamsResponse{Height: 2, ConsensusParams: &params}, response.Message)
+
+	// params we don't have are responded to without params
+	rts.paramsInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.ParamsRequest{Height: 10},
+	}
+	response = <-rts.paramsOutCh
+	require.Equal(t, &ssproto.ParamsResponse{Height: 10}, response.Message)
+
+	// unsolicited params are rejected
+	rts.paramsInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &ssproto.ParamsResponse{Height: 2, ConsensusParams: &params},
+	}
+	peerErr := <-rts.paramsPeerErrCh
+	require.Equal(t, p2p.PeerID{0xAA}, peerErr.PeerID)
+}
+
+func TestReactor_P2PStateProvider(t *testing.T) {
+	const chainID = "test-chain"
+	rts := setup(t, nil, nil, nil, 100)
+	blocks := mockLightBlocks(t, chainID, 10, time.Now().Add(-time.Hour))
+	params := *types.DefaultConsensusParams()
+	badParams := *types.DefaultConsensusParams()
+	badParams.Block.MaxBytes = 1024
+
+	// Both peers serve the chain, but peer 0xBB sends params which don't match
+	// the consensus hash, so params must be fetched from 0xAA.
+	goodPeer, badPeer := p2p.PeerID{0xAA}, p2p.PeerID{0xBB}
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	go func() {
+		for {
+			select {
+			case envelope := <-rts.blockOutCh:
+				height := int64(envelope.Message.(*ssproto.LightBlockRequest).Height)
+				if height == 0 {
+					height = int64(len(blocks))
+				}
+				var pb *tmproto.LightBlock
+				if lb, ok := blocks[height]; ok {
+					var err error
+					pb, err = lb.ToProto()
+					require.NoError(t, err)
+				}
+				rts.blockInCh <- p2p.Envelope{
+					From:    envelope.To,
+					Message: &ssproto.LightBlockResponse{LightBlock: pb},
+				}
+			case envelope := <-rts.paramsOutCh:
+				msg := &ssproto.ParamsResponse{
+					Height:          envelope.Message.(*ssproto.ParamsRequest).Height,
+					ConsensusParams: &params,
+				}
+				if envelope.To.Equal(badPeer) {
+					msg.ConsensusParams = &badParams
+				}
+				rts.paramsInCh <- p2p.Envelope{From: envelope.To, Message: msg}
+			case <-closeCh:
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The state provider waits for enough peers to connect.
+	rts.reactor.dispatcher.addPeer(goodPeer)
+	go func() {
+		time.Sleep(2 * peerWaitInterval)
+		rts.reactor.dispatcher.addPeer(badPeer)
+	}()
+	stateProvider, err := rts.reactor.P2PStateProvider(ctx, chainID, sm.InitStateVersion, 1, light.TrustOptions{
+		Period: 24 * time.Hour,
+		Height: 1,
+		Hash:   blocks[1].Hash(),
+	})
+	require.NoError(t, err)
+
+	appHash, err := stateProvider.AppHash(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, []byte(blocks[6].AppHash), appHash)
+
+	commit, err := stateProvider.Commit(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].Commit.Hash(), commit.Hash())
+
+	state, err := stateProvider.State(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, chainID, state.ChainID)
+	require.EqualValues(t, 5, state.LastBlockHeight)
+	require.Equal(t, blocks[5].Commit.BlockID, state.LastBlockID)
+	require.Equal(t, []byte(blocks[6].AppHash), state.AppHash)
+	require.Equal(t, blocks[5].ValidatorSet.Hash(), state.LastValidators.Hash())
+	require.Equal(t, blocks[6].ValidatorSet.Hash(), state.Validators.Hash())
+	require.Equal(t, blocks[7].ValidatorSet.Hash(), state.NextValidators.Hash())
+	require.Equal(t, params, state.ConsensusParams)
+}
+
+// mockLightBlocks generates a chain of light blocks from height 1 to n, one
+// minute apart, with the validator set changing every third height.
+func mockLightBlocks(t *testing.T, chainID string, n int64, startTime time.Time) map[int64]*types.LightBlock {
+	blocks := make(map[int64]*types.LightBlock, n)
+	vals, privVals := types.RandValidatorSet(3, 10)
+	lastBlockID := types.BlockID{}
+
+	for height := int64(1); height <= n; height++ {
+		nextVals, nextPrivVals := vals, privVals
+		if height%3 == 0 {
+			nextVals, nextPrivVals = types.RandValidatorSet(3, 10)
+		}
+
+		header := &types.Header{
+			Version:            tmversion.Consensus{Block: version.BlockProtocol},
+			ChainID:            chainID,
+			Height:             height,
+			Time:               startTime.Add(time.Duration(height) * time.Minute),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: nextVals.Hash(),
+			ConsensusHash:      types.HashConsensusParams(*types.DefaultConsensusParams()),
+			AppHash:            tmrand.Bytes(tmhash.Size),
+			ProposerAddress:    vals.Validators[0].Address,
+		}
+		blockID := types.BlockID{
+			Hash:          header.Hash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+		}
+		voteSet := types.NewVoteSet(chainID, height, 0, tmproto.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, header.Time)
+		require.NoError(t, err)
+
+		blocks[height] = &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: vals,
+		}
+		lastBlockID = blockID
+		vals, privVals = nextVals, nextPrivVals
+	}
+
+	return blocks
+}
+
 // retryUntil will continue to evaluate fn and will return successfully when true
 // or fail when the timeout is reached.
 func retryUntil(t *testing.T, fn func() bool, timeout time.Duration) {
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -12,10 +13,13 @@ import (
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/light"
 	lightprovider "github.com/tendermint/tendermint/light/provider"
+	lightgrpc "github.com/tendermint/tendermint/light/provider/grpc"
 	lighthttp "github.com/tendermint/tendermint/light/provider/http"
 	lightrpc "github.com/tendermint/tendermint/light/rpc"
 	lightdb "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/p2p"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
@@ -40,10 +44,20 @@ type lightClientStateProvider struct {
 	lc            *light.Client
 	version       tmstate.Version
 	initialHeight int64
-	providers     map[lightprovider.Provider]string
+
+	// consensusParams fetches the consensus params at the height of a light
+	// block which was verified by the light client.
+	consensusParams func(ctx context.Context, lb *types.LightBlock) (tmproto.ConsensusParams, error)
+}
+
+// paramsProvider is a light provider which also serves consensus params, like
+// the gRPC provider.
+type paramsProvider interface {
+	ConsensusParams(ctx context.Context, height int64) (*tmproto.ConsensusParams, error)
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
+// Servers prefixed with grpc:// are reached through their gRPC LightAPI instead of JSON-RPC.
 func NewLightClientStateProvider(
 	ctx context.Context,
 	chainID string,
@@ -60,6 +74,14 @@ func NewLightClientStateProvider(
 	providers := make([]lightprovider.Provider, 0, len(servers))
 	providerRemotes := make(map[lightprovider.Provider]string)
 	for _, server := range servers {
+		if strings.HasPrefix(server, grpcScheme) {
+			provider, err := lightgrpc.New(chainID, "tcp://"+strings.TrimPrefix(server, grpcScheme))
+			if err != nil {
+				return nil, fmt.Errorf("failed to set up gRPC client: %w", err)
+			}
+			providers = append(providers, provider)
+			continue
+		}
 		client, err := rpcClient(server)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set up RPC client: %w", err)
@@ -76,12 +98,107 @@ func NewLightClientStateProvider(
 	if err != nil {
 		return nil, err
 	}
-	return &lightClientStateProvider{
+	s := &lightClientStateProvider{
 		lc:            lc,
 		version:       version,
 		initialHeight: initialHeight,
-		providers:     providerRemotes,
-	}, nil
+	}
+	s.consensusParams = func(ctx context.Context, lb *types.LightBlock) (tmproto.ConsensusParams, error) {
+		// gRPC primaries serve the consensus params, which we check against the verified header.
+		if pp, ok := s.lc.Primary().(paramsProvider); ok {
+			params, err := pp.ConsensusParams(ctx, lb.Height)
+			if err != nil {
+				return tmproto.ConsensusParams{}, err
+			}
+			if !bytes.Equal(types.HashConsensusParams(*params), lb.ConsensusHash) {
+				return tmproto.ConsensusParams{}, fmt.Errorf("consensus params not matching hash %X", lb.ConsensusHash)
+			}
+			return *params, nil
+		}
+
+		// We fetch consensus params via RPC from the primary, using light client verification.
+		primaryURL, ok := providerRemotes[s.lc.Primary()]
+		if !ok || primaryURL == "" {
+			return tmproto.ConsensusParams{}, fmt.Errorf("could not find address for primary light client provider")
+		}
+		primaryRPC, err := rpcClient(primaryURL)
+		if err != nil {
+			return tmproto.ConsensusParams{}, fmt.Errorf("unable to create RPC client: %w", err)
+		}
+		result, err := lightrpc.NewClient(primaryRPC, s.lc).ConsensusParams(ctx, &lb.Height)
+		if err != nil {
+			return tmproto.ConsensusParams{}, err
+		}
+		return result.ConsensusParams, nil
+	}
+	return s, nil
+}
+
+// newP2PStateProvider creates a new StateProvider using a light client which
+// fetches light blocks from the given peers, and consensus params from the
+// light client's primary, or otherwise any of the peers. At least 2 peers are
+// required, the first of which is used as the light client's primary.
+func newP2PStateProvider(
+	ctx context.Context,
+	chainID string,
+	version tmstate.Version,
+	initialHeight int64,
+	peers []p2p.PeerID,
+	dispatcher *dispatcher,
+	trustOptions light.TrustOptions,
+	logger log.Logger,
+) (StateProvider, error) {
+	if len(peers) < 2 {
+		return nil, fmt.Errorf("at least 2 peers are required, got %v", len(peers))
+	}
+
+	providers := make([]lightprovider.Provider, 0, len(peers))
+	providerPeers := make(map[lightprovider.Provider]p2p.PeerID, len(peers))
+	for _, peer := range peers {
+		provider := newBlockProvider(chainID, peer, dispatcher)
+		providers = append(providers, provider)
+		providerPeers[provider] = peer
+	}
+
+	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
+		lightdb.New(dbm.NewMemDB(), ""), light.Logger(logger), light.MaxRetryAttempts(5))
+	if err != nil {
+		return nil, err
+	}
+	s := &lightClientStateProvider{
+		lc:            lc,
+		version:       version,
+		initialHeight: initialHeight,
+	}
+	s.consensusParams = func(ctx context.Context, lb *types.LightBlock) (tmproto.ConsensusParams, error) {
+		// Peers are tried in turn, starting with the primary, until one of
+		// them has params matching the verified header's consensus hash.
+		candidates := []p2p.PeerID{providerPeers[s.lc.Primary()]}
+		for _, peer := range peers {
+			if !peer.Equal(candidates[0]) {
+				candidates = append(candidates, peer)
+			}
+		}
+		var lastErr error
+		for _, peer := range candidates {
+			params, err := dispatcher.consensusParams(ctx, lb.Height, peer)
+			switch {
+			case err != nil:
+				lastErr = err
+			case params == nil:
+				lastErr = fmt.Errorf("peer %v doesn't have consensus params", peer)
+			case !bytes.Equal(types.HashConsensusParams(*params), lb.ConsensusHash):
+				lastErr = fmt.Errorf("peer %v sent consensus params not matching hash %X", peer, lb.ConsensusHash)
+			default:
+				return *params, nil
+			}
+			if ctx.Err() != nil {
+				return tmproto.ConsensusParams{}, ctx.Err()
+			}
+		}
+		return tmproto.ConsensusParams{}, lastErr
+	}
+	return s, nil
 }
 
 // AppHash implements StateProvider.
@@ -169,26 +286,19 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.NextValidators = nextLightBlock.ValidatorSet
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
 
-	// We'll also need to fetch consensus params via RPC, using light client verification.
-	primaryURL, ok := s.providers[s.lc.Primary()]
-	if !ok || primaryURL == "" {
-		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
-	}
-	primaryRPC, err := rpcClient(primaryURL)
-	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
-	}
-	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
-	result, err := rpcclient.ConsensusParams(ctx, &nextLightBlock.Height)
+	// We'll also need to fetch consensus params, verified by the light client.
+	state.ConsensusParams, err = s.consensusParams(ctx, nextLightBlock)
 	if err != nil {
 		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
 			nextLightBlock.Height, err)
 	}
-	state.ConsensusParams = result.ConsensusParams
 
 	return state, nil
 }
 
+// grpcScheme is the scheme of the servers reached through the gRPC LightAPI.
+const grpcScheme = "grpc://"
+
 // rpcClient sets up a new RPC client
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
@@ -5,14 +5,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -56,12 +59,31 @@ type syncer struct {
 	snapshots     *snapshotPool
 	snapshotCh    chan<- p2p.Envelope
 	chunkCh       chan<- p2p.Envelope
-	tempDir       string
+	tempDir       string // if set, chunks and progress are persisted here to resume syncs
+
+	// RPC servers serving snapshots and chunks over HTTP, keyed by the pseudo
+	// peer ID they're known as in the snapshot pool. If rpcSourcesOnly is set,
+	// chunks are never requested from peers.
+	rpcSources     map[string]*rpcSource
+	rpcSourcesOnly bool
 
 	mtx    tmsync.RWMutex
 	chunks *chunkQueue
 }
 
+// rpcSource is an RPC server serving snapshots and chunks.
+type rpcSource struct {
+	peerID p2p.PeerID
+	remote string
+	client rpcclient.StateSyncClient
+}
+
+// rpcSourcePeerID returns the pseudo peer ID for an RPC server, which is used
+// to track its snapshots in the snapshot pool and as the chunk sender.
+func rpcSourcePeerID(remote string) p2p.PeerID {
+	return p2p.PeerID(tmhash.SumTruncated([]byte(remote)))
+}
+
 // newSyncer creates a new syncer.
 func newSyncer(
 	logger log.Logger,
@@ -80,6 +102,44 @@ func newSyncer(
 		snapshotCh:    snapshotCh,
 		chunkCh:       chunkCh,
 		tempDir:       tempDir,
+		rpcSources:    make(map[string]*rpcSource),
+	}
+}
+
+// AddRPCSource adds an RPC server to discover snapshots and fetch chunks from.
+func (s *syncer) AddRPCSource(remote string, client rpcclient.StateSyncClient) {
+	peerID := rpcSourcePeerID(remote)
+	s.rpcSources[peerID.String()] = &rpcSource{
+		peerID: peerID,
+		remote: remote,
+		client: client,
+	}
+}
+
+// discoverRPCSnapshots adds the snapshots listed by the RPC sources to the pool.
+func (s *syncer) discoverRPCSnapshots() {
+	for _, source := range s.rpcSources {
+		ctx, cancel := context.WithTimeout(context.Background(), chunkRequestTimeout)
+		res, err := source.client.Snapshots(ctx)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to list snapshots over RPC", "server", source.remote, "err", err)
+			continue
+		}
+
+		for _, snap := range res.Snapshots {
+			_, err := s.AddSnapshot(source.peerID, &snapshot{
+				Height:   snap.Height,
+				Format:   snap.Format,
+				Chunks:   snap.Chunks,
+				Hash:     snap.Hash,
+				Metadata: snap.Metadata,
+			})
+			if err != nil {
+				s.logger.Error("Failed to add snapshot", "height", snap.Height, "format", snap.Format,
+					"server", source.remote, "err", err)
+			}
+		}
 	}
 }
 
@@ -143,14 +203,16 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 		s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
 		time.Sleep(discoveryTime)
 	}
+	s.discoverRPCSnapshots()
 
 	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
-	// the snapshot and chunk queue from the previous loop iteration.
-	var (
-		snapshot *snapshot
-		chunks   *chunkQueue
-		err      error
-	)
+	// the snapshot and chunk queue from the previous loop iteration. We start off with the
+	// snapshot of an interrupted sync, if any.
+	snapshot, chunks := s.resumeSnapshot()
+	if chunks != nil {
+		defer chunks.Close() // in case we forget to close it elsewhere
+	}
+	var err error
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
@@ -163,10 +225,15 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 			}
 			s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
 			time.Sleep(discoveryTime)
+			s.discoverRPCSnapshots()
 			continue
 		}
 		if chunks == nil {
-			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			if s.tempDir != "" {
+				chunks, err = newPersistentChunkQueue(snapshot, s.tempDir)
+			} else {
+				chunks, err = newChunkQueue(snapshot, s.tempDir)
+			}
 			if err != nil {
 				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
@@ -182,7 +249,9 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
-			chunks.RetryAll()
+			if err := chunks.RetryAll(); err != nil {
+				return sm.State{}, nil, fmt.Errorf("failed to retry snapshot: %w", err)
+			}
 			s.logger.Info("Retrying snapshot", "height", snapshot.Height, "format", snapshot.Format,
 				"hash", fmt.Sprintf("%X", snapshot.Hash))
 			continue
@@ -223,6 +292,46 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 	}
 }
 
+// resumeSnapshot loads the snapshot and chunk queue of a sync that was interrupted, e.g. by a
+// restart, such that it can be resumed without fetching the chunks again. It returns nil if
+// there is no sync to resume, or if the snapshot can no longer be verified. The app is offered
+// the snapshot again along with the chunks it applied, and all chunks are applied from scratch
+// unless the app sets SkipAppliedChunks in its response to continue the previous restoration.
+// Since a chunk is only recorded as applied once the app responded, such an app may be given the
+// chunk it applied right before the interruption again, which it must accept.
+func (s *syncer) resumeSnapshot() (*snapshot, *chunkQueue) {
+	if s.tempDir == "" {
+		return nil, nil
+	}
+
+	chunks, err := loadChunkQueue(s.tempDir)
+	if err != nil {
+		s.logger.Error("Failed to load interrupted state sync, starting over", "err", err)
+		return nil, nil
+	}
+	if chunks == nil {
+		return nil, nil
+	}
+	snapshot := chunks.snapshot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	snapshot.trustedAppHash, err = s.stateProvider.AppHash(ctx, snapshot.Height)
+	if err != nil {
+		s.logger.Error("Failed to verify snapshot of interrupted state sync, starting over",
+			"height", snapshot.Height, "format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash),
+			"err", err)
+		if err := chunks.Close(); err != nil {
+			s.logger.Error("Failed to clean up chunk queue", "err", err)
+		}
+		return nil, nil
+	}
+
+	s.logger.Info("Resuming interrupted state sync", "height", snapshot.Height, "format", snapshot.Format,
+		"hash", fmt.Sprintf("%X", snapshot.Hash), "applied", chunks.Applied(), "total", chunks.Size())
+	return snapshot, chunks
+}
+
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
 // the caller must use to bootstrap the node.
 func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
@@ -239,11 +348,17 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		s.mtx.Unlock()
 	}()
 
-	// Offer snapshot to ABCI app.
-	err := s.offerSnapshot(snapshot)
+	// Offer snapshot to ABCI app, restoring it from scratch unless the app continues an
+	// interrupted restoration.
+	skipApplied, err := s.offerSnapshot(snapshot, chunks.AppliedChunks())
 	if err != nil {
 		return sm.State{}, nil, err
 	}
+	if !skipApplied {
+		if err := chunks.RetryAll(); err != nil {
+			return sm.State{}, nil, fmt.Errorf("failed to reset applied chunks: %w", err)
+		}
+	}
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context cancelled.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -285,11 +400,13 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	return state, commit, nil
 }
 
-// offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
-// response, or nil if the snapshot was accepted.
-func (s *syncer) offerSnapshot(snapshot *snapshot) error {
+// offerSnapshot offers a snapshot to the app, along with the chunks it applied before the
+// restoration was interrupted, if any. It returns various errors depending on the app's
+// response, or nil if the snapshot was accepted, along with whether the app skips the applied
+// chunks.
+func (s *syncer) offerSnapshot(snapshot *snapshot, appliedChunks []uint32) (bool, error) {
 	s.logger.Info("Offering snapshot to ABCI app", "height", snapshot.Height,
-		"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+		"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash), "applied", len(appliedChunks))
 	resp, err := s.conn.OfferSnapshotSync(context.Background(), abci.RequestOfferSnapshot{
 		Snapshot: &abci.Snapshot{
 			Height:   snapshot.Height,
@@ -298,26 +415,28 @@ func (s *syncer) offerSnapshot(snapshot *snapshot) error {
 			Hash:     snapshot.Hash,
 			Metadata: snapshot.Metadata,
 		},
-		AppHash: snapshot.trustedAppHash,
+		AppHash:       snapshot.trustedAppHash,
+		AppliedChunks: appliedChunks,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to offer snapshot: %w", err)
+		return false, fmt.Errorf("failed to offer snapshot: %w", err)
 	}
 	switch resp.Result {
 	case abci.ResponseOfferSnapshot_ACCEPT:
+		skipApplied := resp.SkipAppliedChunks && len(appliedChunks) > 0
 		s.logger.Info("Snapshot accepted, restoring", "height", snapshot.Height,
-			"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
-		return nil
+			"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash), "resumed", skipApplied)
+		return skipApplied, nil
 	case abci.ResponseOfferSnapshot_ABORT:
-		return errAbort
+		return false, errAbort
 	case abci.ResponseOfferSnapshot_REJECT:
-		return errRejectSnapshot
+		return false, errRejectSnapshot
 	case abci.ResponseOfferSnapshot_REJECT_FORMAT:
-		return errRejectFormat
+		return false, errRejectFormat
 	case abci.ResponseOfferSnapshot_REJECT_SENDER:
-		return errRejectSender
+		return false, errRejectSender
 	default:
-		return fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
+		return false, fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
 	}
 }
 
@@ -369,6 +488,9 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 
 		switch resp.Result {
 		case abci.ResponseApplySnapshotChunk_ACCEPT:
+			if err := chunks.MarkApplied(chunk.Index); err != nil {
+				return fmt.Errorf("failed to record applied chunk %v: %w", chunk.Index, err)
+			}
 		case abci.ResponseApplySnapshotChunk_ABORT:
 			return errAbort
 		case abci.ResponseApplySnapshotChunk_RETRY:
@@ -420,15 +542,20 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
+// requestChunk requests a chunk from a peer, or fetches it from an RPC source.
 func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+	peer := s.getChunkPeer(snapshot)
 	if peer == nil {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
 		return
 	}
 
+	if source, ok := s.rpcSources[peer.String()]; ok {
+		s.fetchRPCChunk(source, snapshot, chunk)
+		return
+	}
+
 	s.logger.Debug(
 		"Requesting snapshot chunk",
 		"height", snapshot.Height,
@@ -447,6 +574,58 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	}
 }
 
+// getChunkPeer returns a random peer or RPC source to fetch the snapshot's
+// chunks from, or nil if there is none.
+func (s *syncer) getChunkPeer(snapshot *snapshot) p2p.PeerID {
+	if !s.rpcSourcesOnly {
+		return s.snapshots.GetPeer(snapshot)
+	}
+
+	sources := []p2p.PeerID{}
+	for _, peer := range s.snapshots.GetPeers(snapshot) {
+		if _, ok := s.rpcSources[peer.String()]; ok {
+			sources = append(sources, peer)
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return sources[rand.Intn(len(sources))] // nolint:gosec // G404: Use of weak random number generator
+}
+
+// fetchRPCChunk fetches a chunk from an RPC source and adds it to the chunk queue.
+func (s *syncer) fetchRPCChunk(source *rpcSource, snapshot *snapshot, index uint32) {
+	s.logger.Debug(
+		"Fetching snapshot chunk over RPC",
+		"height", snapshot.Height,
+		"format", snapshot.Format,
+		"chunk", index,
+		"server", source.remote,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), chunkRequestTimeout)
+	defer cancel()
+
+	res, err := source.client.SnapshotChunk(ctx, snapshot.Height, snapshot.Format, index)
+	if err != nil {
+		s.logger.Error("Failed to fetch snapshot chunk over RPC", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "server", source.remote, "err", err)
+		return
+	}
+
+	_, err = s.AddChunk(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  res.Chunk,
+		Sender: source.peerID,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add snapshot chunk", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "server", source.remote, "err", err)
+	}
+}
+
 // verifyApp verifies the sync, checking the app hash and last block height. It returns the
 // app version, which should be returned as part of the initial state.
 func (s *syncer) verifyApp(snapshot *snapshot) (uint64, error) {
@@ -3,6 +3,8 @@ package statesync
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -17,6 +19,8 @@ import (
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/proxy"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	rpcmocks "github.com/tendermint/tendermint/rpc/client/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/statesync/mocks"
 	"github.com/tendermint/tendermint/types"
@@ -190,6 +194,150 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connQuery.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_rpcSources(t *testing.T) {
+	state := sm.State{ChainID: "chain", AppHash: []byte("app_hash")}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+
+	rts := setup(t, connSnapshot, connQuery, stateProvider, 3)
+
+	// The snapshot is discovered both from a peer and from the RPC source, but
+	// chunks are only fetched over RPC.
+	_, err := rts.syncer.AddSnapshot(p2p.PeerID{0xAA}, s)
+	require.NoError(t, err)
+
+	rpcClient := &rpcmocks.Client{}
+	rpcClient.On("Snapshots", mock.Anything).Return(&ctypes.ResultSnapshots{
+		Snapshots: []*abci.Snapshot{toABCI(s)},
+	}, nil)
+	for i := uint32(0); i < s.Chunks; i++ {
+		rpcClient.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), i).Once().Return(
+			&ctypes.ResultSnapshotChunk{Chunk: []byte{1, 1, byte(i)}}, nil)
+	}
+	rts.syncer.AddRPCSource("server", rpcClient)
+	rts.syncer.rpcSourcesOnly = true
+	sender := rpcSourcePeerID("server").String()
+
+	connSnapshot.On("OfferSnapshotSync", ctx, abci.RequestOfferSnapshot{
+		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := uint32(0); i < s.Chunks; i++ {
+		connSnapshot.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+			Index: i, Chunk: []byte{1, 1, byte(i)}, Sender: sender,
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery.On("InfoSync", ctx, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       9,
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	newState, lastCommit, err := rts.syncer.SyncAny(0)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, newState.Version.Consensus.App)
+	require.Equal(t, commit, lastCommit)
+	require.Empty(t, rts.chunkOutCh)
+
+	rpcClient.AssertExpectations(t)
+	connSnapshot.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
+
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	testcases := map[string]struct {
+		skipApplied bool // whether the app continues the interrupted restoration
+		recorded    bool // whether applying the first chunk was recorded before the interruption
+		applied     []uint32
+	}{
+		"restart":  {false, true, []uint32{0, 1, 2}},
+		"continue": {true, true, []uint32{1, 2}},
+		// the app applied the first chunk, but it wasn't recorded, so the app must accept it again
+		"reapply": {true, false, []uint32{0, 1, 2}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			state := sm.State{ChainID: "chain", AppHash: []byte("app_hash")}
+			commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+			s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+			sender := p2p.PeerID{0xAA}
+
+			stateProvider := &mocks.StateProvider{}
+			stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+			stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+			stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+			connSnapshot := &proxymocks.AppConnSnapshot{}
+			connQuery := &proxymocks.AppConnQuery{}
+
+			rts := setup(t, connSnapshot, connQuery, stateProvider, 3)
+			dir, err := ioutil.TempDir("", "syncer")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			rts.syncer.tempDir = dir
+
+			// Leave behind an interrupted sync, which has fetched all chunks and applied the first.
+			queue, err := newPersistentChunkQueue(s, dir)
+			require.NoError(t, err)
+			for i := uint32(0); i < s.Chunks; i++ {
+				_, err = queue.Add(&chunk{Height: 1, Format: 1, Index: i, Chunk: []byte{1, 1, byte(i)}, Sender: sender})
+				require.NoError(t, err)
+			}
+			if tc.recorded {
+				require.NoError(t, queue.MarkApplied(0))
+			}
+
+			// The snapshot is offered again along with the applied chunks, and the app either
+			// restores it from scratch with the chunks already fetched, or continues with the
+			// remaining chunks.
+			var appliedChunks []uint32
+			if tc.recorded {
+				appliedChunks = []uint32{0}
+			}
+			connSnapshot.On("OfferSnapshotSync", ctx, abci.RequestOfferSnapshot{
+				Snapshot: toABCI(s), AppHash: []byte("app_hash"), AppliedChunks: appliedChunks,
+			}).Once().Return(&abci.ResponseOfferSnapshot{
+				Result:            abci.ResponseOfferSnapshot_ACCEPT,
+				SkipAppliedChunks: tc.skipApplied,
+			}, nil)
+
+			applied := []uint32{}
+			connSnapshot.On("ApplySnapshotChunkSync", ctx, mock.Anything).Return(
+				func(_ context.Context, req abci.RequestApplySnapshotChunk) *abci.ResponseApplySnapshotChunk {
+					require.Equal(t, []byte{1, 1, byte(req.Index)}, req.Chunk)
+					require.Equal(t, sender.String(), req.Sender)
+					applied = append(applied, req.Index)
+					return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+				},
+				nil)
+			connQuery.On("InfoSync", ctx, proxy.RequestInfo).Return(&abci.ResponseInfo{
+				AppVersion:       9,
+				LastBlockHeight:  1,
+				LastBlockAppHash: []byte("app_hash"),
+			}, nil)
+
+			_, lastCommit, err := rts.syncer.SyncAny(0)
+			require.NoError(t, err)
+			require.Equal(t, commit, lastCommit)
+			require.Equal(t, tc.applied, applied)
+			require.Empty(t, rts.chunkOutCh)
+
+			// The sync is done, so there's nothing left to resume.
+			loaded, err := loadChunkQueue(dir)
+			require.NoError(t, err)
+			require.Nil(t, loaded)
+
+			connSnapshot.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
@@ -396,7 +544,7 @@ func TestSyncer_offerSnapshot(t *testing.T) {
 				AppHash:  []byte("app_hash"),
 			}).Return(&abci.ResponseOfferSnapshot{Result: tc.result}, tc.err)
 
-			err := rts.syncer.offerSnapshot(s)
+			_, err := rts.syncer.offerSnapshot(s, nil)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -334,11 +334,49 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	}
 
 	height := block.Height
-	hash := block.Hash()
 
 	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
 		panic(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, g))
 	}
+
+	bs.writeBlock(block, blockParts, seenCommit)
+	bs.setHeight(height)
+}
+
+// WriteBlock persists the given block, blockParts, and seenCommit like
+// SaveBlock, but without advancing the store height: the block only becomes
+// part of the store once CommitBlock is called for its height. This allows
+// fast sync to write a block while the previous one is still being executed,
+// without the store ever getting more than one block ahead of the state.
+func (bs *BlockStore) WriteBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
+	if block == nil {
+		panic("BlockStore can only write a non-nil block")
+	}
+
+	if g, w := block.Height, bs.Height()+1; bs.Base() > 0 && g < w {
+		panic(fmt.Sprintf("BlockStore can only write blocks above its height. Wanted >= %v, got %v", w, g))
+	}
+
+	bs.writeBlock(block, blockParts, seenCommit)
+}
+
+// CommitBlock advances the store height to a block previously written with
+// WriteBlock.
+func (bs *BlockStore) CommitBlock(height int64) {
+	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
+		panic(fmt.Sprintf("BlockStore can only commit contiguous blocks. Wanted %v, got %v", w, g))
+	}
+	if bs.LoadBlockMeta(height) == nil {
+		panic(fmt.Sprintf("BlockStore can only commit written blocks, height %v not found", height))
+	}
+
+	bs.setHeight(height)
+}
+
+func (bs *BlockStore) writeBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
+	height := block.Height
+	hash := block.Hash()
+
 	if !blockParts.IsComplete() {
 		panic("BlockStore can only save complete block part sets")
 	}
@@ -380,8 +418,9 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	if err := bs.db.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
 		panic(err)
 	}
+}
 
-	// Done!
+func (bs *BlockStore) setHeight(height int64) {
 	bs.mtx.Lock()
 	bs.height = height
 	if bs.base == 0 {
@@ -424,6 +463,43 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
 }
 
+// SaveSignedHeader saves the header and commit of a block below the base height,
+// without its contents, used e.g. by state sync to backfill the headers needed
+// to serve light clients and verify evidence. The block meta has a block size
+// and number of transactions of -1, since they're unknown, and the block can't
+// be loaded with LoadBlock. It errors if the block is already in the store.
+func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
+	if base := bs.Base(); base > 0 && sh.Height >= base {
+		return fmt.Errorf("cannot save signed header at height %v, at or above base height %v", sh.Height, base)
+	}
+	if bs.LoadBlockMeta(sh.Height) != nil {
+		return fmt.Errorf("block at height %v already saved", sh.Height)
+	}
+
+	// Save the commit first, since callers typically load the block meta as an
+	// indication that the block exists.
+	pbc := sh.Commit.ToProto()
+	commitBytes, err := proto.Marshal(pbc)
+	if err != nil {
+		return fmt.Errorf("unable to marshal commit: %w", err)
+	}
+	if err := bs.db.Set(calcBlockCommitKey(sh.Height), commitBytes); err != nil {
+		return err
+	}
+
+	blockMeta := &types.BlockMeta{
+		BlockID:   blockID,
+		BlockSize: -1,
+		Header:    *sh.Header,
+		NumTxs:    -1,
+	}
+	metaBytes, err := proto.Marshal(blockMeta.ToProto())
+	if err != nil {
+		return fmt.Errorf("unable to marshal block meta: %w", err)
+	}
+	return bs.db.Set(calcBlockMetaKey(sh.Height), metaBytes)
+}
+
 //-----------------------------------------------------------------------------
 
 func calcBlockMetaKey(height int64) []byte {
@@ -389,6 +389,69 @@ func TestLoadBaseMeta(t *testing.T) {
 	assert.EqualValues(t, 4, bs.Base())
 }
 
+func TestBlockStoreWriteCommitBlock(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	block1 := makeBlock(1, state, new(types.Commit))
+	bs.SaveBlock(block1, block1.MakePartSet(2), makeTestCommit(1, tmtime.Now()))
+
+	// written blocks are stored, but not part of the store until committed
+	block2 := makeBlock(2, state, new(types.Commit))
+	bs.WriteBlock(block2, block2.MakePartSet(2), makeTestCommit(2, tmtime.Now()))
+	block3 := makeBlock(3, state, new(types.Commit))
+	bs.WriteBlock(block3, block3.MakePartSet(2), makeTestCommit(3, tmtime.Now()))
+	assert.EqualValues(t, 1, bs.Height())
+	assert.NotNil(t, bs.LoadBlockMeta(3))
+
+	// blocks must be committed in order
+	assert.Panics(t, func() { bs.CommitBlock(3) })
+
+	bs.CommitBlock(2)
+	assert.EqualValues(t, 2, bs.Height())
+	assert.Equal(t, block2.Hash(), bs.LoadBlock(2).Hash())
+
+	bs.CommitBlock(3)
+	assert.EqualValues(t, 3, bs.Height())
+	assert.EqualValues(t, 1, bs.Base())
+
+	// the height is persisted
+	assert.EqualValues(t, 3, NewBlockStore(bs.db).Height())
+
+	// blocks below the height can't be rewritten, and unwritten ones can't be committed
+	assert.Panics(t, func() { bs.WriteBlock(block2, block2.MakePartSet(2), makeTestCommit(2, tmtime.Now())) })
+	assert.Panics(t, func() { bs.CommitBlock(4) })
+}
+
+func TestBlockStoreSaveSignedHeader(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	block := makeBlock(5, state, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(2).Header()}
+	commit := makeTestCommit(5, tmtime.Now())
+	sh := &types.SignedHeader{Header: &block.Header, Commit: commit}
+	require.NoError(t, bs.SaveSignedHeader(sh, blockID))
+
+	// the header and commit can be loaded, but not the block itself
+	meta := bs.LoadBlockMeta(5)
+	require.NotNil(t, meta)
+	assert.Equal(t, blockID, meta.BlockID)
+	assert.Equal(t, block.Header.Hash(), meta.Header.Hash())
+	assert.EqualValues(t, -1, meta.NumTxs)
+	assert.Equal(t, commit.Hash(), bs.LoadBlockCommit(5).Hash())
+	assert.Nil(t, bs.LoadBlock(5))
+	assert.EqualValues(t, 0, bs.Base())
+	assert.EqualValues(t, 0, bs.Height())
+
+	// headers can't be saved twice, or at or above the base height
+	require.Error(t, bs.SaveSignedHeader(sh, blockID))
+	block10 := makeBlock(10, state, new(types.Commit))
+	bs.SaveBlock(block10, block10.MakePartSet(2), makeTestCommit(10, tmtime.Now()))
+	sh10 := &types.SignedHeader{Header: &block10.Header, Commit: makeTestCommit(10, tmtime.Now())}
+	require.Error(t, bs.SaveSignedHeader(sh10, types.BlockID{Hash: block10.Hash()}))
+}
+
 func TestLoadBlockPart(t *testing.T) {
 	bs, db := freshBlockStore()
 	height, index := int64(10), 1
@@ -533,6 +533,12 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return tmjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// GetValidatorSigningInfo implements the rpc/core Consensus interface. The
+// maverick does not monitor its own signatures.
+func (cs *State) GetValidatorSigningInfo() types.ValidatorSigningInfo {
+	return types.ValidatorSigningInfo{}
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -30,6 +31,9 @@ import (
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/light"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
@@ -136,20 +140,21 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger, misbehaviors map[int6
 
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state and evidence Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				evidence.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), evidence.NopMetrics()
 	}
 }
 
@@ -233,7 +238,8 @@ type Node struct {
 	eventBus          *types.EventBus // pub/sub for services
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
-	bcReactor         p2p.Reactor       // for fast-syncing
+	bcReactor         service.Service   // for fast-syncing
+	bcReactorShim     *p2p.ReactorShim  // wires bcReactor to the switch, if it uses p2p Channels
 	mempoolReactor    *mempl.Reactor    // for gossipping transactions
 	mempool           mempl.Mempool
 	stateSync         bool                    // whether the node should state sync on startup
@@ -244,6 +250,7 @@ type Node struct {
 	consensusReactor  *cs.Reactor             // for participating in the consensus
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	evidencePool      *evidence.Pool          // tracking evidence
+	evidenceReactor   *evidence.Reactor       // for gossipping evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
 	txIndexer         txindex.TxIndexer
@@ -380,41 +387,131 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 	return mempoolReactor, mempool
 }
 
+// createEvidenceReactor returns the evidence pool and reactor, along with the
+// shim wiring the reactor to the switch.
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
+	stateDB dbm.DB, blockStore *store.BlockStore, evMetrics *evidence.Metrics,
+	logger log.Logger) (*p2p.ReactorShim, *evidence.Reactor, *evidence.Pool, error) {
 
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	evidenceLogger := logger.With("module", "evidence")
-	evidencePool, err := evidence.NewPool(evidenceDB, sm.NewStore(stateDB), blockStore)
+	reactorShim := p2p.NewReactorShim("EvidenceShim", evidence.ChannelShims)
+	reactorShim.SetLogger(evidenceLogger)
+
+	evidencePool, err := evidence.NewPool(evidenceDB, sm.NewStore(stateDB), blockStore,
+		evidence.WithMetrics(evMetrics))
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	evidencePool.SetLogger(evidenceLogger)
+
+	evidenceReactor := evidence.NewReactor(
+		evidenceLogger,
+		reactorShim.GetChannel(evidence.EvidenceChannel),
+		reactorShim.PeerUpdates,
+		evidencePool,
+		evidence.WithPeerState(evidencePeerState(reactorShim)),
+	)
+	return reactorShim, evidenceReactor, evidencePool, nil
+}
+
+// evidencePeerState returns a function looking up the consensus state of a
+// peer connected to the switch of the given shim, such that the evidence
+// reactor only sends evidence to peers which can verify it.
+func evidencePeerState(reactorShim *p2p.ReactorShim) func(p2p.PeerID) evidence.PeerState {
+	return func(peerID p2p.PeerID) evidence.PeerState {
+		if reactorShim.Switch == nil {
+			return nil
+		}
+		peer := reactorShim.Switch.Peers().Get(p2p.ID(peerID.String()))
+		if peer == nil {
+			return nil
+		}
+		if peerState, ok := peer.Get(types.PeerStateKey).(evidence.PeerState); ok {
+			return peerState
+		}
+		return nil
 	}
-	evidenceReactor := evidence.NewReactor(evidencePool)
-	evidenceReactor.SetLogger(evidenceLogger)
-	return evidenceReactor, evidencePool, nil
 }
 
+// createBlockchainReactor returns the fast sync reactor of the configured
+// version. Reactors built on the p2p Channel API are returned along with the
+// shim wiring them to the switch, which is nil otherwise.
 func createBlockchainReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore *store.BlockStore,
+	csReactor *cs.Reactor,
 	fastSync bool,
-	logger log.Logger) (bcReactor p2p.Reactor, err error) {
+	logger log.Logger) (*p2p.ReactorShim, service.Service, error) {
+
+	logger = logger.With("module", "blockchain")
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		var options []bcv0.ReactorOption
+		if config.FastSync.VerifyLightBlocks {
+			lc, err := createLightClient(config.StateSync, state, logger.With("module", "light"))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to set up light client for fast sync: %w", err)
+			}
+			options = append(options, bcv0.WithLightBlockVerifier(lc))
+		}
+		if config.FastSync.Pipeline {
+			options = append(options, bcv0.WithPipelining())
+		}
+
+		bcReactor := bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync, options...)
+		bcReactor.SetLogger(logger)
+		return nil, bcReactor, nil
+
 	case "v2":
-		bcReactor = bcv2.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		reactorShim := p2p.NewReactorShim("BlockchainShim", bcv2.ChannelShims)
+		reactorShim.SetLogger(logger)
+
+		bcReactor := bcv2.NewBlockchainReactor(
+			state.Copy(),
+			blockExec,
+			blockStore,
+			csReactor,
+			reactorShim.GetChannel(bcv2.BlockchainChannel),
+			reactorShim.PeerUpdates,
+			fastSync,
+		)
+		bcReactor.SetLogger(logger)
+		return reactorShim, bcReactor, nil
+
 	default:
-		return nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
+		return nil, nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
+	}
+}
+
+// createLightClient creates a light client from the [statesync] RPC servers
+// and trust options, used to verify fast synced blocks.
+func createLightClient(config *cfg.StateSyncConfig, state sm.State, logger log.Logger) (*light.Client, error) {
+	providers := make([]lightprovider.Provider, 0, len(config.RPCServers))
+	for _, server := range config.RPCServers {
+		provider, err := lighthttp.New(state.ChainID, server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up RPC provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+	if len(providers) < 2 {
+		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(providers))
 	}
 
-	bcReactor.SetLogger(logger.With("module", "blockchain"))
-	return bcReactor, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return light.NewClient(ctx, state.ChainID, light.TrustOptions{
+		Period: config.TrustPeriod,
+		Height: config.TrustHeight,
+		Hash:   config.TrustHashBytes(),
+	}, providers[0], providers[1:], lightdb.New(dbm.NewMemDB(), ""),
+		light.Logger(logger), light.MaxRetryAttempts(5))
 }
 
 func createConsensusReactor(config *cfg.Config,
@@ -529,7 +626,7 @@ func createSwitch(config *cfg.Config,
 	bcReactor p2p.Reactor,
 	stateSyncReactor *p2p.ReactorShim,
 	consensusReactor *cs.Reactor,
-	evidenceReactor *evidence.Reactor,
+	evidenceReactor *p2p.ReactorShim,
 	nodeInfo p2p.NodeInfo,
 	nodeKey p2p.NodeKey,
 	p2pLogger log.Logger) *p2p.Switch {
@@ -608,7 +705,7 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 	stateStore sm.Store, blockStore *store.BlockStore, state sm.State) error {
 	ssR.Logger.Info("Starting state sync")
 
-	if stateProvider == nil {
+	if stateProvider == nil && !config.UseP2P {
 		var err error
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -626,6 +723,24 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 	}
 
 	go func() {
+		// The P2P state provider waits for peers to connect, so it's set up here
+		// rather than before starting the sync.
+		if stateProvider == nil {
+			var err error
+			stateProvider, err = ssR.P2PStateProvider(
+				context.Background(),
+				state.ChainID, state.Version, state.InitialHeight,
+				light.TrustOptions{
+					Period: config.TrustPeriod,
+					Height: config.TrustHeight,
+					Hash:   config.TrustHashBytes(),
+				})
+			if err != nil {
+				ssR.Logger.Error("Failed to set up P2P state provider", "err", err)
+				return
+			}
+		}
+
 		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
@@ -642,6 +757,12 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 			return
 		}
 
+		// Backfilling is best-effort, so the node continues syncing even if it fails.
+		err = ssR.Backfill(state, config.BackfillBlocks, config.BackfillDuration)
+		if err != nil {
+			ssR.Logger.Error("Failed to backfill light blocks", "err", err)
+		}
+
 		if fastSync {
 			// FIXME Very ugly to have these metrics bleed through here.
 			conR.Metrics.StateSyncing.Set(0)
@@ -746,13 +867,14 @@ func NewNode(config *cfg.Config,
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, evMetrics := metricsProvider(genDoc.ChainID)
 
 	// Make MempoolReactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
+	evidenceReactorShim, evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore,
+		evMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -767,12 +889,6 @@ func NewNode(config *cfg.Config,
 		sm.BlockExecutorWithMetrics(smMetrics),
 	)
 
-	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
-	if err != nil {
-		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
-	}
-
 	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or fast sync first.
 	// FIXME We need to update metrics here, since other reactors don't have access to them.
 	if stateSync {
@@ -786,6 +902,22 @@ func NewNode(config *cfg.Config,
 		config, state, blockExec, blockStore, mempool, evidencePool,
 		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger, misbehaviors)
 
+	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
+	bcReactorShim, bcReactor, err := createBlockchainReactor(
+		config, state, blockExec, blockStore, consensusReactor, fastSync && !stateSync, logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
+	}
+
+	// Reactors built on the p2p Channel API are added to the switch through their shim.
+	var bcSwitchReactor p2p.Reactor
+	if bcReactorShim != nil {
+		bcSwitchReactor = bcReactorShim
+	} else {
+		bcSwitchReactor = bcReactor.(p2p.Reactor)
+	}
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -793,15 +925,33 @@ func NewNode(config *cfg.Config,
 	stateSyncReactorShim := p2p.NewReactorShim("StateSyncShim", statesync.ChannelShims)
 	stateSyncReactorShim.SetLogger(logger.With("module", "statesync"))
 
+	// Snapshot chunks are kept in the data dir unless configured otherwise, so
+	// that an interrupted state sync can be resumed after a restart. A configured
+	// dir may be shared by several nodes, so each gets its own dir within.
+	stateSyncDir := config.DBDir()
+	if config.StateSync.TempDir != "" {
+		stateSyncDir = filepath.Join(config.StateSync.TempDir, string(nodeKey.ID))
+	}
 	stateSyncReactor := statesync.NewReactor(
 		stateSyncReactorShim.Logger,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		stateSyncReactorShim.GetChannel(statesync.SnapshotChannel),
 		stateSyncReactorShim.GetChannel(statesync.ChunkChannel),
+		stateSyncReactorShim.GetChannel(statesync.LightBlockChannel),
+		stateSyncReactorShim.GetChannel(statesync.ParamsChannel),
 		stateSyncReactorShim.PeerUpdates,
-		config.StateSync.TempDir,
+		stateStore,
+		blockStore,
+		stateSyncDir,
 	)
+	if len(config.StateSync.SnapshotServers) > 0 {
+		err = stateSyncReactor.SetSnapshotServers(config.StateSync.SnapshotServers,
+			config.StateSync.SnapshotServersOnly)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
@@ -812,8 +962,8 @@ func NewNode(config *cfg.Config,
 	p2pLogger := logger.With("module", "p2p")
 	transport, peerFilters := createTransport(p2pLogger, config, nodeInfo, nodeKey, proxyApp)
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactorShim, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcSwitchReactor,
+		stateSyncReactorShim, consensusReactor, evidenceReactorShim, nodeInfo, nodeKey, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -869,6 +1019,7 @@ func NewNode(config *cfg.Config,
 		stateStore:       stateStore,
 		blockStore:       blockStore,
 		bcReactor:        bcReactor,
+		bcReactorShim:    bcReactorShim,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
 		consensusState:   consensusState,
@@ -878,6 +1029,7 @@ func NewNode(config *cfg.Config,
 		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
 		pexReactor:       pexReactor,
 		evidencePool:     evidencePool,
+		evidenceReactor:  evidenceReactor,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
@@ -949,6 +1101,18 @@ func (n *Node) OnStart() error {
 		return err
 	}
 
+	// Start the real evidence reactor separately since the switch uses the shim.
+	if err := n.evidenceReactor.Start(); err != nil {
+		return err
+	}
+
+	// Start the real blockchain reactor separately if the switch uses the shim.
+	if n.bcReactorShim != nil {
+		if err := n.bcReactor.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Always connect to persistent peers
 	err = n.sw.DialPeersAsync(splitAndTrimEmpty(n.config.P2P.PersistentPeers, ",", " "))
 	if err != nil {
@@ -995,6 +1159,18 @@ func (n *Node) OnStop() {
 		n.Logger.Error("failed to stop state sync service", "err", err)
 	}
 
+	// Stop the real evidence reactor separately since the switch uses the shim.
+	if err := n.evidenceReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop evidence service", "err", err)
+	}
+
+	// Stop the real blockchain reactor separately if the switch uses the shim.
+	if n.bcReactorShim != nil {
+		if err := n.bcReactor.Stop(); err != nil {
+			n.Logger.Error("failed to stop blockchain service", "err", err)
+		}
+	}
+
 	// stop mempool WAL
 	if n.config.Mempool.WalEnabled() {
 		n.mempool.CloseWAL()
@@ -1035,8 +1211,9 @@ func (n *Node) ConfigureRPC() error {
 		return fmt.Errorf("can't get pubkey: %w", err)
 	}
 	rpccore.SetEnvironment(&rpccore.Environment{
-		ProxyAppQuery:   n.proxyApp.Query(),
-		ProxyAppMempool: n.proxyApp.Mempool(),
+		ProxyAppQuery:    n.proxyApp.Query(),
+		ProxyAppMempool:  n.proxyApp.Mempool(),
+		ProxyAppSnapshot: n.proxyApp.Snapshot(),
 
 		StateStore:     n.stateStore,
 		BlockStore:     n.blockStore,
@@ -1296,7 +1473,7 @@ func makeNodeInfo(
 	case "v0":
 		bcChannel = bcv0.BlockchainChannel
 	case "v2":
-		bcChannel = bcv2.BlockchainChannel
+		bcChannel = byte(bcv2.BlockchainChannel)
 	default:
 		return p2p.NodeInfo{}, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
 	}
@@ -1314,8 +1491,9 @@ func makeNodeInfo(
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
-			evidence.EvidenceChannel,
+			byte(evidence.EvidenceChannel),
 			byte(statesync.SnapshotChannel), byte(statesync.ChunkChannel),
+			byte(statesync.LightBlockChannel), byte(statesync.ParamsChannel),
 		},
 		Moniker: config.Moniker,
 		Other: p2p.NodeInfoOther{
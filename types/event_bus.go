@@ -2,16 +2,26 @@ package types
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 )
 
 const defaultCapacity = 0
 
+// ErrEventsNotInHistory is returned by SubscribeSince and EventsAfter when some
+// of the events after the given sequence number aren't in the history anymore
+// (or were never published), or when the sequence number is from another epoch
+// (e.g. if the node restarted).
+var ErrEventsNotInHistory = errors.New("events are not in the history")
+
 type EventBusSubscriber interface {
 	Subscribe(ctx context.Context, subscriber string, query tmpubsub.Query, outCapacity ...int) (Subscription, error)
 	Unsubscribe(ctx context.Context, subscriber string, query tmpubsub.Query) error
@@ -30,25 +40,61 @@ type Subscription interface {
 // EventBus is a common bus for all events going through the system. All calls
 // are proxied to underlying pubsub server. All events must be published using
 // EventBus to ensure correct data types.
+//
+// Each event is given a sequence number, starting at 1, under the EventSeqKey
+// key. As the sequence numbers restart on each run of the EventBus, they're
+// only valid along with its Epoch. The most recent events are kept in a history
+// (see EventHistorySize), so that subscribers can resume from the last event
+// they received (see SubscribeSince), and clients can poll them (see
+// EventsAfter). Events published concurrently may reach the subscribers out of
+// sequence order.
 type EventBus struct {
 	service.BaseService
 	pubsub *tmpubsub.Server
+	epoch  string
+
+	mtx       tmsync.Mutex
+	seq       uint64             // sequence number of the last event
+	history   []tmpubsub.Message // ring buffer, the event seq is at (seq-1) % len(history)
+	published chan struct{}      // closed when the next event is published
 }
 
 // NewEventBus returns a new event bus.
-func NewEventBus() *EventBus {
-	return NewEventBusWithBufferCapacity(defaultCapacity)
+func NewEventBus(options ...func(*EventBus)) *EventBus {
+	return NewEventBusWithBufferCapacity(defaultCapacity, options...)
 }
 
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
-func NewEventBusWithBufferCapacity(cap int) *EventBus {
+func NewEventBusWithBufferCapacity(cap int, options ...func(*EventBus)) *EventBus {
 	// capacity could be exposed later if needed
 	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
-	b := &EventBus{pubsub: pubsub}
+	b := &EventBus{
+		pubsub:    pubsub,
+		epoch:     fmt.Sprintf("%X", tmrand.Bytes(8)),
+		published: make(chan struct{}),
+	}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
+	for _, option := range options {
+		option(b)
+	}
 	return b
 }
 
+// EventHistorySize sets the number of recent events kept in the history. The
+// events are kept with their data, e.g. the whole block of a NewBlock event.
+// Defaults to 0 (no history).
+func EventHistorySize(size int) func(*EventBus) {
+	return func(b *EventBus) {
+		b.history = make([]tmpubsub.Message, size)
+	}
+}
+
+// Epoch returns the random identifier of this run of the EventBus, which must
+// be passed along with the sequence numbers of its events.
+func (b *EventBus) Epoch() string {
+	return b.epoch
+}
+
 func (b *EventBus) SetLogger(l log.Logger) {
 	b.BaseService.SetLogger(l)
 	b.pubsub.SetLogger(l.With("module", "pubsub"))
@@ -81,6 +127,45 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeSince does the same as Subscribe, and also returns the events of
+// the history matching the query published after the event with the given
+// epoch and sequence number, along with the sequence number of the last event looked up.
+// The subscription may receive some of the returned events too: the ones with
+// a sequence number up to the returned one must be skipped. It returns
+// ErrEventsNotInHistory if some of the events aren't in the history.
+func (b *EventBus) SubscribeSince(
+	ctx context.Context,
+	subscriber string,
+	query tmpubsub.Query,
+	epoch string,
+	since uint64,
+	outCapacity ...int,
+) (Subscription, []tmpubsub.Message, uint64, error) {
+	if err := b.checkEpoch(epoch); err != nil {
+		return nil, nil, 0, err
+	}
+
+	// subscribe before looking up the history, so that the events published in
+	// the meantime aren't missed
+	sub, err := b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	history, _, err := b.historyAfter(since)
+	if err == nil {
+		var missed []tmpubsub.Message
+		missed, _, err = matchEvents(query, history, since, len(history))
+		if err == nil {
+			return sub, missed, since + uint64(len(history)), nil
+		}
+	}
+	if err := b.pubsub.Unsubscribe(context.Background(), subscriber, query); err != nil {
+		b.Logger.Error("Failed to unsubscribe", "subscriber", subscriber, "query", query, "err", err)
+	}
+	return nil, nil, 0, err
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -102,7 +187,137 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
+	return b.publish(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
+}
+
+// EventsAfter returns up to limit events of the history matching the query,
+// published after the event with the given epoch and sequence number (after),
+// along with the sequence number of the last event looked up, to pass as after
+// to get the next events, and whether there are more events to look up. If
+// after is 0, only the events published from now on are looked up, whatever
+// the epoch. If no event
+// matches, it waits for new ones until the context is done, in which case no
+// event is returned. It returns ErrEventsNotInHistory if some of the events
+// aren't in the history.
+func (b *EventBus) EventsAfter(
+	ctx context.Context,
+	query tmpubsub.Query,
+	epoch string,
+	after uint64,
+	limit int,
+) (events []tmpubsub.Message, next uint64, more bool, err error) {
+	if after == 0 {
+		b.mtx.Lock()
+		after = b.seq
+		b.mtx.Unlock()
+	} else if err := b.checkEpoch(epoch); err != nil {
+		return nil, after, false, err
+	}
+	for {
+		history, published, err := b.historyAfter(after)
+		if err != nil {
+			return nil, after, false, err
+		}
+		events, next, err = matchEvents(query, history, after, limit)
+		if err != nil || len(events) > 0 {
+			more = next < after+uint64(len(history))
+			return events, next, more, err
+		}
+
+		select {
+		case <-published:
+		case <-ctx.Done():
+			return nil, next, false, nil
+		}
+		after = next
+	}
+}
+
+// checkEpoch returns ErrEventsNotInHistory if the given epoch isn't the epoch
+// of the EventBus.
+func (b *EventBus) checkEpoch(epoch string) error {
+	if epoch != b.epoch {
+		return fmt.Errorf("%w: epoch %q doesn't match the current epoch %q (the node restarted?)",
+			ErrEventsNotInHistory, epoch, b.epoch)
+	}
+	return nil
+}
+
+// historyAfter returns a copy of the events of the history published after the
+// event with the given sequence number, and a channel closed when the next
+// event is published.
+func (b *EventBus) historyAfter(after uint64) ([]tmpubsub.Message, <-chan struct{}, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if after > b.seq || b.seq-after > uint64(len(b.history)) {
+		return nil, nil, fmt.Errorf("%w: can't look up events after %d (latest is %d, history size is %d)",
+			ErrEventsNotInHistory, after, b.seq, len(b.history))
+	}
+	history := make([]tmpubsub.Message, 0, b.seq-after)
+	for seq := after + 1; seq <= b.seq; seq++ {
+		history = append(history, b.history[(seq-1)%uint64(len(b.history))])
+	}
+	return history, b.published, nil
+}
+
+// matchEvents returns up to limit events matching the query, out of the given
+// events published after the event with the given sequence number, and the
+// sequence number of the last event looked up.
+func matchEvents(
+	query tmpubsub.Query,
+	history []tmpubsub.Message,
+	after uint64,
+	limit int,
+) ([]tmpubsub.Message, uint64, error) {
+	var (
+		events []tmpubsub.Message
+		seq    = after
+	)
+	for _, msg := range history {
+		if len(events) >= limit {
+			break
+		}
+		seq++
+		match, err := query.Matches(msg.Events())
+		if err != nil {
+			return nil, after, fmt.Errorf("failed to match event %d: %w", seq, err)
+		}
+		if match {
+			events = append(events, msg)
+		}
+	}
+	return events, seq, nil
+}
+
+// publish sets the sequence number of the event, adds it to the history and
+// publishes it.
+func (b *EventBus) publish(ctx context.Context, eventData TMEventData, events map[string][]string) error {
+	b.mtx.Lock()
+	b.seq++
+	events[EventSeqKey] = []string{strconv.FormatUint(b.seq, 10)}
+	if len(b.history) > 0 {
+		b.history[(b.seq-1)%uint64(len(b.history))] = tmpubsub.NewMessage(eventData, events)
+	}
+	close(b.published)
+	b.published = make(chan struct{})
+	b.mtx.Unlock()
+
+	return b.pubsub.PublishWithEvents(ctx, eventData, events)
+}
+
+// EventSeq returns the sequence number of an event published by the EventBus,
+// given its events, or 0 if it has none.
+func EventSeq(events map[string][]string) uint64 {
+	values := events[EventSeqKey]
+	if len(values) == 0 {
+		return 0
+	}
+	seq, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return seq
 }
 
 // validateAndStringifyEvents takes a slice of event objects and creates a
@@ -141,7 +356,7 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publish(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
@@ -155,7 +370,7 @@ func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) erro
 	// add predefined new block header event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockHeader)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publish(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error {
@@ -184,7 +399,7 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publish(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
@@ -227,6 +442,10 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventValidatorSigning(data EventDataValidatorSigning) error {
+	return b.Publish(EventValidatorSigning, data)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -306,3 +525,7 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventValidatorSigning(data EventDataValidatorSigning) error {
+	return nil
+}
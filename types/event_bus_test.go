@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -313,6 +314,125 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusSubscribeSince(t *testing.T) {
+	eventBus := NewEventBus(EventHistorySize(3))
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	epoch := eventBus.Epoch()
+	require.NotEmpty(t, epoch)
+	assert.NotEqual(t, epoch, NewEventBus().Epoch())
+
+	// events 1 to 4, the first one being out of the history
+	for i := 0; i < 2; i++ {
+		require.NoError(t, eventBus.PublishEventVote(EventDataVote{}))
+		require.NoError(t, eventBus.PublishEventLock(EventDataRoundState{}))
+	}
+
+	sub, missed, last, err := eventBus.SubscribeSince(context.Background(), "test", EventQueryVote, epoch, 1, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, last)
+	require.Len(t, missed, 1)
+	assert.EqualValues(t, 3, EventSeq(missed[0].Events()))
+	assert.Equal(t, EventDataVote{}, missed[0].Data())
+
+	// the subscription receives the next events
+	require.NoError(t, eventBus.PublishEventVote(EventDataVote{}))
+	select {
+	case msg := <-sub.Out():
+		assert.EqualValues(t, 5, EventSeq(msg.Events()))
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive the event after 1 sec.")
+	}
+
+	// events which aren't in the history can't be replayed
+	_, _, _, err = eventBus.SubscribeSince(context.Background(), "other", EventQueryVote, epoch, 1)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+	_, _, _, err = eventBus.SubscribeSince(context.Background(), "other", EventQueryVote, epoch, 6)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+	_, _, _, err = eventBus.SubscribeSince(context.Background(), "other", EventQueryVote, "other", 5)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+	assert.Zero(t, eventBus.NumClientSubscriptions("other"))
+
+	_, missed, last, err = eventBus.SubscribeSince(context.Background(), "other", EventQueryVote, epoch, 5)
+	require.NoError(t, err)
+	assert.Empty(t, missed)
+	assert.EqualValues(t, 5, last)
+}
+
+func TestEventBusEventsAfter(t *testing.T) {
+	eventBus := NewEventBus(EventHistorySize(4))
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	epoch := eventBus.Epoch()
+
+	// events 1 to 4
+	for i := 0; i < 2; i++ {
+		require.NoError(t, eventBus.PublishEventVote(EventDataVote{}))
+		require.NoError(t, eventBus.PublishEventLock(EventDataRoundState{}))
+	}
+
+	// without a cursor, only new events are looked up
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	events, next, more, err := eventBus.EventsAfter(ctx, EventQueryVote, epoch, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.EqualValues(t, 4, next)
+	assert.False(t, more)
+
+	// the events are limited
+	events, next, more, err = eventBus.EventsAfter(context.Background(), EventQueryLock, epoch, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 2, EventSeq(events[0].Events()))
+	assert.EqualValues(t, 2, next)
+	assert.True(t, more)
+	events, next, more, err = eventBus.EventsAfter(context.Background(), EventQueryLock, epoch, next, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 4, EventSeq(events[0].Events()))
+	assert.EqualValues(t, 4, next)
+	assert.False(t, more)
+
+	// it waits for a matching event
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		if err := eventBus.PublishEventLock(EventDataRoundState{}); err != nil {
+			t.Error(err)
+		}
+		if err := eventBus.PublishEventVote(EventDataVote{}); err != nil {
+			t.Error(err)
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	events, next, _, err = eventBus.EventsAfter(ctx, EventQueryVote, epoch, 4, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 6, EventSeq(events[0].Events()))
+	assert.EqualValues(t, 6, next)
+
+	// events which aren't in the history can't be looked up
+	_, _, _, err = eventBus.EventsAfter(context.Background(), EventQueryVote, epoch, 1, 10)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+	_, _, _, err = eventBus.EventsAfter(context.Background(), EventQueryVote, epoch, 7, 10)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+	_, _, _, err = eventBus.EventsAfter(context.Background(), EventQueryVote, "other", 6, 10)
+	assert.True(t, errors.Is(err, ErrEventsNotInHistory))
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -323,7 +443,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 15
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, numEventsExpected)
 	require.NoError(t, err)
@@ -368,6 +488,8 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventValidatorSigning(EventDataValidatorSigning{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
@@ -36,6 +36,11 @@ const (
 	EventUnlock           = "Unlock"
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
+
+	// Validator self-monitoring events.
+	// These are triggered from the consensus package for the node's own
+	// validator, after a block has been committed.
+	EventValidatorSigning = "ValidatorSigning"
 )
 
 // ENCODING / DECODING
@@ -55,6 +60,7 @@ func init() {
 	tmjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	tmjson.RegisterType(EventDataValidatorSigning{}, "tendermint/event/ValidatorSigning")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 }
 
@@ -125,6 +131,26 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// ValidatorSigningInfo summarizes how many of the most recent commits a
+// validator failed to sign.
+type ValidatorSigningInfo struct {
+	Address Address `json:"address"`
+	// Number of commits checked so far, capped at the configured window.
+	Window int64 `json:"window"`
+	// Number of commits within the window missing the validator's signature.
+	Missed           int64 `json:"missed"`
+	LastSignedHeight int64 `json:"last_signed_height"`
+}
+
+// EventDataValidatorSigning is fired for the node's own validator each time a
+// commit is checked for its signature.
+type EventDataValidatorSigning struct {
+	Height int64 `json:"height"`
+	Signed bool  `json:"signed"`
+
+	SigningInfo ValidatorSigningInfo `json:"signing_info"`
+}
+
 // PUBSUB
 
 const (
@@ -136,6 +162,10 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// EventSeqKey is a reserved composite key for the sequence number of an
+	// event, set by the EventBus.
+	// see EventBus#SubscribeSince
+	EventSeqKey = "tm.seq"
 )
 
 var (
@@ -154,6 +184,7 @@ var (
 	EventQueryUnlock              = QueryForEvent(EventUnlock)
 	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
 	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
+	EventQueryValidatorSigning    = QueryForEvent(EventValidatorSigning)
 	EventQueryVote                = QueryForEvent(EventVote)
 )
 
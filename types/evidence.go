@@ -409,6 +409,228 @@ func LightClientAttackEvidenceFromProto(lpb *tmproto.LightClientAttackEvidence)
 	return l, l.ValidateBasic()
 }
 
+//------------------------------------ AMNESIA EVIDENCE -------------------------------------
+
+// AmnesiaEvidence contains evidence of a validator which precommitted a block
+// in one round, thereby locking on it, and prevoted a different block in a
+// later round of the same height, even though no proof of lock change (+2/3
+// prevotes) can exist in the rounds after the precommit, up to and including
+// the round of the prevote. This is proven by the lock proof: prevotes for the
+// precommitted block holding +1/3 of the voting power in every such round.
+type AmnesiaEvidence struct {
+	Precommit *Vote   `json:"precommit"`
+	Prevote   *Vote   `json:"prevote"`
+	LockProof []*Vote `json:"lock_proof"`
+
+	// abci specific information
+	TotalVotingPower int64
+	ValidatorPower   int64
+	Timestamp        time.Time
+}
+
+var _ Evidence = &AmnesiaEvidence{}
+
+// NewAmnesiaEvidence creates AmnesiaEvidence given the precommit the validator
+// locked with, the later prevote it signed for another block and the prevotes
+// proving the lock in the rounds after the precommit, up to and including the
+// round of the prevote. If one of the votes is nil or the
+// validator is not in the set, evidence returned is nil.
+func NewAmnesiaEvidence(precommit, prevote *Vote, lockProof []*Vote, blockTime time.Time,
+	valSet *ValidatorSet) *AmnesiaEvidence {
+	if precommit == nil || prevote == nil || valSet == nil {
+		return nil
+	}
+	idx, val := valSet.GetByAddress(precommit.ValidatorAddress)
+	if idx == -1 {
+		return nil
+	}
+
+	return &AmnesiaEvidence{
+		Precommit:        precommit,
+		Prevote:          prevote,
+		LockProof:        lockProof,
+		TotalVotingPower: valSet.TotalVotingPower(),
+		ValidatorPower:   val.VotingPower,
+		Timestamp:        blockTime,
+	}
+}
+
+// ABCI returns the application relevant representation of the evidence
+func (ae *AmnesiaEvidence) ABCI() []abci.Evidence {
+	return []abci.Evidence{{
+		Type: abci.EvidenceType_AMNESIA,
+		Validator: abci.Validator{
+			Address: ae.Precommit.ValidatorAddress,
+			Power:   ae.ValidatorPower,
+		},
+		Height:           ae.Precommit.Height,
+		Time:             ae.Timestamp,
+		TotalVotingPower: ae.TotalVotingPower,
+	}}
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (ae *AmnesiaEvidence) Bytes() []byte {
+	pbe := ae.ToProto()
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the evidence. It only covers the votes of the
+// infraction, not the lock proof, such that evidence for the same infraction
+// with different lock proofs is the same.
+func (ae *AmnesiaEvidence) Hash() []byte {
+	precommitBz, err := ae.Precommit.ToProto().Marshal()
+	if err != nil {
+		panic(err)
+	}
+	prevoteBz, err := ae.Prevote.ToProto().Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return tmhash.Sum(append(precommitBz, prevoteBz...))
+}
+
+// Height returns the height of the infraction
+func (ae *AmnesiaEvidence) Height() int64 {
+	return ae.Precommit.Height
+}
+
+// String returns a string representation of the evidence.
+func (ae *AmnesiaEvidence) String() string {
+	return fmt.Sprintf("AmnesiaEvidence{Precommit: %v, Prevote: %v}", ae.Precommit, ae.Prevote)
+}
+
+// Time returns the time of the infraction
+func (ae *AmnesiaEvidence) Time() time.Time {
+	return ae.Timestamp
+}
+
+// ValidateBasic performs basic validation.
+func (ae *AmnesiaEvidence) ValidateBasic() error {
+	if ae == nil {
+		return errors.New("empty amnesia evidence")
+	}
+
+	if ae.Precommit == nil || ae.Prevote == nil {
+		return fmt.Errorf("one or both of the votes are empty %v, %v", ae.Precommit, ae.Prevote)
+	}
+	if err := ae.Precommit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid precommit: %w", err)
+	}
+	if err := ae.Prevote.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid prevote: %w", err)
+	}
+
+	if ae.Precommit.Type != tmproto.PrecommitType {
+		return fmt.Errorf("expected precommit, got %v", ae.Precommit.Type)
+	}
+	if ae.Prevote.Type != tmproto.PrevoteType {
+		return fmt.Errorf("expected prevote, got %v", ae.Prevote.Type)
+	}
+	if ae.Precommit.Height != ae.Prevote.Height {
+		return fmt.Errorf("votes are for different heights: %d vs %d", ae.Precommit.Height, ae.Prevote.Height)
+	}
+	if ae.Precommit.Round >= ae.Prevote.Round {
+		return fmt.Errorf("prevote round %d must be after precommit round %d", ae.Prevote.Round, ae.Precommit.Round)
+	}
+	if !bytes.Equal(ae.Precommit.ValidatorAddress, ae.Prevote.ValidatorAddress) {
+		return fmt.Errorf("validator addresses do not match: %X vs %X",
+			ae.Precommit.ValidatorAddress, ae.Prevote.ValidatorAddress)
+	}
+
+	// precommitting nil doesn't lock, and prevoting nil doesn't change the lock
+	if ae.Precommit.BlockID.IsZero() || ae.Prevote.BlockID.IsZero() {
+		return errors.New("votes must be for blocks, not nil")
+	}
+	if ae.Precommit.BlockID.Equals(ae.Prevote.BlockID) {
+		return fmt.Errorf("block IDs are the same (%v) - not a real amnesia attack", ae.Precommit.BlockID)
+	}
+
+	// the lock proof must hold prevotes for the precommitted block in every
+	// round after the precommit up to the prevote, as a validator also unlocks on
+	// +2/3 prevotes for another block in the round it prevotes in
+	rounds := make(map[int32]bool, ae.Prevote.Round-ae.Precommit.Round)
+	for i, vote := range ae.LockProof {
+		if vote == nil {
+			return fmt.Errorf("lock proof vote #%d is empty", i)
+		}
+		if err := vote.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid lock proof vote #%d: %w", i, err)
+		}
+		if vote.Type != tmproto.PrevoteType || vote.Height != ae.Precommit.Height ||
+			vote.Round <= ae.Precommit.Round || vote.Round > ae.Prevote.Round ||
+			!vote.BlockID.Equals(ae.Precommit.BlockID) {
+			return fmt.Errorf("lock proof vote #%d is not a prevote for the precommitted block "+
+				"in a round after the precommit up to the prevote: %v", i, vote)
+		}
+		rounds[vote.Round] = true
+	}
+	for round := ae.Precommit.Round + 1; round <= ae.Prevote.Round; round++ {
+		if !rounds[round] {
+			return fmt.Errorf("lock proof has no prevotes for round %d", round)
+		}
+	}
+	return nil
+}
+
+// ToProto encodes AmnesiaEvidence to protobuf
+func (ae *AmnesiaEvidence) ToProto() *tmproto.AmnesiaEvidence {
+	lockProof := make([]*tmproto.Vote, len(ae.LockProof))
+	for i, vote := range ae.LockProof {
+		lockProof[i] = vote.ToProto()
+	}
+	return &tmproto.AmnesiaEvidence{
+		Precommit:        ae.Precommit.ToProto(),
+		Prevote:          ae.Prevote.ToProto(),
+		TotalVotingPower: ae.TotalVotingPower,
+		ValidatorPower:   ae.ValidatorPower,
+		Timestamp:        ae.Timestamp,
+		LockProof:        lockProof,
+	}
+}
+
+// AmnesiaEvidenceFromProto decodes protobuf into AmnesiaEvidence
+func AmnesiaEvidenceFromProto(pb *tmproto.AmnesiaEvidence) (*AmnesiaEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil amnesia evidence")
+	}
+
+	precommit, err := VoteFromProto(pb.Precommit)
+	if err != nil {
+		return nil, err
+	}
+
+	prevote, err := VoteFromProto(pb.Prevote)
+	if err != nil {
+		return nil, err
+	}
+
+	var lockProof []*Vote
+	for _, pbv := range pb.LockProof {
+		vote, err := VoteFromProto(pbv)
+		if err != nil {
+			return nil, err
+		}
+		lockProof = append(lockProof, vote)
+	}
+
+	ae := &AmnesiaEvidence{
+		Precommit:        precommit,
+		Prevote:          prevote,
+		LockProof:        lockProof,
+		TotalVotingPower: pb.TotalVotingPower,
+		ValidatorPower:   pb.ValidatorPower,
+		Timestamp:        pb.Timestamp,
+	}
+
+	return ae, ae.ValidateBasic()
+}
+
 //------------------------------------------------------------------------------------------
 
 // EvidenceList is a list of Evidence. Evidences is not a word.
@@ -473,6 +695,13 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 			},
 		}, nil
 
+	case *AmnesiaEvidence:
+		return &tmproto.Evidence{
+			Sum: &tmproto.Evidence_AmnesiaEvidence{
+				AmnesiaEvidence: evi.ToProto(),
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
 	}
@@ -490,6 +719,8 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
 	case *tmproto.Evidence_LightClientAttackEvidence:
 		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
+	case *tmproto.Evidence_AmnesiaEvidence:
+		return AmnesiaEvidenceFromProto(evi.AmnesiaEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -498,6 +729,7 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 func init() {
 	tmjson.RegisterType(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence")
 	tmjson.RegisterType(&LightClientAttackEvidence{}, "tendermint/LightClientAttackEvidence")
+	tmjson.RegisterType(&AmnesiaEvidence{}, "tendermint/AmnesiaEvidence")
 }
 
 //-------------------------------------------- ERRORS --------------------------------------
@@ -560,6 +792,31 @@ func NewMockDuplicateVoteEvidenceWithValidator(height int64, time time.Time,
 	return NewDuplicateVoteEvidence(voteA, voteB, time, NewValidatorSet([]*Validator{val}))
 }
 
+// assumes voting power to be 10 for both validators and them to be the only ones
+// in the set, lockPV prevoting for the block pv precommitted in the next round
+func NewMockAmnesiaEvidenceWithValidators(height int64, time time.Time,
+	pv, lockPV PrivValidator, chainID string) *AmnesiaEvidence {
+	pubKey, _ := pv.GetPubKey()
+	lockPubKey, _ := lockPV.GetPubKey()
+	valSet := NewValidatorSet([]*Validator{NewValidator(pubKey, 10), NewValidator(lockPubKey, 10)})
+	blockID := randBlockID()
+	precommit := makeMockVote(height, 0, 0, pubKey.Address(), blockID, time)
+	pc := precommit.ToProto()
+	_ = pv.SignVote(chainID, pc)
+	precommit.Signature = pc.Signature
+	prevote := makeMockVote(height, 1, 0, pubKey.Address(), randBlockID(), time)
+	prevote.Type = tmproto.PrevoteType
+	pv1 := prevote.ToProto()
+	_ = pv.SignVote(chainID, pv1)
+	prevote.Signature = pv1.Signature
+	lockVote := makeMockVote(height, 1, 1, lockPubKey.Address(), blockID, time)
+	lockVote.Type = tmproto.PrevoteType
+	lv := lockVote.ToProto()
+	_ = lockPV.SignVote(chainID, lv)
+	lockVote.Signature = lv.Signature
+	return NewAmnesiaEvidence(precommit, prevote, []*Vote{lockVote}, time, valSet)
+}
+
 func makeMockVote(height int64, round, index int32, addr Address,
 	blockID BlockID, time time.Time) *Vote {
 	return &Vote{
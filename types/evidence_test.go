@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -213,9 +214,99 @@ func TestLightClientAttackEvidenceValidation(t *testing.T) {
 
 }
 
+func TestAmnesiaEvidence(t *testing.T) {
+	const height = int64(13)
+	ev := NewMockAmnesiaEvidenceWithValidators(height, time.Now(), NewMockPV(), NewMockPV(), "mock-chain-id")
+	hash := ev.Hash()
+	assert.Len(t, hash, tmhash.Size)
+	// the hash only covers the infraction, not the lock proof
+	ev.LockProof = []*Vote{ev.Precommit}
+	assert.Equal(t, hash, ev.Hash())
+	assert.NotNil(t, ev.String())
+	assert.Equal(t, ev.Height(), height)
+	require.Len(t, ev.ABCI(), 1)
+	assert.Equal(t, abci.EvidenceType_AMNESIA, ev.ABCI()[0].Type)
+	assert.Equal(t, ev.Precommit.ValidatorAddress, Address(ev.ABCI()[0].Validator.Address))
+}
+
+func TestAmnesiaEvidenceValidation(t *testing.T) {
+	val := NewMockPV()
+	val2 := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+
+	testCases := []struct {
+		testName         string
+		malleateEvidence func(*AmnesiaEvidence)
+		expectErr        bool
+	}{
+		{"Good AmnesiaEvidence", func(ev *AmnesiaEvidence) {}, false},
+		{"Nil precommit", func(ev *AmnesiaEvidence) { ev.Precommit = nil }, true},
+		{"Nil prevote", func(ev *AmnesiaEvidence) { ev.Prevote = nil }, true},
+		{"Precommit is a prevote", func(ev *AmnesiaEvidence) {
+			ev.Precommit = makeVote(t, val, chainID, 0, 10, 1, 0x01, blockID, defaultVoteTime)
+		}, true},
+		{"Prevote is a precommit", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 3, 0x02, blockID2, defaultVoteTime)
+		}, true},
+		{"Different heights", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 11, 3, 0x01, blockID2, defaultVoteTime)
+		}, true},
+		{"Prevote in the same round", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 1, 0x01, blockID2, defaultVoteTime)
+		}, true},
+		{"Prevote in an earlier round", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 0, 0x01, blockID2, defaultVoteTime)
+		}, true},
+		{"Different validators", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, NewMockPV(), chainID, 0, 10, 3, 0x01, blockID2, defaultVoteTime)
+		}, true},
+		{"Prevote for nil", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 3, 0x01, BlockID{}, defaultVoteTime)
+		}, true},
+		{"Prevote for the same block", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 3, 0x01, blockID, defaultVoteTime)
+		}, true},
+		{"No lock proof", func(ev *AmnesiaEvidence) { ev.LockProof = nil }, true},
+		{"No lock proof in the prevote round", func(ev *AmnesiaEvidence) { ev.LockProof = ev.LockProof[:1] }, true},
+		{"Prevote in the next round", func(ev *AmnesiaEvidence) {
+			ev.Prevote = makeVote(t, val, chainID, 0, 10, 2, 0x01, blockID2, defaultVoteTime)
+			ev.LockProof = ev.LockProof[:1]
+		}, false},
+		{"Nil lock proof vote", func(ev *AmnesiaEvidence) { ev.LockProof = []*Vote{nil} }, true},
+		{"Lock proof for another block", func(ev *AmnesiaEvidence) {
+			ev.LockProof[0] = makeVote(t, val2, chainID, 1, 10, 2, 0x01, blockID2, defaultVoteTime)
+		}, true},
+		{"Lock proof with a precommit", func(ev *AmnesiaEvidence) {
+			ev.LockProof[0] = makeVote(t, val2, chainID, 1, 10, 2, 0x02, blockID, defaultVoteTime)
+		}, true},
+		{"Lock proof outside the rounds", func(ev *AmnesiaEvidence) {
+			ev.LockProof = append(ev.LockProof, makeVote(t, val2, chainID, 1, 10, 4, 0x01, blockID, defaultVoteTime))
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			precommit := makeVote(t, val, chainID, 0, 10, 1, 0x02, blockID, defaultVoteTime)
+			prevote := makeVote(t, val, chainID, 0, 10, 3, 0x01, blockID2, defaultVoteTime)
+			lockProof := []*Vote{
+				makeVote(t, val2, chainID, 1, 10, 2, 0x01, blockID, defaultVoteTime),
+				makeVote(t, val2, chainID, 1, 10, 3, 0x01, blockID, defaultVoteTime),
+			}
+			valSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10), val2.ExtractIntoValidator(10)})
+			ev := NewAmnesiaEvidence(precommit, prevote, lockProof, defaultVoteTime, valSet)
+			tc.malleateEvidence(ev)
+			assert.Equal(t, tc.expectErr, ev.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestMockEvidenceValidateBasic(t *testing.T) {
 	goodEvidence := NewMockDuplicateVoteEvidence(int64(1), time.Now(), "mock-chain-id")
 	assert.Nil(t, goodEvidence.ValidateBasic())
+	goodAmnesia := NewMockAmnesiaEvidenceWithValidators(int64(1), time.Now(), NewMockPV(), NewMockPV(), "mock-chain-id")
+	assert.Nil(t, goodAmnesia.ValidateBasic())
 }
 
 func makeVote(
@@ -297,6 +388,9 @@ func TestEvidenceProto(t *testing.T) {
 		{"DuplicateVoteEvidence nil voteB", &DuplicateVoteEvidence{VoteA: v, VoteB: nil}, false, true},
 		{"DuplicateVoteEvidence nil voteA", &DuplicateVoteEvidence{VoteA: nil, VoteB: v}, false, true},
 		{"DuplicateVoteEvidence success", &DuplicateVoteEvidence{VoteA: v2, VoteB: v}, false, false},
+		{"AmnesiaEvidence empty fail", &AmnesiaEvidence{}, false, true},
+		{"AmnesiaEvidence success", NewMockAmnesiaEvidenceWithValidators(height, defaultVoteTime, val, NewMockPV(), chainID),
+			false, false},
 	}
 	for _, tt := range tests {
 		tt := tt
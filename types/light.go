@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tendermint/tendermint/crypto/merkle"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -113,6 +114,161 @@ func LightBlockFromProto(pb *tmproto.LightBlock) (*LightBlock, error) {
 
 //-----------------------------------------------------------------------------
 
+// ValidatorProof is a validator along with a Merkle proof of its inclusion in
+// a validator set, i.e. against the hash of the validator set.
+type ValidatorProof struct {
+	Validator *Validator    `json:"validator"`
+	Proof     *merkle.Proof `json:"proof"`
+}
+
+// Verify checks the proof proves the validator is part of the validator set
+// with the given hash, at index Proof.Index.
+func (vp ValidatorProof) Verify(valsHash []byte) error {
+	if vp.Validator == nil {
+		return errors.New("missing validator")
+	}
+	if vp.Proof == nil {
+		return errors.New("missing proof")
+	}
+	if err := vp.Validator.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid validator: %w", err)
+	}
+	if err := vp.Proof.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid proof: %w", err)
+	}
+	return vp.Proof.Verify(valsHash, vp.Validator.Bytes())
+}
+
+// HeaderOnlyLightBlock is a SignedHeader along with the validators which
+// signed its commit, each with a Merkle proof against the ValidatorsHash of
+// the header. For large validator sets, it is much smaller than the
+// LightBlock, as it doesn't contain the validators which didn't sign.
+//
+// TotalVotingPower is the total voting power of the validator set, as
+// reported by the provider: unlike the signers, it can't be proven without
+// the whole validator set.
+type HeaderOnlyLightBlock struct {
+	*SignedHeader    `json:"signed_header"`
+	Signers          []ValidatorProof `json:"signers"`
+	TotalVotingPower int64            `json:"total_voting_power"`
+}
+
+// NewHeaderOnlyLightBlock returns the HeaderOnlyLightBlock of the given signed
+// header, whose commit was signed by the given validator set.
+func NewHeaderOnlyLightBlock(sh *SignedHeader, vals *ValidatorSet) (*HeaderOnlyLightBlock, error) {
+	if sh == nil || sh.Commit == nil {
+		return nil, errors.New("missing commit")
+	}
+	if vals.Size() != len(sh.Commit.Signatures) {
+		return nil, NewErrInvalidCommitSignatures(vals.Size(), len(sh.Commit.Signatures))
+	}
+
+	bzs := make([][]byte, len(vals.Validators))
+	for i, val := range vals.Validators {
+		bzs[i] = val.Bytes()
+	}
+	_, proofs := merkle.ProofsFromByteSlices(bzs)
+
+	signers := make([]ValidatorProof, 0, len(sh.Commit.Signatures))
+	for idx, commitSig := range sh.Commit.Signatures {
+		if !commitSig.ForBlock() {
+			continue
+		}
+		signers = append(signers, ValidatorProof{
+			Validator: vals.Validators[idx].Copy(),
+			Proof:     proofs[idx],
+		})
+	}
+
+	return &HeaderOnlyLightBlock{
+		SignedHeader:     sh,
+		Signers:          signers,
+		TotalVotingPower: vals.TotalVotingPower(),
+	}, nil
+}
+
+// ValidateBasic checks that the data is correct and consistent: each signer
+// must be proven to be part of the validator set of the header and must have
+// signed the commit for the block, at the index of its proof.
+//
+// This does no verification of the signatures
+func (hlb HeaderOnlyLightBlock) ValidateBasic(chainID string) error {
+	if hlb.SignedHeader == nil {
+		return errors.New("missing signed header")
+	}
+	if err := hlb.SignedHeader.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+
+	var (
+		signatures  = hlb.Commit.Signatures
+		signedPower = int64(0)
+		lastIndex   = int64(-1)
+	)
+	for i, signer := range hlb.Signers {
+		if err := signer.Verify(hlb.ValidatorsHash); err != nil {
+			return fmt.Errorf("invalid signer #%d: %w", i, err)
+		}
+
+		idx := signer.Proof.Index
+		if signer.Proof.Total != int64(len(signatures)) {
+			return fmt.Errorf("signer #%d is part of a set of %d validators, commit has %d signatures",
+				i, signer.Proof.Total, len(signatures))
+		}
+		if idx <= lastIndex {
+			return fmt.Errorf("signer #%d at index %d is out of order", i, idx)
+		}
+		lastIndex = idx
+
+		if commitSig := signatures[idx]; !commitSig.ForBlock() {
+			return fmt.Errorf("signer #%d at index %d didn't sign the block", i, idx)
+		} else if !bytes.Equal(commitSig.ValidatorAddress, signer.Validator.Address) {
+			return fmt.Errorf("signer #%d address %X doesn't match the commit signature address %X",
+				i, signer.Validator.Address, commitSig.ValidatorAddress)
+		}
+
+		signedPower = safeAddClip(signedPower, signer.Validator.VotingPower)
+	}
+
+	if hlb.TotalVotingPower < signedPower || hlb.TotalVotingPower > MaxTotalVotingPower {
+		return fmt.Errorf("total voting power %d must be within [%d, %d]",
+			hlb.TotalVotingPower, signedPower, MaxTotalVotingPower)
+	}
+
+	return nil
+}
+
+// VerifyCommitLight verifies +2/3 of TotalVotingPower signed the commit, like
+// ValidatorSet.VerifyCommitLight, from the signers only. hlb must be valid
+// (see ValidateBasic).
+//
+// NOTE: TotalVotingPower isn't proven, so a block must not be trusted on the
+// sole basis of this verification.
+func (hlb HeaderOnlyLightBlock) VerifyCommitLight(chainID string) error {
+	talliedVotingPower := int64(0)
+	votingPowerNeeded := hlb.TotalVotingPower * 2 / 3
+	for _, signer := range hlb.Signers {
+		idx := int32(signer.Proof.Index)
+
+		// Validate signature.
+		voteSignBytes := hlb.Commit.VoteSignBytes(chainID, idx)
+		if !signer.Validator.PubKey.VerifySignature(voteSignBytes, hlb.Commit.Signatures[idx].Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, hlb.Commit.Signatures[idx].Signature)
+		}
+
+		talliedVotingPower += signer.Validator.VotingPower
+
+		// return as soon as +2/3 of the signatures are verified
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+}
+
+//-----------------------------------------------------------------------------
+
 // SignedHeader is a header along with the commits that prove it.
 type SignedHeader struct {
 	*Header `json:"header"`
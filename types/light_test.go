@@ -6,9 +6,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	tmtime "github.com/tendermint/tendermint/types/time"
 	"github.com/tendermint/tendermint/version"
 )
 
@@ -162,3 +166,74 @@ func TestSignedHeaderValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestHeaderOnlyLightBlock(t *testing.T) {
+	voteSet, vals, privVals := randVoteSet(1, 0, tmproto.PrecommitType, 4, 10)
+	header := makeRandHeader()
+	header.ChainID = voteSet.ChainID()
+	header.Height = 1
+	header.ValidatorsHash = vals.Hash()
+	header.Version.Block = version.BlockProtocol
+	blockID := makeBlockID(header.Hash(), 1, tmhash.Sum([]byte("parts")))
+
+	// all but the third validator sign
+	for i, privVal := range privVals {
+		if i == 2 {
+			continue
+		}
+		pubKey, err := privVal.GetPubKey()
+		require.NoError(t, err)
+		_, err = signAddVote(privVal, &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   int32(i),
+			Height:           1,
+			Type:             tmproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        tmtime.Now(),
+		}, voteSet)
+		require.NoError(t, err)
+	}
+	sh := &SignedHeader{Header: &header, Commit: voteSet.MakeCommit()}
+
+	hlb, err := NewHeaderOnlyLightBlock(sh, vals)
+	require.NoError(t, err)
+	require.Len(t, hlb.Signers, 3)
+	assert.EqualValues(t, 3, hlb.Signers[2].Proof.Index)
+	assert.EqualValues(t, 40, hlb.TotalVotingPower)
+	require.NoError(t, hlb.ValidateBasic(header.ChainID))
+	require.NoError(t, hlb.VerifyCommitLight(header.ChainID))
+
+	otherVals, _ := RandValidatorSet(4, 10)
+
+	testCases := []struct {
+		name     string
+		malleate func(hlb *HeaderOnlyLightBlock)
+	}{
+		{"signer from another set", func(hlb *HeaderOnlyLightBlock) {
+			hlb.Signers[0].Validator = otherVals.Validators[0]
+		}},
+		{"wrong proof", func(hlb *HeaderOnlyLightBlock) {
+			hlb.Signers[0].Proof = hlb.Signers[1].Proof
+		}},
+		{"signers out of order", func(hlb *HeaderOnlyLightBlock) {
+			hlb.Signers[0], hlb.Signers[1] = hlb.Signers[1], hlb.Signers[0]
+		}},
+		{"total voting power lower than the signers one", func(hlb *HeaderOnlyLightBlock) {
+			hlb.TotalVotingPower = 20
+		}},
+		{"missing signed header", func(hlb *HeaderOnlyLightBlock) {
+			hlb.SignedHeader = nil
+		}},
+	}
+	for _, tc := range testCases {
+		hlb, err := NewHeaderOnlyLightBlock(sh, vals)
+		require.NoError(t, err)
+		tc.malleate(hlb)
+		assert.Error(t, hlb.ValidateBasic(header.ChainID), tc.name)
+	}
+
+	// not enough voting power signed
+	hlb.TotalVotingPower = 100
+	require.NoError(t, hlb.ValidateBasic(header.ChainID))
+	assert.IsType(t, ErrNotEnoughVotingPowerSigned{}, hlb.VerifyCommitLight(header.ChainID))
+}
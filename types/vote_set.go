@@ -364,6 +364,44 @@ func (voteSet *VoteSet) BitArrayByBlockID(blockID BlockID) *bits.BitArray {
 	return nil
 }
 
+// VotingPowerFor returns the voting power of the validators whose votes for
+// blockID have been seen. Conflicting votes are only counted if blockID was
+// claimed as a +2/3 majority by a peer.
+func (voteSet *VoteSet) VotingPowerFor(blockID BlockID) int64 {
+	if voteSet == nil {
+		return 0
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	votesByBlock, ok := voteSet.votesByBlock[blockID.Key()]
+	if ok {
+		return votesByBlock.sum
+	}
+	return 0
+}
+
+// VotesFor returns the votes for blockID that have been seen, ordered by
+// validator index. Conflicting votes are only included if blockID was claimed
+// as a +2/3 majority by a peer.
+func (voteSet *VoteSet) VotesFor(blockID BlockID) []*Vote {
+	if voteSet == nil {
+		return nil
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	votesByBlock, ok := voteSet.votesByBlock[blockID.Key()]
+	if !ok {
+		return nil
+	}
+	votes := make([]*Vote, 0, len(votesByBlock.votes))
+	for _, vote := range votesByBlock.votes {
+		if vote != nil {
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
 // NOTE: if validator has conflicting votes, returns "canonical" vote
 // Implements VoteSetReader.
 func (voteSet *VoteSet) GetByIndex(valIndex int32) *Vote {
@@ -474,6 +474,44 @@ func TestVoteSet_MakeCommit(t *testing.T) {
 	}
 }
 
+func TestVoteSet_VotingPowerFor(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrevoteType, 4, 10)
+	blockID := BlockID{tmrand.Bytes(32), PartSetHeader{}}
+
+	assert.EqualValues(t, 0, voteSet.VotingPowerFor(blockID))
+
+	for i := int32(0); i < 3; i++ {
+		pubKey, err := privValidators[i].GetPubKey()
+		require.NoError(t, err)
+		vote := &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   i,
+			Height:           height,
+			Round:            round,
+			Type:             tmproto.PrevoteType,
+			Timestamp:        tmtime.Now(),
+			BlockID:          BlockID{nil, PartSetHeader{}},
+		}
+		if i > 0 {
+			vote.BlockID = blockID
+		}
+		_, err = signAddVote(privValidators[i], vote, voteSet)
+		require.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 20, voteSet.VotingPowerFor(blockID))
+	assert.EqualValues(t, 10, voteSet.VotingPowerFor(BlockID{}))
+	assert.EqualValues(t, 0, (*VoteSet)(nil).VotingPowerFor(blockID))
+
+	votes := voteSet.VotesFor(blockID)
+	require.Len(t, votes, 2)
+	assert.EqualValues(t, 1, votes[0].ValidatorIndex)
+	assert.EqualValues(t, 2, votes[1].ValidatorIndex)
+	assert.Empty(t, voteSet.VotesFor(BlockID{tmrand.Bytes(32), PartSetHeader{}}))
+	assert.Nil(t, (*VoteSet)(nil).VotesFor(blockID))
+}
+
 // NOTE: privValidators are in order
 func randVoteSet(
 	height int64,